@@ -30,6 +30,54 @@ func BenchmarkMarshaling(b *testing.B) {
 	}
 }
 
+func BenchmarkMarshalingParallel(b *testing.B) {
+	b.ReportAllocs()
+
+	data := getTestMessageData()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			msg := iso8583.NewMessage(benchmarkSpec)
+			msg.Marshal(data)
+		}
+	})
+}
+
+func BenchmarkUnmarshaling(b *testing.B) {
+	b.ReportAllocs()
+
+	b.StopTimer()
+	msg := iso8583.NewMessage(benchmarkSpec)
+	err := msg.Marshal(getTestMessageData())
+	require.NoError(b, err)
+
+	// test that we can Unmarshal without errors before starting the benchmark
+	data := &messageData{}
+	err = msg.Unmarshal(data)
+	require.NoError(b, err)
+	b.StartTimer()
+
+	for i := 0; i < b.N; i++ {
+		data := &messageData{}
+		msg.Unmarshal(data)
+	}
+}
+
+func BenchmarkUnmarshalingParallel(b *testing.B) {
+	b.ReportAllocs()
+
+	msg := iso8583.NewMessage(benchmarkSpec)
+	err := msg.Marshal(getTestMessageData())
+	require.NoError(b, err)
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			data := &messageData{}
+			msg.Unmarshal(data)
+		}
+	})
+}
+
 func BenchmarkUnpacking(b *testing.B) {
 	b.ReportAllocs()
 
@@ -54,6 +102,64 @@ func BenchmarkUnpacking(b *testing.B) {
 	}
 }
 
+func BenchmarkPacking(b *testing.B) {
+	b.ReportAllocs()
+
+	b.StopTimer()
+	msg := iso8583.NewMessage(benchmarkSpec)
+	err := msg.Marshal(getTestMessageData())
+	require.NoError(b, err)
+
+	// test that we can Pack without errors before starting the benchmark
+	_, err = msg.Pack()
+	require.NoError(b, err)
+	b.StartTimer()
+
+	for i := 0; i < b.N; i++ {
+		msg.Pack()
+	}
+}
+
+func BenchmarkAppendPacking(b *testing.B) {
+	b.ReportAllocs()
+
+	b.StopTimer()
+	msg := iso8583.NewMessage(benchmarkSpec)
+	err := msg.Marshal(getTestMessageData())
+	require.NoError(b, err)
+
+	// test that we can AppendPack without errors before starting the benchmark
+	buf, err := msg.AppendPack(nil)
+	require.NoError(b, err)
+	b.StartTimer()
+
+	for i := 0; i < b.N; i++ {
+		buf, _ = msg.AppendPack(buf[:0])
+	}
+}
+
+func BenchmarkPackedLen(b *testing.B) {
+	b.ReportAllocs()
+
+	b.StopTimer()
+	msg := iso8583.NewMessage(benchmarkSpec)
+	err := msg.Marshal(getTestMessageData())
+	require.NoError(b, err)
+
+	// PackedLen computes the length without packing the fields, so only
+	// the bitmap and the list of field IDs are allocated
+	allocs := testing.AllocsPerRun(10, func() {
+		_, err = msg.PackedLen()
+	})
+	require.NoError(b, err)
+	require.LessOrEqual(b, allocs, float64(2))
+	b.StartTimer()
+
+	for i := 0; i < b.N; i++ {
+		msg.PackedLen()
+	}
+}
+
 var benchmarkSpec *iso8583.MessageSpec = &iso8583.MessageSpec{
 	Name: "benchmark spec",
 	Fields: map[int]field.Field{
@@ -10,8 +10,11 @@ import (
 )
 
 var availableSpecs = map[string]*iso8583.MessageSpec{
-	"87ascii": specs.Spec87ASCII,
-	"87hex":   specs.Spec87Hex,
+	"87ascii":  specs.Spec87ASCII,
+	"87hex":    specs.Spec87Hex,
+	"93ascii":  specs.Spec93ASCII,
+	"93binary": specs.Spec93Binary,
+	"03ascii":  specs.Spec03ASCII,
 }
 
 func describeMessage(paths []string, spec *iso8583.MessageSpec) error {
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/moov-io/iso8583"
+)
+
+func diffMessages(pathA, pathB string, spec *iso8583.MessageSpec) error {
+	messageA, err := createMessageFromFile(pathA, spec)
+	if err != nil {
+		return fmt.Errorf("creating message from file: %w", err)
+	}
+
+	messageB, err := createMessageFromFile(pathB, spec)
+	if err != nil {
+		return fmt.Errorf("creating message from file: %w", err)
+	}
+
+	err = iso8583.DescribeDiff(iso8583.Diff(messageA, messageB), os.Stdout)
+	if err != nil {
+		return fmt.Errorf("describing diff: %w", err)
+	}
+
+	return nil
+}
+
+func Diff(paths []string, specName string) error {
+	spec := availableSpecs[specName]
+	if spec == nil {
+		return fmt.Errorf("unknown built-in spec %s", specName)
+	}
+
+	if len(paths) != 2 {
+		return fmt.Errorf("expected 2 files to compare, got %d", len(paths))
+	}
+
+	return diffMessages(paths[0], paths[1], spec)
+}
+
+func DiffWithSpecFile(paths []string, specFileName string) error {
+	spec, err := createSpecFromFile(specFileName)
+	if err != nil || spec == nil {
+		return fmt.Errorf("creating spec from file: %w", err)
+	}
+
+	if len(paths) != 2 {
+		return fmt.Errorf("expected 2 files to compare, got %d", len(paths))
+	}
+
+	return diffMessages(paths[0], paths[1], spec)
+}
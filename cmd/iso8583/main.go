@@ -13,17 +13,18 @@ import (
 var (
 	programName = filepath.Base(os.Args[0])
 	describeCmd = "describe"
+	diffCmd     = "diff"
 )
 
 func main() {
 	versionFlag := flag.Bool("version", false, "show version")
-	describeCommand := flag.NewFlagSet("describe", flag.ExitOnError)
+	describeCommand := flag.NewFlagSet(describeCmd, flag.ExitOnError)
+	diffCommand := flag.NewFlagSet(diffCmd, flag.ExitOnError)
 	flag.Usage = func() {
 		fmt.Fprintf(os.Stdout, "Work seamlessly with ISO 8583 from the command line.\n\nUsage:\n  %s <command> [flags]\n\n", programName)
 		fmt.Fprintf(os.Stdout, "Available commands:\n")
-
-		// TODO: we will print all commands when we have more than one
 		fmt.Fprintf(os.Stdout, "  %s: display ISO 8583 file in a human-readable format\n", describeCmd)
+		fmt.Fprintf(os.Stdout, "  %s: display differences between two ISO 8583 files\n", diffCmd)
 		fmt.Fprintf(os.Stdout, "\n")
 	}
 
@@ -34,6 +35,13 @@ func main() {
 		fmt.Fprintf(os.Stdout, "\n")
 	}
 
+	diffCommand.Usage = func() {
+		fmt.Fprintf(os.Stdout, "Display field differences between two ISO 8583 files.\n\nUsage:\n  %s %s [flags] <file> <file> \n\n", programName, diffCmd)
+		fmt.Fprintf(os.Stdout, "Flags: \n")
+		diffCommand.PrintDefaults()
+		fmt.Fprintf(os.Stdout, "\n")
+	}
+
 	var specNames []string
 	for name := range availableSpecs {
 		specNames = append(specNames, name)
@@ -43,6 +51,9 @@ func main() {
 	specName := describeCommand.String("spec", "87ascii", fmt.Sprintf("name of built-in spec: %s", availableSpecNames))
 	specFileName := describeCommand.String("spec-file", "", "path to customized specification file in JSON format")
 
+	diffSpecName := diffCommand.String("spec", "87ascii", fmt.Sprintf("name of built-in spec: %s", availableSpecNames))
+	diffSpecFileName := diffCommand.String("spec-file", "", "path to customized specification file in JSON format")
+
 	flag.Parse()
 
 	if *versionFlag {
@@ -82,6 +93,29 @@ func main() {
 			fmt.Fprintf(os.Stdout, "Error describing files: %s\n", err)
 			os.Exit(1)
 		}
+	case diffCmd:
+		diffCommand.Parse(os.Args[2:])
+
+		if diffCommand.NArg() != 2 {
+			diffCommand.Usage()
+			os.Exit(1)
+		}
+
+		var err error
+		if diffSpecFileName != nil && *diffSpecFileName != "" {
+			err = DiffWithSpecFile(diffCommand.Args(), *diffSpecFileName)
+		} else if availableSpecs[*diffSpecName] != nil {
+			err = Diff(diffCommand.Args(), *diffSpecName)
+		} else {
+			fmt.Fprintf(os.Stdout, "Unknown spec: %s\n\n", *diffSpecName)
+			fmt.Fprintf(os.Stdout, "Supported specs: %s\n\n", availableSpecNames)
+			os.Exit(1)
+		}
+
+		if err != nil {
+			fmt.Fprintf(os.Stdout, "Error comparing files: %s\n", err)
+			os.Exit(1)
+		}
 	default:
 		fmt.Fprintf(os.Stdout, "Uknown command: %s\n\n", command)
 		flag.Usage()
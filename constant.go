@@ -3,6 +3,11 @@ package iso8583
 // MesssageTypeIndicator message type indicator is a four-digit numeric field which indicates the overall function of the ISO 8583:1987 message
 type MesssageTypeIndicator string
 
+// Parse returns the parsed MTI. See ParseMTI for details.
+func (m MesssageTypeIndicator) Parse() (MTI, error) {
+	return ParseMTI(string(m))
+}
+
 const (
 	// AuthorizationRequest is a request from a point-of-sale terminal for authorization for a cardholder purchase
 	AuthorizationRequest MesssageTypeIndicator = "0100"
@@ -51,18 +51,25 @@ func Describe(message *Message, w io.Writer, filters ...FieldFilter) error {
 
 	tw := tabwriter.NewWriter(w, 0, 0, 2, '.', 0)
 
-	mti, err := message.GetMTI()
-	if err != nil {
-		return fmt.Errorf("getting MTI: %w", err)
-	}
-	fmt.Fprintf(tw, "MTI\t: %s\n", mti)
-
 	// use default filter
 	if len(filters) == 0 {
 		filters = DefaultFilters()
 	}
 
-	err = DescribeFieldContainer(&MessageWrapper{message}, tw, filters...)
+	if err := describeHeader(message, tw, filters...); err != nil {
+		return fmt.Errorf("describing header: %w", err)
+	}
+
+	// the bitmapless message may have no MTI
+	if _, ok := message.GetSpec().Fields[mtiIdx]; ok {
+		mti, err := message.GetMTI()
+		if err != nil {
+			return fmt.Errorf("getting MTI: %w", err)
+		}
+		fmt.Fprintf(tw, "MTI\t: %s\n", mti)
+	}
+
+	err := DescribeFieldContainer(&MessageWrapper{message}, tw, filters...)
 	if err != nil {
 		return fmt.Errorf("describing message: %w", err)
 	}
@@ -72,6 +79,35 @@ func Describe(message *Message, w io.Writer, filters ...FieldFilter) error {
 	return nil
 }
 
+// describeHeader describes the protocol header of the message if it's set.
+func describeHeader(message *Message, w io.Writer, filters ...FieldFilter) error {
+	message.mu.Lock()
+	header := message.header
+	message.mu.Unlock()
+
+	if header == nil {
+		return nil
+	}
+
+	if container, ok := header.(FieldContainer); ok {
+		fmt.Fprintf(w, "HEADER %s SUBFIELDS:\n", header.Spec().Description)
+		fmt.Fprintln(w, "-------------------------------------------")
+		err := DescribeFieldContainer(container, w, filters...)
+		fmt.Fprintln(w, "------------------------------------------")
+
+		return err
+	}
+
+	str, err := header.String()
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "Header\t: %s\n", str)
+
+	return nil
+}
+
 // DescribeFieldContainer describes the FieldContainer (e.g. Wrapped Message or CompositeField)
 func DescribeFieldContainer(container FieldContainer, w io.Writer, filters ...FieldFilter) error {
 	// making filter map
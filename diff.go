@@ -0,0 +1,186 @@
+package iso8583
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/moov-io/iso8583/field"
+)
+
+// DifferenceType describes how the field differs between two messages.
+type DifferenceType string
+
+const (
+	// FieldAdded means that the field is set only in the second message
+	FieldAdded DifferenceType = "added"
+	// FieldRemoved means that the field is set only in the first message
+	FieldRemoved DifferenceType = "removed"
+	// FieldChanged means that the field is set in both messages but its
+	// values are different
+	FieldChanged DifferenceType = "changed"
+)
+
+// FieldDifference describes the difference of a single field (or subfield)
+// between two messages.
+type FieldDifference struct {
+	// Path is the dot-separated path of the field, e.g. "2", "3.1" or
+	// "55.9F02"
+	Path string
+	Type DifferenceType
+
+	// A and B are the fields of the first and the second message. One of
+	// them is nil when the field was added or removed.
+	A field.Field
+	B field.Field
+
+	// ValueA and ValueB are string representations of the field values
+	ValueA string
+	ValueB string
+}
+
+// Diff compares two messages field by field and returns the list of
+// differences sorted by field path. Composite fields (including TLV and
+// bitmapped composites) are compared subfield by subfield. Bitmaps are not
+// compared, as they reflect the set of fields that are already compared.
+func Diff(a, b *Message) []FieldDifference {
+	leavesA := map[string]field.Field{}
+	leavesB := map[string]field.Field{}
+
+	if a != nil {
+		collectLeafFields(&MessageWrapper{a}, "", leavesA)
+	}
+
+	if b != nil {
+		collectLeafFields(&MessageWrapper{b}, "", leavesB)
+	}
+
+	paths := make([]string, 0, len(leavesA)+len(leavesB))
+	for path := range leavesA {
+		paths = append(paths, path)
+	}
+	for path := range leavesB {
+		if _, ok := leavesA[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+	sortFieldPaths(paths)
+
+	var diffs []FieldDifference
+
+	for _, path := range paths {
+		fieldA, fieldB := leavesA[path], leavesB[path]
+
+		diff := FieldDifference{
+			Path: path,
+			A:    fieldA,
+			B:    fieldB,
+		}
+
+		switch {
+		case fieldA == nil:
+			diff.Type = FieldAdded
+			diff.ValueB = fieldValue(fieldB)
+		case fieldB == nil:
+			diff.Type = FieldRemoved
+			diff.ValueA = fieldValue(fieldA)
+		default:
+			diff.ValueA = fieldValue(fieldA)
+			diff.ValueB = fieldValue(fieldB)
+			if diff.ValueA == diff.ValueB {
+				continue
+			}
+			diff.Type = FieldChanged
+		}
+
+		diffs = append(diffs, diff)
+	}
+
+	return diffs
+}
+
+// collectLeafFields recursively walks the container and stores all
+// non-container fields (except bitmaps) in result keyed by their paths.
+func collectLeafFields(container FieldContainer, prefix string, result map[string]field.Field) {
+	for tag, f := range container.GetSubfields() {
+		if _, ok := f.(*field.Bitmap); ok {
+			continue
+		}
+
+		path := tag
+		if prefix != "" {
+			path = prefix + "." + tag
+		}
+
+		if subContainer, ok := f.(FieldContainer); ok {
+			collectLeafFields(subContainer, path, result)
+			continue
+		}
+
+		result[path] = f
+	}
+}
+
+func fieldValue(f field.Field) string {
+	str, err := f.String()
+	if err != nil {
+		return fmt.Sprintf("<error: %v>", err)
+	}
+
+	return str
+}
+
+// DescribeDiff writes differences in a human-readable format. Filters are
+// applied to the values of the fields using the full field path (e.g. "2" or
+// "55.9F02"). If no filters are provided, DefaultFilters are used.
+func DescribeDiff(diffs []FieldDifference, w io.Writer, filters ...FieldFilter) error {
+	if len(filters) == 0 {
+		filters = DefaultFilters()
+	}
+
+	filterMap := make(map[string]FilterFunc)
+	for _, filter := range filters {
+		filter(filterMap)
+	}
+
+	if len(diffs) == 0 {
+		fmt.Fprintln(w, "Messages are equal")
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, '.', 0)
+
+	for _, diff := range diffs {
+		valueA, valueB := diff.ValueA, diff.ValueB
+		if filter, ok := filterMap[diff.Path]; ok {
+			if diff.A != nil {
+				valueA = filter(valueA, diff.A)
+			}
+			if diff.B != nil {
+				valueB = filter(valueB, diff.B)
+			}
+		}
+
+		desc := ""
+		if f := diff.A; f != nil && f.Spec() != nil {
+			desc = f.Spec().Description
+		} else if f := diff.B; f != nil && f.Spec() != nil {
+			desc = f.Spec().Description
+		}
+
+		var change string
+		switch diff.Type {
+		case FieldAdded:
+			change = fmt.Sprintf("+ %s", valueB)
+		case FieldRemoved:
+			change = fmt.Sprintf("- %s", valueA)
+		case FieldChanged:
+			change = fmt.Sprintf("%s -> %s", valueA, valueB)
+		}
+
+		fmt.Fprintf(tw, "F%-7s %s\t: %s %s\n", diff.Path, strings.TrimSpace(desc), strings.ToUpper(string(diff.Type)), change)
+	}
+
+	return tw.Flush()
+}
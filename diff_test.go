@@ -0,0 +1,215 @@
+package iso8583
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/moov-io/iso8583/encoding"
+	"github.com/moov-io/iso8583/field"
+	"github.com/moov-io/iso8583/prefix"
+	"github.com/moov-io/iso8583/sort"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	spec := &MessageSpec{
+		Fields: map[int]field.Field{
+			0: field.NewString(&field.Spec{
+				Length:      4,
+				Description: "Message Type Indicator",
+				Enc:         encoding.ASCII,
+				Pref:        prefix.ASCII.Fixed,
+			}),
+			1: field.NewBitmap(&field.Spec{
+				Description: "Bitmap",
+				Enc:         encoding.BytesToASCIIHex,
+				Pref:        prefix.Hex.Fixed,
+			}),
+			2: field.NewString(&field.Spec{
+				Length:      19,
+				Description: "Primary Account Number",
+				Enc:         encoding.ASCII,
+				Pref:        prefix.ASCII.LL,
+			}),
+			3: field.NewComposite(&field.Spec{
+				Length:      6,
+				Description: "Processing Code",
+				Pref:        prefix.ASCII.Fixed,
+				Tag: &field.TagSpec{
+					Sort: sort.StringsByInt,
+				},
+				Subfields: map[string]field.Field{
+					"1": field.NewString(&field.Spec{
+						Length:      2,
+						Description: "Transaction Type",
+						Enc:         encoding.ASCII,
+						Pref:        prefix.ASCII.Fixed,
+					}),
+					"2": field.NewString(&field.Spec{
+						Length:      2,
+						Description: "From Account",
+						Enc:         encoding.ASCII,
+						Pref:        prefix.ASCII.Fixed,
+					}),
+					"3": field.NewString(&field.Spec{
+						Length:      2,
+						Description: "To Account",
+						Enc:         encoding.ASCII,
+						Pref:        prefix.ASCII.Fixed,
+					}),
+				},
+			}),
+			11: field.NewString(&field.Spec{
+				Length:      6,
+				Description: "Systems Trace Audit Number (STAN)",
+				Enc:         encoding.ASCII,
+				Pref:        prefix.ASCII.Fixed,
+			}),
+			48: field.NewComposite(&field.Spec{
+				Length:      999,
+				Description: "Additional Data",
+				Pref:        prefix.ASCII.LLL,
+				Bitmap: field.NewBitmap(&field.Spec{
+					Length:            8,
+					Description:       "Bitmap",
+					Enc:               encoding.BytesToASCIIHex,
+					Pref:              prefix.Hex.Fixed,
+					DisableAutoExpand: true,
+				}),
+				Subfields: map[string]field.Field{
+					"1": field.NewString(&field.Spec{
+						Length:      2,
+						Description: "Subfield 1",
+						Enc:         encoding.ASCII,
+						Pref:        prefix.ASCII.LL,
+					}),
+					"2": field.NewString(&field.Spec{
+						Length:      2,
+						Description: "Subfield 2",
+						Enc:         encoding.ASCII,
+						Pref:        prefix.ASCII.LL,
+					}),
+				},
+			}),
+			55: field.NewComposite(&field.Spec{
+				Length:      999,
+				Description: "ICC Data",
+				Pref:        prefix.ASCII.LLL,
+				Tag: &field.TagSpec{
+					Enc:  encoding.BerTLVTag,
+					Sort: sort.StringsByHex,
+				},
+				Subfields: map[string]field.Field{
+					"9A": field.NewHex(&field.Spec{
+						Description: "Transaction Date",
+						Enc:         encoding.Binary,
+						Pref:        prefix.BerTLV,
+					}),
+					"9F02": field.NewHex(&field.Spec{
+						Description: "Amount, Authorized (Numeric)",
+						Enc:         encoding.Binary,
+						Pref:        prefix.BerTLV,
+					}),
+				},
+			}),
+		},
+	}
+
+	newMessage := func(t *testing.T, fields map[string]string) *Message {
+		t.Helper()
+
+		message := NewMessage(spec)
+		for path, value := range fields {
+			require.NoError(t, message.MarshalPath(path, value))
+		}
+
+		// pack and unpack to make sure bitmaps are not compared
+		packed, err := message.Pack()
+		require.NoError(t, err)
+
+		unpacked := NewMessage(spec)
+		require.NoError(t, unpacked.Unpack(packed))
+
+		return unpacked
+	}
+
+	t.Run("returns no differences for equal messages", func(t *testing.T) {
+		fields := map[string]string{
+			"0":       "0100",
+			"2":       "4242424242424242",
+			"3.1":     "00",
+			"3.2":     "20",
+			"3.3":     "00",
+			"48.1":    "AB",
+			"55.9F02": "000000000100",
+		}
+
+		require.Empty(t, Diff(newMessage(t, fields), newMessage(t, fields)))
+	})
+
+	t.Run("returns added, removed and changed fields and subfields", func(t *testing.T) {
+		a := newMessage(t, map[string]string{
+			"0":       "0100",
+			"2":       "4242424242424242",
+			"3.1":     "00",
+			"3.2":     "20",
+			"3.3":     "00",
+			"48.1":    "AB",
+			"55.9A":   "210720",
+			"55.9F02": "000000000100",
+		})
+
+		b := newMessage(t, map[string]string{
+			"0":       "0110",
+			"2":       "4242424242424242",
+			"3.1":     "00",
+			"3.2":     "30",
+			"3.3":     "00",
+			"11":      "123456",
+			"48.2":    "CD",
+			"55.9F02": "000000000200",
+		})
+
+		diffs := Diff(a, b)
+
+		var got [][3]string
+		for _, diff := range diffs {
+			got = append(got, [3]string{diff.Path, string(diff.Type), diff.ValueA + "|" + diff.ValueB})
+		}
+
+		require.Equal(t, [][3]string{
+			{"0", "changed", "0100|0110"},
+			{"3.2", "changed", "20|30"},
+			{"11", "added", "|123456"},
+			{"48.1", "removed", "AB|"},
+			{"48.2", "added", "|CD"},
+			{"55.9A", "removed", "210720|"},
+			{"55.9F02", "changed", "000000000100|000000000200"},
+		}, got)
+	})
+
+	t.Run("DescribeDiff applies filters to values", func(t *testing.T) {
+		a := newMessage(t, map[string]string{
+			"0": "0100",
+			"2": "4242424242424242",
+		})
+
+		b := newMessage(t, map[string]string{
+			"0":  "0100",
+			"2":  "5555555555554444",
+			"11": "123456",
+		})
+
+		out := bytes.NewBuffer(nil)
+		require.NoError(t, DescribeDiff(Diff(a, b), out))
+
+		expected := `F2       Primary Account Number.............: CHANGED 4242****4242 -> 5555****4444
+F11      Systems Trace Audit Number (STAN)..: ADDED + 123456
+`
+		require.Equal(t, expected, out.String())
+
+		out.Reset()
+		require.NoError(t, DescribeDiff(nil, out))
+		require.Equal(t, "Messages are equal\n", out.String())
+	})
+}
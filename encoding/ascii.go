@@ -7,8 +7,9 @@ import (
 )
 
 var (
-	_     Encoder = (*asciiEncoder)(nil)
-	ASCII         = &asciiEncoder{}
+	_     Encoder  = (*asciiEncoder)(nil)
+	_     Appender = (*asciiEncoder)(nil)
+	ASCII          = &asciiEncoder{}
 )
 
 type asciiEncoder struct{}
@@ -25,6 +26,20 @@ func (e asciiEncoder) Encode(data []byte) ([]byte, error) {
 	return out, nil
 }
 
+func (e asciiEncoder) AppendEncode(dst, src []byte) ([]byte, error) {
+	for _, r := range src {
+		if r > 127 {
+			return nil, utils.NewSafeError(fmt.Errorf("invalid ASCII char: '%s'", string(r)), "failed to perform ASCII encoding")
+		}
+	}
+
+	return append(dst, src...), nil
+}
+
+func (e asciiEncoder) EncodedLen(n int) int {
+	return n
+}
+
 func (e asciiEncoder) Decode(data []byte, length int) ([]byte, int, error) {
 	// length should be positive
 	if length < 0 {
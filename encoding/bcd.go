@@ -8,8 +8,9 @@ import (
 )
 
 var (
-	_   Encoder = (*bcdEncoder)(nil)
-	BCD         = &bcdEncoder{}
+	_   Encoder  = (*bcdEncoder)(nil)
+	_   Appender = (*bcdEncoder)(nil)
+	BCD          = &bcdEncoder{}
 )
 
 type bcdEncoder struct{}
@@ -29,6 +30,34 @@ func (e *bcdEncoder) Encode(src []byte) ([]byte, error) {
 	return dst[:n], nil
 }
 
+// AppendEncode appends BCD encoded digits to dst. As Encode, it left pads odd
+// number of digits with 0.
+func (e *bcdEncoder) AppendEncode(dst, src []byte) ([]byte, error) {
+	// odd number of digits is left padded with 0, so the first byte holds
+	// only one digit
+	hi := len(src)%2 == 0
+
+	var b byte
+	for _, c := range src {
+		if c < '0' || c > '9' {
+			return nil, utils.NewSafeError(fmt.Errorf("invalid BCD digit: %q", c), "failed to perform BCD encoding")
+		}
+
+		if hi {
+			b = (c - '0') << 4
+		} else {
+			dst = append(dst, b|(c-'0'))
+		}
+		hi = !hi
+	}
+
+	return dst, nil
+}
+
+func (e *bcdEncoder) EncodedLen(n int) int {
+	return bcd.EncodedLen(n)
+}
+
 func (e *bcdEncoder) Decode(src []byte, length int) ([]byte, int, error) {
 	// length should be positive
 	if length < 0 {
@@ -5,8 +5,9 @@ import (
 )
 
 var (
-	_      Encoder = (*binaryEncoder)(nil)
-	Binary         = &binaryEncoder{}
+	_      Encoder  = (*binaryEncoder)(nil)
+	_      Appender = (*binaryEncoder)(nil)
+	Binary          = &binaryEncoder{}
 )
 
 type binaryEncoder struct{}
@@ -17,6 +18,14 @@ func (e binaryEncoder) Encode(data []byte) ([]byte, error) {
 	return out, nil
 }
 
+func (e binaryEncoder) AppendEncode(dst, src []byte) ([]byte, error) {
+	return append(dst, src...), nil
+}
+
+func (e binaryEncoder) EncodedLen(n int) int {
+	return n
+}
+
 func (e binaryEncoder) Decode(data []byte, length int) ([]byte, int, error) {
 	if length < 0 {
 		return nil, 0, fmt.Errorf("length should be positive, got %d", length)
@@ -76,8 +76,9 @@ var (
 )
 
 var (
-	_      Encoder = (*ebcdicEncoder)(nil)
-	EBCDIC         = &ebcdicEncoder{}
+	_      Encoder  = (*ebcdicEncoder)(nil)
+	_      Appender = (*ebcdicEncoder)(nil)
+	EBCDIC          = &ebcdicEncoder{}
 )
 
 type ebcdicEncoder struct{}
@@ -90,6 +91,17 @@ func (e *ebcdicEncoder) Encode(src []byte) ([]byte, error) {
 	return dst, nil
 }
 
+func (e *ebcdicEncoder) AppendEncode(dst, src []byte) ([]byte, error) {
+	for _, v := range src {
+		dst = append(dst, asciiToEbcdic[v])
+	}
+	return dst, nil
+}
+
+func (e *ebcdicEncoder) EncodedLen(n int) int {
+	return n
+}
+
 func (e *ebcdicEncoder) Decode(src []byte, length int) ([]byte, int, error) {
 	if length < 0 {
 		return nil, 0, fmt.Errorf("length should be positive, got %d", length)
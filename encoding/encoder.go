@@ -10,3 +10,16 @@ type Encoder interface {
 	// number of bytes read from the input, and any error
 	Decode([]byte, int) (data []byte, read int, err error)
 }
+
+// Appender is an optional interface implemented by encoders that can append
+// encoded data to the destination slice instead of allocating a new one. It
+// is used by fields to pack data into a buffer provided by the caller.
+type Appender interface {
+	// AppendEncode appends encoded source data to dst and returns the
+	// extended slice and any error
+	AppendEncode(dst, src []byte) ([]byte, error)
+
+	// EncodedLen returns the number of bytes the source data of length n
+	// will be encoded into
+	EncodedLen(n int) int
+}
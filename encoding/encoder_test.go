@@ -0,0 +1,49 @@
+package encoding
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppendEncode(t *testing.T) {
+	tests := []struct {
+		name    string
+		encoder Encoder
+		src     []byte
+	}{
+		{"ASCII", ASCII, []byte("hello")},
+		{"Binary", Binary, []byte{0x00, 0xAB, 0xFF}},
+		{"BytesToASCIIHex", BytesToASCIIHex, []byte{0x5F, 0x2A, 0x0b}},
+		{"EBCDIC", EBCDIC, []byte("hello 123")},
+		{"BCD even", BCD, []byte("1234")},
+		{"BCD odd", BCD, []byte("123")},
+		{"BCD empty", BCD, []byte{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			appender, ok := tt.encoder.(Appender)
+			require.True(t, ok)
+
+			encoded, err := tt.encoder.Encode(tt.src)
+			require.NoError(t, err)
+
+			prefix := []byte("prefix")
+			appended, err := appender.AppendEncode(prefix, tt.src)
+			require.NoError(t, err)
+
+			require.Equal(t, "prefix", string(appended[:len(prefix)]))
+			require.Equal(t, string(encoded), string(appended[len(prefix):]))
+			require.Equal(t, len(encoded), appender.EncodedLen(len(tt.src)))
+		})
+	}
+
+	t.Run("returns errors of invalid data", func(t *testing.T) {
+		_, err := ASCII.AppendEncode(nil, []byte("hello, 世界!"))
+		require.EqualError(t, err, "failed to perform ASCII encoding")
+
+		_, err = BCD.AppendEncode(nil, []byte("12A4"))
+		require.EqualError(t, err, "failed to perform BCD encoding")
+	})
+}
@@ -11,7 +11,8 @@ import (
 
 // HEX to ASCII encoder
 var (
-	_ Encoder = (*hexToASCIIEncoder)(nil)
+	_ Encoder  = (*hexToASCIIEncoder)(nil)
+	_ Appender = (*hexToASCIIEncoder)(nil)
 	// BytesToASCIIHex is an encoder that converts bytes into their ASCII
 	// representation.  On success, the ASCII representation bytes are returned
 	// Don't use this encoder with String, Numeric or Binary fields as packing and
@@ -35,6 +36,22 @@ func (e hexToASCIIEncoder) Encode(data []byte) ([]byte, error) {
 	return []byte(str), nil
 }
 
+// AppendEncode appends the upper case ASCII representation of the bytes to
+// dst, e.g. []byte{0x5F, 0x2A} would be appended as []byte("5F2A")
+func (e hexToASCIIEncoder) AppendEncode(dst, src []byte) ([]byte, error) {
+	const upperHex = "0123456789ABCDEF"
+
+	for _, b := range src {
+		dst = append(dst, upperHex[b>>4], upperHex[b&0x0f])
+	}
+
+	return dst, nil
+}
+
+func (e hexToASCIIEncoder) EncodedLen(n int) int {
+	return hex.EncodedLen(n)
+}
+
 // Decodes ASCII hex and returns bytes
 // length is number of HEX-digits (two ASCII characters is one HEX digit)
 // e.g. []byte("AABBCC") would be converted into []byte{0xAA, 0xBB, 0xCC}
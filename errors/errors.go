@@ -2,6 +2,8 @@ package errors
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 )
 
 // UnpackError returns an error with the possibility to access the RawMessage when
@@ -37,6 +39,27 @@ func (e *UnpackError) FieldIDs() []string {
 	return fieldIDs
 }
 
+// UnpackErrors returns all unpack errors joined in err (e.g. by the lenient
+// unpacking) in the order they were joined. If err is (or wraps) a single
+// *UnpackError, it's returned as the only element.
+func UnpackErrors(err error) []*UnpackError {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		var unpackErrors []*UnpackError
+		for _, e := range joined.Unwrap() {
+			unpackErrors = append(unpackErrors, UnpackErrors(e)...)
+		}
+
+		return unpackErrors
+	}
+
+	var unpackError *UnpackError
+	if errors.As(err, &unpackError) {
+		return []*UnpackError{unpackError}
+	}
+
+	return nil
+}
+
 type PackError struct {
 	Err error
 }
@@ -48,3 +71,40 @@ func (e *PackError) Error() string {
 func (e *PackError) Unwrap() error {
 	return e.Err
 }
+
+// ValidationError is returned when the message does not satisfy the rules
+// defined in its spec. It holds all violations found in the message.
+type ValidationError struct {
+	MTI        string
+	Violations []*FieldViolation
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		msgs = append(msgs, v.Error())
+	}
+
+	return fmt.Sprintf("message %s is invalid: %s", e.MTI, strings.Join(msgs, "; "))
+}
+
+// FieldIDs returns the paths of the fields that violate the rules
+func (e *ValidationError) FieldIDs() []string {
+	fieldIDs := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		fieldIDs = append(fieldIDs, v.FieldID)
+	}
+
+	return fieldIDs
+}
+
+// FieldViolation describes a single rule violation. FieldID is the
+// dot-separated path of the field (e.g. "11" or "48.1").
+type FieldViolation struct {
+	FieldID string
+	Reason  string
+}
+
+func (v *FieldViolation) Error() string {
+	return fmt.Sprintf("field %s: %s", v.FieldID, v.Reason)
+}
@@ -0,0 +1,481 @@
+package field
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/moov-io/iso8583/utils"
+)
+
+var (
+	_ Field            = (*Amount)(nil)
+	_ json.Marshaler   = (*Amount)(nil)
+	_ json.Unmarshaler = (*Amount)(nil)
+)
+
+// SignIndicator defines how the sign of the amount is packed.
+type SignIndicator string
+
+const (
+	// SignNone is used for amounts without the sign indicator. Such amounts
+	// can't be negative.
+	SignNone SignIndicator = ""
+
+	// SignCreditDebit is used for amounts prefixed with C (credit, positive
+	// amount) or D (debit, negative amount), e.g. field 28 (x+n 8).
+	SignCreditDebit SignIndicator = "C/D"
+
+	// SignPlusMinus is used for amounts prefixed with + or -.
+	SignPlusMinus SignIndicator = "+/-"
+)
+
+// AmountSpec defines the sign indicator and the currency exponent of the
+// amount field.
+type AmountSpec struct {
+	// Sign defines the indicator of the sign packed before the digits of
+	// the amount. The indicator is included in the Length of the field.
+	Sign SignIndicator
+	// Exponent is the default ISO 4217 currency exponent (number of digits
+	// of the minor unit) used to convert the amount to and from decimal
+	// values. It can be changed for the instance of the field with
+	// SetExponent, e.g. using the currency of field 49 and
+	// CurrencyExponent.
+	Exponent int
+}
+
+// MinorUnitsMarshaler is implemented by money types that can be marshaled
+// into the Amount field.
+type MinorUnitsMarshaler interface {
+	// MarshalMinorUnits returns the signed amount in minor units of the
+	// currency with the given exponent.
+	MarshalMinorUnits(exponent int) (int64, error)
+}
+
+// MinorUnitsUnmarshaler is implemented by money types the Amount field can
+// be unmarshaled into.
+type MinorUnitsUnmarshaler interface {
+	// UnmarshalMinorUnits sets the signed amount in minor units of the
+	// currency with the given exponent.
+	UnmarshalMinorUnits(value int64, exponent int) error
+}
+
+// Amount is the numeric field that holds the signed amount in minor units of
+// the currency, e.g. 12345 for 123.45 USD. Depending on Spec.Amount, the
+// amount is packed with the C/D or +/- sign indicator before the digits.
+// If the spec has padding, only the digits of the amount are padded.
+//
+// The amount can be marshaled from and unmarshaled into int64 (minor
+// units), decimal string (e.g. "-123.45") using the currency exponent, or
+// the money types that implement MinorUnitsMarshaler and
+// MinorUnitsUnmarshaler.
+type Amount struct {
+	value    int64
+	exponent int
+	spec     *Spec
+}
+
+// NewAmount creates the Amount field with the given spec.
+func NewAmount(spec *Spec) *Amount {
+	f := &Amount{}
+	f.SetSpec(spec)
+
+	return f
+}
+
+// NewAmountValue creates the Amount field with the given value in minor
+// units.
+func NewAmountValue(val int64) *Amount {
+	return &Amount{
+		value: val,
+	}
+}
+
+// NewInstance creates a new instance of the Amount field with the same Spec.
+func (f *Amount) NewInstance() Field {
+	return NewAmount(f.spec)
+}
+
+func (f *Amount) Spec() *Spec {
+	return f.spec
+}
+
+// SetSpec sets the spec of the field and its exponent to the default
+// exponent of the spec.
+func (f *Amount) SetSpec(spec *Spec) {
+	f.spec = spec
+
+	if spec != nil && spec.Amount != nil {
+		f.exponent = spec.Amount.Exponent
+	}
+}
+
+// Value returns the signed amount in minor units.
+func (f *Amount) Value() int64 {
+	if f == nil {
+		return 0
+	}
+	return f.value
+}
+
+// SetValue sets the signed amount in minor units.
+func (f *Amount) SetValue(v int64) {
+	f.value = v
+}
+
+// Exponent returns the currency exponent used to convert the amount to and
+// from decimal values.
+func (f *Amount) Exponent() int {
+	return f.exponent
+}
+
+// SetExponent sets the currency exponent used to convert the amount to and
+// from decimal values, e.g. the exponent of the currency of the message.
+func (f *Amount) SetExponent(exponent int) {
+	f.exponent = exponent
+}
+
+// Decimal returns the amount as decimal string using the currency exponent,
+// e.g. "-123.45" for -12345 with exponent 2.
+func (f *Amount) Decimal() string {
+	return formatDecimal(f.value, f.exponent)
+}
+
+func (f *Amount) sign() SignIndicator {
+	if f.spec == nil || f.spec.Amount == nil {
+		return SignNone
+	}
+
+	return f.spec.Amount.Sign
+}
+
+// Bytes returns the sign indicator (if any) followed by the digits of the
+// amount.
+func (f *Amount) Bytes() ([]byte, error) {
+	if f == nil {
+		return nil, nil
+	}
+
+	indicator, digits, err := f.format()
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(indicator + digits), nil
+}
+
+// format returns the sign indicator and the digits of the amount.
+func (f *Amount) format() (string, string, error) {
+	negative := f.value < 0
+	digits := strconv.FormatUint(absInt64(f.value), 10)
+
+	switch f.sign() {
+	case SignCreditDebit:
+		if negative {
+			return "D", digits, nil
+		}
+		return "C", digits, nil
+	case SignPlusMinus:
+		if negative {
+			return "-", digits, nil
+		}
+		return "+", digits, nil
+	}
+
+	if negative {
+		return "", "", errors.New("negative amount requires sign indicator")
+	}
+
+	return "", digits, nil
+}
+
+func (f *Amount) String() (string, error) {
+	b, err := f.Bytes()
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+// SetBytes parses the sign indicator (if any) and the digits of the amount.
+func (f *Amount) SetBytes(b []byte) error {
+	value := string(b)
+	negative := false
+
+	switch f.sign() {
+	case SignCreditDebit, SignPlusMinus:
+		if len(value) == 0 {
+			return errors.New("missing sign indicator")
+		}
+
+		switch indicator := value[0]; {
+		case f.sign() == SignCreditDebit && indicator == 'D',
+			f.sign() == SignPlusMinus && indicator == '-':
+			negative = true
+		case f.sign() == SignCreditDebit && indicator == 'C',
+			f.sign() == SignPlusMinus && indicator == '+':
+		default:
+			return fmt.Errorf("invalid sign indicator %q, expected %s", indicator, f.sign())
+		}
+
+		value = value[1:]
+	}
+
+	// for the amount 0 left-padded with '0' the digits may be empty
+	if value == "" {
+		f.value = 0
+		return nil
+	}
+
+	for _, c := range value {
+		if c < '0' || c > '9' {
+			return errors.New("invalid amount digits")
+		}
+	}
+
+	val, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return utils.NewSafeError(err, "failed to convert into number")
+	}
+
+	if negative {
+		val = -val
+	}
+
+	f.value = val
+
+	return nil
+}
+
+// Pack packs the sign indicator and the digits of the amount. If the spec
+// has padding, the digits are padded to the length of the field without the
+// sign indicator.
+func (f *Amount) Pack() ([]byte, error) {
+	indicator, digits, err := f.format()
+	if err != nil {
+		return nil, err
+	}
+
+	if f.spec.Pad != nil {
+		digits = string(f.spec.Pad.Pad([]byte(digits), f.spec.Length-len(indicator)))
+	}
+
+	packer := f.spec.getPacker()
+
+	return packer.Pack([]byte(indicator+digits), f.spec)
+}
+
+// Unpack unpacks the amount and returns the number of bytes read.
+func (f *Amount) Unpack(data []byte) (int, error) {
+	unpacker := f.spec.getUnpacker()
+
+	raw, bytesRead, err := unpacker.Unpack(data, f.spec)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := f.SetBytes(raw); err != nil {
+		return 0, fmt.Errorf("failed to set bytes: %w", err)
+	}
+
+	return bytesRead, nil
+}
+
+// Deprecated. Use Marshal instead
+func (f *Amount) SetData(data interface{}) error {
+	return f.Marshal(data)
+}
+
+// Unmarshal sets the amount into v. The amount is set in minor units into
+// int64, as decimal string into string and using UnmarshalMinorUnits into
+// money types.
+func (f *Amount) Unmarshal(v interface{}) error {
+	switch val := v.(type) {
+	case reflect.Value:
+		if !val.CanSet() {
+			return fmt.Errorf("cannot set reflect.Value of type %s", val.Kind())
+		}
+
+		if val.CanAddr() {
+			if u, ok := val.Addr().Interface().(MinorUnitsUnmarshaler); ok {
+				return u.UnmarshalMinorUnits(f.value, f.exponent)
+			}
+		}
+
+		switch val.Kind() { //nolint:exhaustive
+		case reflect.String:
+			val.SetString(f.Decimal())
+		case reflect.Int64:
+			val.SetInt(f.value)
+		default:
+			return fmt.Errorf("unsupported reflect.Value type: %s", val.Kind())
+		}
+	case *string:
+		*val = f.Decimal()
+	case *int64:
+		*val = f.value
+	case *Amount:
+		val.value = f.value
+		val.exponent = f.exponent
+	case MinorUnitsUnmarshaler:
+		return val.UnmarshalMinorUnits(f.value, f.exponent)
+	default:
+		return fmt.Errorf("unsupported type: expected *Amount, *int64, *string, MinorUnitsUnmarshaler or reflect.Value, got %T", v)
+	}
+
+	return nil
+}
+
+// Marshal sets the amount from v: int64 in minor units, decimal string
+// converted using the currency exponent, or the money type that implements
+// MinorUnitsMarshaler.
+func (f *Amount) Marshal(v any) error {
+	if v == nil || reflect.ValueOf(v).IsZero() {
+		f.value = 0
+		return nil
+	}
+
+	switch v := v.(type) {
+	case *Amount:
+		f.value = v.value
+	case int64:
+		f.value = v
+	case *int64:
+		f.value = *v
+	case string:
+		return f.marshalDecimal(v)
+	case *string:
+		return f.marshalDecimal(*v)
+	case MinorUnitsMarshaler:
+		return f.marshalMinorUnits(v)
+	default:
+		// money types may implement MinorUnitsMarshaler with the pointer
+		// receiver
+		ptr := reflect.New(reflect.TypeOf(v))
+		ptr.Elem().Set(reflect.ValueOf(v))
+
+		if m, ok := ptr.Interface().(MinorUnitsMarshaler); ok {
+			return f.marshalMinorUnits(m)
+		}
+
+		return fmt.Errorf("data does not match required *Amount, (int64, *int64, string, *string) or MinorUnitsMarshaler type")
+	}
+
+	return nil
+}
+
+func (f *Amount) marshalDecimal(s string) error {
+	val, err := parseDecimal(s, f.exponent)
+	if err != nil {
+		return err
+	}
+
+	f.value = val
+
+	return nil
+}
+
+func (f *Amount) marshalMinorUnits(m MinorUnitsMarshaler) error {
+	val, err := m.MarshalMinorUnits(f.exponent)
+	if err != nil {
+		return fmt.Errorf("marshalling minor units: %w", err)
+	}
+
+	f.value = val
+
+	return nil
+}
+
+func (f *Amount) MarshalJSON() ([]byte, error) {
+	bytes, err := json.Marshal(f.value)
+	if err != nil {
+		return nil, utils.NewSafeError(err, "failed to JSON marshal int to bytes")
+	}
+	return bytes, nil
+}
+
+func (f *Amount) UnmarshalJSON(b []byte) error {
+	var v int64
+	err := json.Unmarshal(b, &v)
+	if err != nil {
+		return utils.NewSafeError(err, "failed to JSON unmarshal bytes to int")
+	}
+
+	f.value = v
+
+	return nil
+}
+
+// absInt64 returns the absolute value of v, which fits into uint64 even
+// for math.MinInt64.
+func absInt64(v int64) uint64 {
+	if v < 0 {
+		return ^uint64(v) + 1
+	}
+
+	return uint64(v)
+}
+
+// formatDecimal formats the value in minor units as decimal string with
+// exponent digits after the decimal point.
+func formatDecimal(value int64, exponent int) string {
+	digits := strconv.FormatUint(absInt64(value), 10)
+
+	if exponent > 0 {
+		if len(digits) <= exponent {
+			digits = strings.Repeat("0", exponent-len(digits)+1) + digits
+		}
+
+		digits = digits[:len(digits)-exponent] + "." + digits[len(digits)-exponent:]
+	}
+
+	if value < 0 {
+		return "-" + digits
+	}
+
+	return digits
+}
+
+// parseDecimal parses the decimal string into the value in minor units of
+// the currency with the given exponent.
+func parseDecimal(s string, exponent int) (int64, error) {
+	value := s
+	negative := false
+
+	if value != "" && (value[0] == '-' || value[0] == '+') {
+		negative = value[0] == '-'
+		value = value[1:]
+	}
+
+	intPart, fracPart, _ := strings.Cut(value, ".")
+
+	if intPart == "" && fracPart == "" {
+		return 0, errors.New("invalid decimal amount")
+	}
+
+	if len(fracPart) > exponent {
+		return 0, fmt.Errorf("decimal amount has more than %d digits after the decimal point", exponent)
+	}
+
+	digits := intPart + fracPart + strings.Repeat("0", exponent-len(fracPart))
+	for _, c := range digits {
+		if c < '0' || c > '9' {
+			return 0, errors.New("invalid decimal amount")
+		}
+	}
+
+	val, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return 0, utils.NewSafeError(err, "failed to convert decimal amount into number")
+	}
+
+	if negative {
+		val = -val
+	}
+
+	return val, nil
+}
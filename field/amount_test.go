@@ -0,0 +1,184 @@
+package field
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/moov-io/iso8583/encoding"
+	"github.com/moov-io/iso8583/padding"
+	"github.com/moov-io/iso8583/prefix"
+	"github.com/stretchr/testify/require"
+)
+
+// money is the user money type used to test the Amount field
+type money struct {
+	Cents    int64
+	Exponent int
+}
+
+func (m money) MarshalMinorUnits(exponent int) (int64, error) {
+	if exponent != 2 {
+		return 0, errors.New("unsupported exponent")
+	}
+	return m.Cents, nil
+}
+
+func (m *money) UnmarshalMinorUnits(value int64, exponent int) error {
+	m.Cents = value
+	m.Exponent = exponent
+	return nil
+}
+
+func TestAmount(t *testing.T) {
+	// field 28 (x+n 8)
+	spec := &Spec{
+		Length:      9,
+		Description: "Transaction Fee Amount",
+		Enc:         encoding.ASCII,
+		Pref:        prefix.ASCII.Fixed,
+		Pad:         padding.Left('0'),
+		Amount: &AmountSpec{
+			Sign:     SignCreditDebit,
+			Exponent: 2,
+		},
+	}
+
+	t.Run("Pack packs sign indicator and padded digits", func(t *testing.T) {
+		f := NewAmount(spec)
+
+		f.SetValue(-1234)
+		packed, err := f.Pack()
+		require.NoError(t, err)
+		require.Equal(t, "D00001234", string(packed))
+
+		f.SetValue(1234)
+		packed, err = f.Pack()
+		require.NoError(t, err)
+		require.Equal(t, "C00001234", string(packed))
+
+		str, err := f.String()
+		require.NoError(t, err)
+		require.Equal(t, "C1234", str)
+	})
+
+	t.Run("Unpack parses sign indicator", func(t *testing.T) {
+		f := NewAmount(spec)
+
+		read, err := f.Unpack([]byte("D00001234"))
+		require.NoError(t, err)
+		require.Equal(t, 9, read)
+		require.Equal(t, int64(-1234), f.Value())
+		require.Equal(t, "-12.34", f.Decimal())
+
+		_, err = f.Unpack([]byte("X00001234"))
+		require.EqualError(t, err, `failed to set bytes: invalid sign indicator 'X', expected C/D`)
+
+		_, err = f.Unpack([]byte("C0000A234"))
+		require.EqualError(t, err, "failed to set bytes: invalid amount digits")
+	})
+
+	t.Run("Marshal and Unmarshal", func(t *testing.T) {
+		f := NewAmount(spec)
+
+		require.NoError(t, f.Marshal(int64(-500)))
+		require.Equal(t, int64(-500), f.Value())
+
+		require.NoError(t, f.Marshal("-123.4"))
+		require.Equal(t, int64(-12340), f.Value())
+
+		require.NoError(t, f.Marshal(money{Cents: 999}))
+		require.Equal(t, int64(999), f.Value())
+
+		require.EqualError(t, f.Marshal("1.234"), "decimal amount has more than 2 digits after the decimal point")
+
+		var minorUnits int64
+		require.NoError(t, f.Unmarshal(&minorUnits))
+		require.Equal(t, int64(999), minorUnits)
+
+		var decimal string
+		require.NoError(t, f.Unmarshal(&decimal))
+		require.Equal(t, "9.99", decimal)
+
+		var m money
+		require.NoError(t, f.Unmarshal(&m))
+		require.Equal(t, money{Cents: 999, Exponent: 2}, m)
+
+		// money type as struct field value is unmarshaled using reflect.Value
+		data := struct{ Amount money }{}
+		require.NoError(t, f.Unmarshal(reflect.ValueOf(&data).Elem().Field(0)))
+		require.Equal(t, int64(999), data.Amount.Cents)
+	})
+
+	t.Run("SetExponent changes conversion of decimal values", func(t *testing.T) {
+		f := NewAmount(spec)
+		f.SetExponent(CurrencyExponent("392")) // JPY
+
+		require.NoError(t, f.Marshal("1500"))
+		require.Equal(t, int64(1500), f.Value())
+		require.Equal(t, "1500", f.Decimal())
+
+		f.SetExponent(CurrencyExponent("048")) // BHD
+		require.Equal(t, "1.500", f.Decimal())
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		f := NewAmount(spec)
+		f.SetValue(-1234)
+
+		data, err := json.Marshal(f)
+		require.NoError(t, err)
+		require.Equal(t, "-1234", string(data))
+
+		fromJSON := NewAmount(spec)
+		require.NoError(t, json.Unmarshal(data, fromJSON))
+		require.Equal(t, int64(-1234), fromJSON.Value())
+	})
+
+	t.Run("amount without sign indicator can't be negative", func(t *testing.T) {
+		f := NewAmount(&Spec{
+			Length:      12,
+			Description: "Transaction Amount",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.ASCII.Fixed,
+			Pad:         padding.Left('0'),
+		})
+
+		f.SetValue(100)
+		packed, err := f.Pack()
+		require.NoError(t, err)
+		require.Equal(t, "000000000100", string(packed))
+
+		f.SetValue(-100)
+		_, err = f.Pack()
+		require.EqualError(t, err, "negative amount requires sign indicator")
+	})
+}
+
+func TestFormatAndParseDecimal(t *testing.T) {
+	tests := []struct {
+		value    int64
+		exponent int
+		decimal  string
+	}{
+		{12345, 2, "123.45"},
+		{-5, 2, "-0.05"},
+		{0, 2, "0.00"},
+		{1500, 0, "1500"},
+		{-1, 3, "-0.001"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.decimal, func(t *testing.T) {
+			require.Equal(t, tt.decimal, formatDecimal(tt.value, tt.exponent))
+
+			value, err := parseDecimal(tt.decimal, tt.exponent)
+			require.NoError(t, err)
+			require.Equal(t, tt.value, value)
+		})
+	}
+
+	_, err := parseDecimal("1.2-", 2)
+	require.EqualError(t, err, "invalid decimal amount")
+}
@@ -12,6 +12,7 @@ import (
 
 var (
 	_ Field            = (*Binary)(nil)
+	_ AppendPacker     = (*Binary)(nil)
 	_ json.Marshaler   = (*Binary)(nil)
 	_ json.Unmarshaler = (*Binary)(nil)
 )
@@ -49,6 +50,12 @@ func (f *Binary) SetSpec(spec *Spec) {
 }
 
 func (f *Binary) SetBytes(b []byte) error {
+	if f.spec.checksConstraints(false) {
+		if err := f.spec.checkConstraints(fmt.Sprintf("%X", b), false); err != nil {
+			return err
+		}
+	}
+
 	f.value = b
 	return nil
 }
@@ -86,6 +93,25 @@ func (f *Binary) Pack() ([]byte, error) {
 	return packer.Pack(data, f.spec)
 }
 
+// AppendPack appends the packed field to dst and returns the extended slice
+func (f *Binary) AppendPack(dst []byte) ([]byte, error) {
+	return appendPack(dst, f.value, f.spec)
+}
+
+// PackedLen returns the length of the packed field
+func (f *Binary) PackedLen() (int, error) {
+	if n, ok := packedLen(len(f.value), f.spec); ok {
+		return n, nil
+	}
+
+	packed, err := f.Pack()
+	if err != nil {
+		return 0, err
+	}
+
+	return len(packed), nil
+}
+
 func (f *Binary) Unpack(data []byte) (int, error) {
 	unpacker := f.spec.getUnpacker()
 
@@ -94,10 +120,14 @@ func (f *Binary) Unpack(data []byte) (int, error) {
 		return 0, err
 	}
 
-	if err := f.SetBytes(raw); err != nil {
-		return 0, fmt.Errorf("failed to set bytes: %w", err)
+	if f.spec.checksConstraints(true) {
+		if err := f.spec.checkConstraints(fmt.Sprintf("%X", raw), true); err != nil {
+			return 0, err
+		}
 	}
 
+	f.value = raw
+
 	return bytesRead, nil
 }
 
@@ -137,7 +167,26 @@ func (f *Binary) Unmarshal(v interface{}) error {
 	return nil
 }
 
+// Marshal sets the value of the field. It returns an error if the value
+// does not satisfy the constraints of the spec.
 func (f *Binary) Marshal(v interface{}) error {
+	value := f.value
+
+	if err := f.marshal(v); err != nil {
+		return err
+	}
+
+	if f.spec.checksConstraints(false) {
+		if err := f.spec.checkConstraints(fmt.Sprintf("%X", f.value), false); err != nil {
+			f.value = value
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (f *Binary) marshal(v interface{}) error {
 	if v == nil {
 		f.value = nil
 		return nil
@@ -167,9 +216,9 @@ func (f *Binary) Marshal(v interface{}) error {
 
 		f.value = buf
 	case []byte:
-		f.SetBytes(v)
+		f.value = v
 	case *[]byte:
-		f.SetBytes(*v)
+		f.value = *v
 	default:
 		kind := rv.Kind()
 		if kind == reflect.Ptr {
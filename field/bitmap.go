@@ -6,9 +6,14 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+
+	"github.com/moov-io/iso8583/encoding"
 )
 
-var _ Field = (*Bitmap)(nil)
+var (
+	_ Field        = (*Bitmap)(nil)
+	_ AppendPacker = (*Bitmap)(nil)
+)
 
 // Bitmap represents an ISO 8583–style bitmap.
 // Bits are 1-indexed from left to right (ISO bit 1 → MSB of byte 0, ISO bit 8 → LSB of byte 0, and so on).
@@ -83,6 +88,40 @@ func (f *Bitmap) Pack() ([]byte, error) {
 	return packed, nil
 }
 
+// AppendPack appends the packed bitmap to dst and returns the extended slice
+func (f *Bitmap) AppendPack(dst []byte) ([]byte, error) {
+	enc, ok := f.spec.Enc.(encoding.Appender)
+	if !ok {
+		packed, err := f.Pack()
+		if err != nil {
+			return nil, err
+		}
+
+		return append(dst, packed...), nil
+	}
+
+	dst, err := enc.AppendEncode(dst, f.data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode content: %w", err)
+	}
+
+	return dst, nil
+}
+
+// PackedLen returns the length of the packed bitmap
+func (f *Bitmap) PackedLen() (int, error) {
+	if enc, ok := f.spec.Enc.(encoding.Appender); ok {
+		return enc.EncodedLen(len(f.data)), nil
+	}
+
+	packed, err := f.Pack()
+	if err != nil {
+		return 0, err
+	}
+
+	return len(packed), nil
+}
+
 // Unpack sets the bitmap data. It returns the number of bytes read from the
 // data. Usually it's 8 for binary, 16 for hex - for a single bitmap.
 // If DisableAutoExpand is not set (default), it will read all bitmaps until
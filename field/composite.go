@@ -1,6 +1,7 @@
 package field
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -20,6 +21,8 @@ import (
 
 var (
 	_ Field            = (*Composite)(nil)
+	_ AppendPacker     = (*Composite)(nil)
+	_ structMarshaler  = (*Composite)(nil)
 	_ json.Marshaler   = (*Composite)(nil)
 	_ json.Unmarshaler = (*Composite)(nil)
 )
@@ -78,6 +81,29 @@ type Composite struct {
 
 	// stores all fields according to the spec
 	subfields map[string]Field
+
+	// preserveOriginal makes the composite remember the original bytes of
+	// the subfields when unpacking
+	preserveOriginal bool
+
+	// original order of the subfields and their original bytes
+	originalOrder     []string
+	originalSubfields map[string]originalBytes
+	originalBitmap    *originalBytes
+
+	// lenient makes the composite continue unpacking when subfields fail
+	// to unpack and their length can be determined
+	lenient bool
+
+	// errors of the subfields collected during lenient unpacking
+	unpackErrs []error
+}
+
+// originalBytes holds the bytes the subfield was unpacked from and the bytes
+// it was packed into right after unpacking.
+type originalBytes struct {
+	raw    []byte
+	packed []byte
 }
 
 // NewComposite creates a new instance of the *Composite struct,
@@ -94,11 +120,32 @@ func NewComposite(spec *Spec) *Composite {
 
 func (c *Composite) NewInstance() Field {
 	return &Composite{
-		spec:      c.spec, // spec is validated already
-		subfields: make(map[string]Field),
+		spec:             c.spec, // spec is validated already
+		subfields:        make(map[string]Field),
+		preserveOriginal: c.preserveOriginal,
+		lenient:          c.lenient,
 	}
 }
 
+// SetPreserveOriginalBytes makes the composite remember the original bytes
+// of its subfields (including nested composites) when unpacking. When the
+// composite is packed, subfields that were not modified are packed using
+// their original bytes instead of the normalized output of their encoders
+// and paddings. Subfields with tags are packed in the order they were
+// unpacked in and new subfields are packed after them in order of
+// Spec.Tag.Sort.
+//
+// The subfield is considered modified if it packs into bytes that differ
+// from the ones it packed into right after unpacking. The option is copied
+// to the new instances of the composite, so it can be set on the composite
+// used in the message spec.
+func (f *Composite) SetPreserveOriginalBytes(preserve bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.preserveOriginal = preserve
+}
+
 // Spec returns the receiver's spec.
 func (f *Composite) Spec() *Spec {
 	return f.spec
@@ -132,6 +179,12 @@ func (f *Composite) SetSpec(spec *Spec) {
 }
 
 func (f *Composite) Unmarshal(v any) error {
+	return f.unmarshalStruct(v, nil)
+}
+
+// unmarshalStruct unmarshals subfields into the struct v converting the
+// values of its fields using converters.
+func (f *Composite) unmarshalStruct(v any, converters Converters) error {
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
@@ -147,8 +200,8 @@ func (f *Composite) Unmarshal(v any) error {
 	}
 
 	// iterate over struct fields
-	for i := range dataStruct.NumField() {
-		indexTag := NewIndexTag(dataStruct.Type().Field(i))
+	for _, structField := range StructFields(dataStruct.Type()) {
+		i, indexTag := structField.Index, structField.Tag
 		if indexTag.Tag == "" {
 			continue
 		}
@@ -158,28 +211,9 @@ func (f *Composite) Unmarshal(v any) error {
 			continue
 		}
 
-		dataField := dataStruct.Field(i)
-		switch dataField.Kind() { //nolint:exhaustive
-		case reflect.Pointer, reflect.Interface:
-			if dataField.IsNil() {
-				dataField.Set(reflect.New(dataField.Type().Elem()))
-			}
-
-			err := messageField.Unmarshal(dataField.Interface())
-			if err != nil {
-				return fmt.Errorf("unmarshalling field %s: %w", indexTag.Tag, err)
-			}
-		case reflect.Slice:
-			// Pass reflect.Value for slices so they can be modified
-			err := messageField.Unmarshal(dataField)
-			if err != nil {
-				return fmt.Errorf("unmarshalling field %s: %w", indexTag.Tag, err)
-			}
-		default: // Native types
-			err := messageField.Unmarshal(dataField)
-			if err != nil {
-				return fmt.Errorf("unmarshalling field %s: %w", indexTag.Tag, err)
-			}
+		err := UnmarshalStructField(messageField, dataStruct.Field(i), indexTag, converters)
+		if err != nil {
+			return fmt.Errorf("unmarshalling field %s: %w", indexTag.Tag, err)
 		}
 	}
 
@@ -204,6 +238,12 @@ func (f *Composite) SetData(v any) error {
 //	    F4 *SubfieldCompositeData
 //	}
 func (f *Composite) Marshal(v any) error {
+	return f.marshalStruct(v, nil)
+}
+
+// marshalStruct marshals the struct v into subfields converting the values
+// of its fields using converters.
+func (f *Composite) marshalStruct(v any, converters Converters) error {
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
@@ -226,8 +266,8 @@ func (f *Composite) Marshal(v any) error {
 	dataStruct := rv.Elem()
 
 	// iterate over struct fields
-	for i := range dataStruct.NumField() {
-		indexTag := NewIndexTag(dataStruct.Type().Field(i))
+	for _, structField := range StructFields(dataStruct.Type()) {
+		i, indexTag := structField.Index, structField.Tag
 		if indexTag.Tag == "" {
 			continue
 		}
@@ -242,7 +282,7 @@ func (f *Composite) Marshal(v any) error {
 			continue
 		}
 
-		err = messageField.Marshal(dataField.Interface())
+		err = MarshalStructField(messageField, dataField, indexTag, converters)
 		if err != nil {
 			return fmt.Errorf("marshalling field %s: %w", indexTag.Tag, err)
 		}
@@ -271,9 +311,12 @@ func (f *Composite) createField(id string) (Field, error) {
 		return nil, fmt.Errorf("field %s is not defined in the spec", id)
 	}
 
-	field := NewInstanceOf(specField)
+	field := f.newSubfield(specField)
 	f.subfields[id] = field
 
+	// the subfield is replaced, so it's packed as a new one
+	delete(f.originalSubfields, id)
+
 	return field, nil
 }
 
@@ -283,17 +326,41 @@ func (f *Composite) Pack() ([]byte, error) {
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
-	packed, err := f.pack()
-	if err != nil {
-		return nil, err
+	return f.appendPack(nil)
+}
+
+// AppendPack appends the packed field to dst and returns the extended slice
+func (f *Composite) AppendPack(dst []byte) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.appendPack(dst)
+}
+
+// PackedLen returns the length of the packed field. The subfields are not
+// packed unless they don't implement AppendPacker or their original bytes
+// are preserved.
+func (f *Composite) PackedLen() (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if pref, ok := f.spec.Pref.(prefix.Appender); ok {
+		n, ok, err := f.packedContentLen()
+		if err != nil {
+			return 0, err
+		}
+
+		if ok {
+			return pref.EncodedLen() + n, nil
+		}
 	}
 
-	packedLength, err := f.spec.Pref.EncodeLength(f.spec.Length, len(packed))
+	packed, err := f.appendPack(nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to encode length: %w", err)
+		return 0, err
 	}
 
-	return append(packedLength, packed...), nil
+	return len(packed), nil
 }
 
 // Unpack takes in a byte array and serializes them into the receiver's
@@ -354,7 +421,7 @@ func (f *Composite) Bytes() ([]byte, error) {
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
-	return f.pack()
+	return f.appendContent(nil)
 }
 
 // Bitmap returns the parsed bitmap instantiated on the key "0" of the spec.
@@ -450,102 +517,420 @@ func (f *Composite) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
-func (f *Composite) pack() ([]byte, error) {
+// appendPack appends the length prefix and the packed subfields to dst. It
+// assumes that the mutex is already locked by the caller.
+func (f *Composite) appendPack(dst []byte) ([]byte, error) {
+	pref, ok := f.spec.Pref.(prefix.Appender)
+	if !ok {
+		packed, err := f.appendContent(nil)
+		if err != nil {
+			return nil, err
+		}
+
+		packedLength, err := f.spec.Pref.EncodeLength(f.spec.Length, len(packed))
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode length: %w", err)
+		}
+
+		dst = append(dst, packedLength...)
+
+		return append(dst, packed...), nil
+	}
+
+	// reserve space for the length prefix and pack the subfields after it
+	start := len(dst)
+	for range pref.EncodedLen() {
+		dst = append(dst, 0)
+	}
+
+	dst, err := f.appendContent(dst)
+	if err != nil {
+		return nil, err
+	}
+
+	contentLen := len(dst) - start - pref.EncodedLen()
+
+	lengthPrefix, err := pref.AppendLength(dst[start:start], f.spec.Length, contentLen)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode length: %w", err)
+	}
+
+	if len(lengthPrefix) != pref.EncodedLen() {
+		return nil, fmt.Errorf("failed to encode length: expected %d bytes, got %d", pref.EncodedLen(), len(lengthPrefix))
+	}
+
+	return dst, nil
+}
+
+// appendContent appends the packed subfields (and bitmap) to dst without the
+// length prefix.
+func (f *Composite) appendContent(dst []byte) ([]byte, error) {
 	// we can validate specs here
 	if f.isWithBitmap() {
-		return f.packWithBitmap()
+		return f.appendWithBitmap(dst)
 	}
 
-	return f.packByTag()
+	return f.appendByTag(dst)
 }
 
-func (f *Composite) packWithBitmap() ([]byte, error) {
+// setBitmap sets the bits of the bitmap for the subfields that are set
+func (f *Composite) setBitmap() error {
 	f.bitmap().Reset()
 
-	var packedFields []byte
-
-	for _, id := range orderedKeys(f.subfields, sort.StringsByInt) {
-		// field must be there as we got it from the subfields map
-		field := f.subfields[id]
-
+	for id := range f.subfields {
 		idInt, err := strconv.Atoi(id)
 		if err != nil {
-			return nil, fmt.Errorf("converting id %s to int: %w", id, err)
+			return fmt.Errorf("converting id %s to int: %w", id, err)
 		}
 
-		// set bitmap bit for this field
 		f.bitmap().Set(idInt)
+	}
 
-		packedField, err := field.Pack()
-		if err != nil {
-			return nil, fmt.Errorf("failed to pack subfield %s (%s): %w", id, field.Spec().Description, err)
-		}
+	return nil
+}
 
-		packedFields = append(packedFields, packedField...)
+func (f *Composite) appendWithBitmap(dst []byte) ([]byte, error) {
+	if err := f.setBitmap(); err != nil {
+		return nil, err
 	}
 
 	// pack bitmap.
-	packedBitmap, err := f.bitmap().Pack()
+	start := len(dst)
+
+	dst, err := AppendPack(dst, f.bitmap())
 	if err != nil {
 		return nil, fmt.Errorf("packing bitmap: %w", err)
 	}
 
-	return append(packedBitmap, packedFields...), nil
-}
+	if f.originalBitmap != nil {
+		dst = append(dst[:start], f.originalBitmap.pack(dst[start:])...)
+	}
+
+	for _, id := range orderedKeys(f.subfields, sort.StringsByInt) {
+		dst, err = f.appendSubfield(dst, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to pack subfield %s (%s): %w", id, f.subfields[id].Spec().Description, err)
+		}
+	}
 
-func (f *Composite) packByTag() ([]byte, error) {
-	packed := []byte{}
+	return dst, nil
+}
 
+func (f *Composite) appendByTag(dst []byte) ([]byte, error) {
 	if f.spec.Tag == nil {
 		return nil, errors.New("cannot pack composite field by tag when Tag spec is not defined")
 	}
 
-	for _, tag := range orderedKeys(f.subfields, f.spec.Tag.Sort) {
-		field := f.subfields[tag]
+	if dst == nil {
+		dst = []byte{}
+	}
 
-		if f.spec.Tag.Enc != nil {
-			tagBytes := []byte(tag)
-			if f.spec.Tag.Pad != nil {
-				tagBytes = f.spec.Tag.Pad.Pad(tagBytes, f.spec.Tag.Length)
-			}
+	for _, tag := range f.packingOrder(orderedKeys(f.subfields, f.spec.Tag.Sort)) {
+		var err error
 
-			tagBytes, err := f.spec.Tag.Enc.Encode(tagBytes)
-			if err != nil {
-				return nil, fmt.Errorf("failed to convert subfield Tag \"%v\" to int", tagBytes)
+		dst, err = f.appendSubfield(dst, tag)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return dst, nil
+}
+
+// appendSubfield appends the packed subfield to dst. The original bytes of
+// the subfield are appended instead if it was not modified after unpacking.
+func (f *Composite) appendSubfield(dst []byte, tag string) ([]byte, error) {
+	start := len(dst)
+
+	dst, err := f.appendPackSubfield(dst, tag)
+	if err != nil {
+		return nil, err
+	}
+
+	if original, ok := f.originalSubfields[tag]; ok {
+		dst = append(dst[:start], original.pack(dst[start:])...)
+	}
+
+	return dst, nil
+}
+
+// packSubfield packs the subfield and prepends it with its encoded tag if
+// subfields are packed by tag and Spec.Tag.Enc is set.
+func (f *Composite) packSubfield(tag string) ([]byte, error) {
+	return f.appendPackSubfield(nil, tag)
+}
+
+// appendPackSubfield appends the packed subfield to dst prepended with its
+// encoded tag if subfields are packed by tag and Spec.Tag.Enc is set.
+func (f *Composite) appendPackSubfield(dst []byte, tag string) ([]byte, error) {
+	if f.isTagEncoded() {
+		tagBytes := []byte(tag)
+		if f.spec.Tag.Pad != nil {
+			tagBytes = f.spec.Tag.Pad.Pad(tagBytes, f.spec.Tag.Length)
+		}
+
+		tagBytes, err := f.spec.Tag.Enc.Encode(tagBytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert subfield Tag \"%v\" to int", tagBytes)
+		}
+
+		dst = append(dst, tagBytes...)
+	}
+
+	dst, err := AppendPack(dst, f.subfields[tag])
+	if err != nil {
+		// subfields packed with bitmap are wrapped by the caller
+		if f.isWithBitmap() {
+			return nil, err
+		}
+
+		return nil, fmt.Errorf("failed to pack subfield %v: %w", tag, err)
+	}
+
+	return dst, nil
+}
+
+// isTagEncoded reports whether subfields are prepended with their tags
+func (f *Composite) isTagEncoded() bool {
+	return !f.isWithBitmap() && f.spec.Tag != nil && f.spec.Tag.Enc != nil
+}
+
+// packedContentLen returns the length of the packed subfields (and bitmap)
+// without packing them. It returns false if the length can't be computed
+// without packing, e.g. when the original bytes of the subfields are
+// preserved.
+func (f *Composite) packedContentLen() (int, bool, error) {
+	if len(f.originalSubfields) > 0 || f.originalBitmap != nil {
+		return 0, false, nil
+	}
+
+	var n int
+
+	if f.isWithBitmap() {
+		if err := f.setBitmap(); err != nil {
+			return 0, false, err
+		}
+
+		bitmapLen, err := PackedLen(f.bitmap())
+		if err != nil {
+			return 0, false, fmt.Errorf("packing bitmap: %w", err)
+		}
+
+		n += bitmapLen
+	} else if f.spec.Tag == nil {
+		return 0, false, errors.New("cannot pack composite field by tag when Tag spec is not defined")
+	}
+
+	for id, field := range f.subfields {
+		if f.isTagEncoded() {
+			tagLen, ok := f.packedTagLen(id)
+			if !ok {
+				return 0, false, nil
 			}
 
-			packed = append(packed, tagBytes...)
+			n += tagLen
 		}
 
-		packedBytes, err := field.Pack()
+		fieldLen, err := PackedLen(field)
 		if err != nil {
-			return nil, fmt.Errorf("failed to pack subfield %v: %w", tag, err)
+			if f.isWithBitmap() {
+				return 0, false, fmt.Errorf("failed to pack subfield %s (%s): %w", id, field.Spec().Description, err)
+			}
+
+			return 0, false, fmt.Errorf("failed to pack subfield %v: %w", id, err)
 		}
 
-		packed = append(packed, packedBytes...)
+		n += fieldLen
+	}
+
+	return n, true, nil
+}
+
+// packedTagLen returns the length of the encoded tag. It returns false if
+// the tag encoder can't report the encoded length.
+func (f *Composite) packedTagLen(tag string) (int, bool) {
+	enc, ok := f.spec.Tag.Enc.(encoding.Appender)
+	if !ok {
+		return 0, false
+	}
 
+	tagLen := len(tag)
+	if f.spec.Tag.Pad != nil && tagLen < f.spec.Tag.Length {
+		tagLen += (f.spec.Tag.Length - tagLen) * len(f.spec.Tag.Pad.Inspect())
 	}
 
-	return packed, nil
+	return enc.EncodedLen(tagLen), true
+}
+
+// packingOrder returns the tags of the subfields in the order they were
+// unpacked in followed by the new tags in the given order. If the original
+// bytes are not preserved, tags are returned as is.
+func (f *Composite) packingOrder(tags []string) []string {
+	if len(f.originalOrder) == 0 {
+		return tags
+	}
+
+	ordered := make([]string, 0, len(tags))
+	for _, tag := range f.originalOrder {
+		if _, ok := f.subfields[tag]; ok {
+			ordered = append(ordered, tag)
+		}
+	}
+
+	for _, tag := range tags {
+		if _, ok := f.originalSubfields[tag]; !ok {
+			ordered = append(ordered, tag)
+		}
+	}
+
+	return ordered
+}
+
+// pack returns the original bytes of the subfield if packed matches the
+// bytes the subfield was packed into right after unpacking.
+func (o originalBytes) pack(packed []byte) []byte {
+	if o.packed != nil && bytes.Equal(packed, o.packed) {
+		return o.raw
+	}
+
+	return packed
+}
+
+// resetOriginal resets the original bytes of the subfields. If original
+// bytes are preserved, it prepares the composite to remember them.
+func (f *Composite) resetOriginal() {
+	f.originalOrder = nil
+	f.originalSubfields = nil
+	f.originalBitmap = nil
+
+	if f.preserveOriginal {
+		f.originalSubfields = make(map[string]originalBytes)
+	}
+}
+
+// newSubfield creates a new instance of the subfield. Nested composites
+// preserve the original bytes of their subfields as well.
+func (f *Composite) newSubfield(specField Field) Field {
+	field := NewInstanceOf(specField)
+
+	if composite, ok := field.(*Composite); ok {
+		composite.preserveOriginal = composite.preserveOriginal || f.preserveOriginal
+		composite.lenient = composite.lenient || f.lenient
+	}
+
+	return field
+}
+
+// rememberOriginal stores the original bytes of the unpacked subfield if
+// the composite preserves them. Tag is packed along with the subfield.
+func (f *Composite) rememberOriginal(tag string, raw []byte) {
+	// the subfield may be not set if it failed to unpack in lenient mode
+	if _, ok := f.subfields[tag]; !f.preserveOriginal || !ok {
+		return
+	}
+
+	packed, err := f.packSubfield(tag)
+	if err != nil {
+		// the subfield will fail to pack anyway
+		return
+	}
+
+	if _, ok := f.originalSubfields[tag]; !ok {
+		f.originalOrder = append(f.originalOrder, tag)
+	}
+
+	f.originalSubfields[tag] = originalBytes{
+		raw:    bytes.Clone(raw),
+		packed: packed,
+	}
 }
 
 // wrapErrorUnpack calls the core unpacking logic and wraps any
-// errors in a *UnpackError. It assumes that the mutex is already
-// locked by the caller.
+// errors in a *UnpackError. In lenient mode, errors of all subfields
+// are joined. It assumes that the mutex is already locked by the caller.
 func (f *Composite) wrapErrorUnpack(src []byte, isVariableLength bool) (int, error) {
 	offset, tagID, err := f.unpack(src, isVariableLength)
+
+	unpackErrs := f.unpackErrs
+	f.unpackErrs = nil
+
 	if err != nil {
-		return offset, &iso8583errors.UnpackError{
+		unpackErrs = append(unpackErrs, &iso8583errors.UnpackError{
 			Err:        err,
 			FieldID:    tagID,
 			RawMessage: src,
+		})
+	}
+
+	if len(unpackErrs) == 0 {
+		return offset, nil
+	}
+
+	if !f.lenient {
+		return offset, unpackErrs[0]
+	}
+
+	return offset, errors.Join(unpackErrs...)
+}
+
+// SetLenientUnpacking makes the composite continue unpacking when its
+// subfields fail to unpack, as long as the length of the failed subfield can
+// be determined (see PeekLen). Unpack then returns errors of all such
+// subfields joined together (see errors.UnpackErrors), and the subfields
+// that were unpacked successfully stay populated. Failed subfields are not
+// set, unless they are composites with some of their subfields populated.
+// The option applies to nested composites as well and is copied to the new
+// instances of the composite.
+func (f *Composite) SetLenientUnpacking(lenient bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.lenient = lenient
+}
+
+// recoverSubfield is called when the subfield fails to unpack with err. In
+// lenient mode it records the error and returns the length of the subfield,
+// so unpacking can continue. Otherwise, or if the length of the subfield
+// can't be determined, err is returned.
+func (f *Composite) recoverSubfield(tag string, field Field, data []byte, err error) (int, error) {
+	if !f.lenient {
+		return 0, err
+	}
+
+	n, ok, peekErr := PeekLen(field, data)
+	if peekErr != nil || !ok {
+		return 0, err
+	}
+
+	// report errors of the nested composite separately
+	causes := []error{err}
+	if unpackErrs := iso8583errors.UnpackErrors(err); len(unpackErrs) > 1 {
+		causes = causes[:0]
+		for _, unpackErr := range unpackErrs {
+			causes = append(causes, unpackErr)
 		}
 	}
 
-	return offset, nil
+	for _, cause := range causes {
+		f.unpackErrs = append(f.unpackErrs, &iso8583errors.UnpackError{
+			Err:        fmt.Errorf("failed to unpack subfield %v: %w", tag, cause),
+			FieldID:    tag,
+			RawMessage: data[:n],
+		})
+	}
+
+	// keep the nested composite with the subfields that were unpacked
+	if composite, ok := field.(*Composite); !ok || len(composite.GetSubfields()) == 0 {
+		delete(f.subfields, tag)
+	}
+
+	return n, nil
 }
 
 func (f *Composite) unpack(data []byte, isVariableLength bool) (int, string, error) {
+	f.resetOriginal()
+	f.unpackErrs = nil
+
 	if f.isWithBitmap() {
 		n, s, err := f.unpackSubfieldsByBitmap(data)
 		if err != nil {
@@ -580,14 +965,18 @@ func (f *Composite) unpackSubfields(data []byte, isVariableLength bool) (int, st
 	offset := 0
 
 	for _, tag := range orderedKeys(f.spec.Subfields, f.spec.Tag.Sort) {
-		field := NewInstanceOf(f.spec.Subfields[tag])
+		field := f.newSubfield(f.spec.Subfields[tag])
 		f.subfields[tag] = field
 
 		read, err := field.Unpack(data[offset:])
 		if err != nil {
-			return 0, tag, fmt.Errorf("failed to unpack subfield %v: %w", tag, err)
+			if read, err = f.recoverSubfield(tag, field, data[offset:], err); err != nil {
+				return 0, tag, fmt.Errorf("failed to unpack subfield %v: %w", tag, err)
+			}
 		}
 
+		f.rememberOriginal(tag, data[offset:offset+read])
+
 		offset += read
 
 		if isVariableLength && offset >= len(data) {
@@ -610,6 +999,15 @@ func (f *Composite) unpackSubfieldsByBitmap(data []byte) (int, string, error) {
 		return 0, "", fmt.Errorf("failed to unpack bitmap: %w", err)
 	}
 
+	if f.preserveOriginal {
+		if packed, err := f.bitmap().Pack(); err == nil {
+			f.originalBitmap = &originalBytes{
+				raw:    bytes.Clone(data[offset : offset+read]),
+				packed: packed,
+			}
+		}
+	}
+
 	offset += read
 
 	for i := 1; i <= f.bitmap().Len(); i++ {
@@ -623,9 +1021,13 @@ func (f *Composite) unpackSubfieldsByBitmap(data []byte) (int, string, error) {
 
 			read, err = fl.Unpack(data[offset:])
 			if err != nil {
-				return 0, idx, fmt.Errorf("failed to unpack subfield %s (%s): %w", idx, fl.Spec().Description, err)
+				if read, err = f.recoverSubfield(idx, fl, data[offset:], err); err != nil {
+					return 0, idx, fmt.Errorf("failed to unpack subfield %s (%s): %w", idx, fl.Spec().Description, err)
+				}
 			}
 
+			f.rememberOriginal(idx, data[offset:offset+read])
+
 			offset += read
 		}
 	}
@@ -648,6 +1050,8 @@ func (f *Composite) unpackSubfieldsByTag(data []byte) (int, string, error) {
 	offset := 0
 
 	for offset < len(data) {
+		start := offset
+
 		tagBytes, read, err := f.spec.Tag.Enc.Decode(data[offset:], f.spec.Tag.Length)
 		if err != nil {
 			return 0, "", fmt.Errorf("failed to unpack subfield Tag: %w", err)
@@ -699,6 +1103,7 @@ func (f *Composite) unpackSubfieldsByTag(data []byte) (int, string, error) {
 						return 0, tag, fmt.Errorf("failed to set bytes for unknown tag %s: %w", tag, err)
 					}
 					f.subfields[tag] = binaryField
+					f.rememberOriginal(tag, data[start:offset+read+fieldLength])
 				}
 
 				offset += fieldLength + read
@@ -709,15 +1114,19 @@ func (f *Composite) unpackSubfieldsByTag(data []byte) (int, string, error) {
 			return 0, tag, fmt.Errorf("failed to unpack subfield %v: field is not defined in the spec", tag)
 		}
 
-		field := NewInstanceOf(specField)
+		field := f.newSubfield(specField)
 		f.subfields[tag] = field
 
 		read, err = field.Unpack(data[offset:])
 		if err != nil {
-			return 0, tag, fmt.Errorf("failed to unpack subfield %v: %w", tag, err)
+			if read, err = f.recoverSubfield(tag, field, data[offset:], err); err != nil {
+				return 0, tag, fmt.Errorf("failed to unpack subfield %v: %w", tag, err)
+			}
 		}
 
 		offset += read
+
+		f.rememberOriginal(tag, data[start:offset])
 	}
 
 	return offset, "", nil
@@ -750,6 +1159,7 @@ func (m *Composite) UnsetSubfield(id string) {
 // isn't protected by mutex, caller must ensure mutex is locked
 func (m *Composite) unsetField(id string) {
 	delete(m.subfields, id)
+	delete(m.originalSubfields, id)
 }
 
 // UnsetSubfields marks multiple subfields identified by their paths as not set and
@@ -838,7 +1248,7 @@ func (m *Composite) MarshalPath(path string, value any) error {
 		return nil
 	}
 
-	err = field.Marshal(value)
+	err = marshalValue(field, value)
 	if err != nil {
 		return fmt.Errorf("marshaling field %s: %w", id, err)
 	}
@@ -881,7 +1291,7 @@ func (m *Composite) UnmarshalPath(path string, value any) error {
 		return nil
 	}
 
-	err := field.Unmarshal(value)
+	err := unmarshalValue(field, value)
 	if err != nil {
 		return fmt.Errorf("unmarshaling field %s: %w", id, err)
 	}
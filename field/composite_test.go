@@ -878,6 +878,238 @@ func TestTLVPacking(t *testing.T) {
 	})
 }
 
+func TestCompositePreserveOriginalBytes(t *testing.T) {
+	// tag 9F02 goes before 9A and length of 9A is encoded in the long form
+	rawTLV := []byte{0x30, 0x31, 0x35, 0x9f, 0x2, 0x6, 0x0, 0x0, 0x0, 0x0, 0x5, 0x1, 0x9a, 0x81, 0x3, 0x21, 0x7, 0x20}
+
+	t.Run("Pack normalizes subfields by default", func(t *testing.T) {
+		composite := NewComposite(tlvTestSpec)
+
+		_, err := composite.Unpack(rawTLV)
+		require.NoError(t, err)
+
+		packed, err := composite.Pack()
+		require.NoError(t, err)
+		require.Equal(t, []byte{0x30, 0x31, 0x34, 0x9a, 0x3, 0x21, 0x7, 0x20, 0x9f, 0x2, 0x6, 0x0, 0x0, 0x0, 0x0, 0x5, 0x1}, packed)
+	})
+
+	t.Run("Pack uses original bytes of unmodified subfields", func(t *testing.T) {
+		composite := NewComposite(tlvTestSpec)
+		composite.SetPreserveOriginalBytes(true)
+
+		read, err := composite.Unpack(rawTLV)
+		require.NoError(t, err)
+		require.Equal(t, len(rawTLV), read)
+
+		packed, err := composite.Pack()
+		require.NoError(t, err)
+		require.Equal(t, rawTLV, packed)
+
+		// modified subfield is packed as usual in its original position
+		require.NoError(t, composite.Marshal(&TLVTestData{F9F02: NewHexValue("000000000999")}))
+
+		packed, err = composite.Pack()
+		require.NoError(t, err)
+		require.Equal(t, []byte{0x30, 0x31, 0x35, 0x9f, 0x2, 0x6, 0x0, 0x0, 0x0, 0x0, 0x9, 0x99, 0x9a, 0x81, 0x3, 0x21, 0x7, 0x20}, packed)
+	})
+
+	t.Run("new instances preserve original bytes", func(t *testing.T) {
+		spec := NewComposite(tlvTestSpec)
+		spec.SetPreserveOriginalBytes(true)
+
+		composite := NewInstanceOf(spec)
+
+		_, err := composite.Unpack(rawTLV)
+		require.NoError(t, err)
+
+		packed, err := composite.Pack()
+		require.NoError(t, err)
+		require.Equal(t, rawTLV, packed)
+	})
+}
+
+func TestCompositeAppendPack(t *testing.T) {
+	bitmapSpec := &Spec{
+		Length: 30,
+		Pref:   prefix.ASCII.LL,
+		Bitmap: NewBitmap(&Spec{
+			Length:            8,
+			Enc:               encoding.BytesToASCIIHex,
+			Pref:              prefix.Hex.Fixed,
+			DisableAutoExpand: true,
+		}),
+		Subfields: map[string]Field{
+			"1": NewString(&Spec{
+				Length: 2,
+				Enc:    encoding.ASCII,
+				Pref:   prefix.ASCII.Fixed,
+			}),
+			"3": NewNumeric(&Spec{
+				Length: 2,
+				Enc:    encoding.ASCII,
+				Pref:   prefix.ASCII.Fixed,
+			}),
+		},
+	}
+
+	tests := []struct {
+		name string
+		spec *Spec
+		data any
+	}{
+		{
+			name: "subfields with encoded tags",
+			spec: compositeTestSpecWithTagPadding,
+			data: &CompositeTestData{
+				F1:  NewStringValue("AB"),
+				F3:  NewNumericValue(12),
+				F11: &SubCompositeData{F1: NewStringValue("YZ")},
+			},
+		},
+		{
+			name: "subfields with bitmap",
+			spec: bitmapSpec,
+			data: &CompositeTestData{
+				F1: NewStringValue("AB"),
+				F3: NewNumericValue(12),
+			},
+		},
+		{
+			name: "TLV subfields",
+			spec: tlvTestSpec,
+			data: &TLVTestData{
+				F9A:   NewHexValue("210720"),
+				F9F02: NewHexValue("000000000501"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			composite := NewComposite(tt.spec)
+			require.NoError(t, composite.Marshal(tt.data))
+
+			packed, err := composite.Pack()
+			require.NoError(t, err)
+
+			appended, err := composite.AppendPack([]byte("head"))
+			require.NoError(t, err)
+			require.Equal(t, append([]byte("head"), packed...), appended)
+
+			packedLen, err := composite.PackedLen()
+			require.NoError(t, err)
+			require.Equal(t, len(packed), packedLen)
+		})
+	}
+
+	t.Run("PackedLen uses original bytes of unmodified subfields", func(t *testing.T) {
+		rawTLV := []byte{0x30, 0x31, 0x35, 0x9f, 0x2, 0x6, 0x0, 0x0, 0x0, 0x0, 0x5, 0x1, 0x9a, 0x81, 0x3, 0x21, 0x7, 0x20}
+
+		composite := NewComposite(tlvTestSpec)
+		composite.SetPreserveOriginalBytes(true)
+
+		_, err := composite.Unpack(rawTLV)
+		require.NoError(t, err)
+
+		packedLen, err := composite.PackedLen()
+		require.NoError(t, err)
+		require.Equal(t, len(rawTLV), packedLen)
+
+		appended, err := composite.AppendPack(nil)
+		require.NoError(t, err)
+		require.Equal(t, rawTLV, appended)
+	})
+
+	t.Run("AppendPack returns packing errors", func(t *testing.T) {
+		composite := NewComposite(bitmapSpec)
+		require.NoError(t, composite.Marshal(&CompositeTestData{F1: NewStringValue("ABC")}))
+
+		_, packErr := composite.Pack()
+		require.Error(t, packErr)
+
+		_, err := composite.AppendPack(nil)
+		require.EqualError(t, err, packErr.Error())
+	})
+}
+
+func TestCompositeLenientUnpacking(t *testing.T) {
+	spec := &Spec{
+		Length:      999,
+		Description: "Additional Data",
+		Pref:        prefix.ASCII.LLL,
+		Tag: &TagSpec{
+			Length: 2,
+			Enc:    encoding.ASCII,
+			Sort:   sort.StringsByInt,
+		},
+		Subfields: map[string]Field{
+			"01": NewNumeric(&Spec{
+				Length:      2,
+				Description: "Subfield 1",
+				Enc:         encoding.ASCII,
+				Pref:        prefix.ASCII.Fixed,
+			}),
+			"02": NewString(&Spec{
+				Length:      10,
+				Description: "Subfield 2",
+				Enc:         encoding.ASCII,
+				Pref:        prefix.ASCII.LL,
+			}),
+			"03": NewNumeric(&Spec{
+				Length:      2,
+				Description: "Subfield 3",
+				Enc:         encoding.ASCII,
+				Pref:        prefix.ASCII.Fixed,
+			}),
+		},
+	}
+
+	// subfields 01 and 03 are not numeric
+	data := []byte("017" + "01XY" + "0205hello" + "03ZZ")
+
+	t.Run("Unpack returns the first error by default", func(t *testing.T) {
+		composite := NewComposite(spec)
+
+		_, err := composite.Unpack(data)
+		require.Error(t, err)
+		require.Len(t, iso8583errors.UnpackErrors(err), 1)
+	})
+
+	t.Run("Unpack returns errors of all subfields in lenient mode", func(t *testing.T) {
+		composite := NewComposite(spec)
+		composite.SetLenientUnpacking(true)
+
+		_, err := composite.Unpack(data)
+		require.Error(t, err)
+
+		unpackErrs := iso8583errors.UnpackErrors(err)
+		require.Len(t, unpackErrs, 2)
+		require.Equal(t, []string{"01"}, unpackErrs[0].FieldIDs())
+		require.Equal(t, []string{"03"}, unpackErrs[1].FieldIDs())
+
+		subfields := composite.GetSubfields()
+		require.Len(t, subfields, 1)
+
+		value, err := subfields["02"].String()
+		require.NoError(t, err)
+		require.Equal(t, "hello", value)
+	})
+
+	t.Run("Unpack stops when length of the subfield can't be determined", func(t *testing.T) {
+		composite := NewComposite(spec)
+		composite.SetLenientUnpacking(true)
+
+		// subfield 02 is truncated
+		_, err := composite.Unpack([]byte("011" + "01XY" + "0205hel"))
+		require.Error(t, err)
+
+		unpackErrs := iso8583errors.UnpackErrors(err)
+		require.Len(t, unpackErrs, 2)
+		require.Equal(t, []string{"01"}, unpackErrs[0].FieldIDs())
+		require.Equal(t, []string{"02"}, unpackErrs[1].FieldIDs())
+	})
+}
+
 func TestCompositePacking(t *testing.T) {
 	t.Run("Pack returns an error on mismatch of subfield types", func(t *testing.T) {
 		type TestDataIncorrectType struct {
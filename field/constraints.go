@@ -0,0 +1,189 @@
+package field
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+)
+
+// Class is the ISO 8583 attribute class that defines which characters the
+// field value may contain.
+type Class string
+
+const (
+	// ClassNumeric allows numeric digits only (0-9).
+	ClassNumeric Class = "n"
+	// ClassAlpha allows alphabetic characters only (A-Z, a-z).
+	ClassAlpha Class = "a"
+	// ClassAlphaNumeric allows alphabetic characters and numeric digits.
+	ClassAlphaNumeric Class = "an"
+	// ClassAlphaNumericSpecial allows printable ASCII characters including
+	// space.
+	ClassAlphaNumericSpecial Class = "ans"
+	// ClassBinary allows any data.
+	ClassBinary Class = "b"
+	// ClassTrack allows characters of the track 2 and 3 code set: numeric
+	// digits, separators (: ; < = > ?) and the 'D' separator.
+	ClassTrack Class = "z"
+)
+
+// allows returns true if the class allows all characters of the value.
+func (c Class) allows(value string) (bool, error) {
+	var allowed func(ch byte) bool
+
+	switch c {
+	case ClassNumeric:
+		allowed = isDigit
+	case ClassAlpha:
+		allowed = isAlpha
+	case ClassAlphaNumeric:
+		allowed = func(ch byte) bool { return isAlpha(ch) || isDigit(ch) }
+	case ClassAlphaNumericSpecial:
+		allowed = func(ch byte) bool { return ch >= 0x20 && ch <= 0x7e }
+	case ClassBinary:
+		return true, nil
+	case ClassTrack:
+		allowed = func(ch byte) bool { return (ch >= '0' && ch <= '?') || ch == 'D' }
+	default:
+		return false, fmt.Errorf("unknown class %q", c)
+	}
+
+	for i := range len(value) {
+		if !allowed(value[i]) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func isDigit(ch byte) bool {
+	return ch >= '0' && ch <= '9'
+}
+
+func isAlpha(ch byte) bool {
+	return (ch >= 'A' && ch <= 'Z') || (ch >= 'a' && ch <= 'z')
+}
+
+// Strictness defines when the constraints of the field are enforced.
+type Strictness string
+
+const (
+	// StrictnessStrict enforces the constraints when the value of the field
+	// is set (SetBytes, Marshal) and when the field is unpacked. It's the
+	// default.
+	StrictnessStrict Strictness = ""
+	// StrictnessLenient enforces the constraints only when the value of the
+	// field is set. Unpacked values are accepted as they are, which is
+	// useful when the counterparty doesn't follow the spec precisely.
+	StrictnessLenient Strictness = "lenient"
+	// StrictnessOff disables enforcement of the constraints. They can
+	// still be checked using Constraints.Check.
+	StrictnessOff Strictness = "off"
+)
+
+// Constraints defines constraints on the value of the field in addition to
+// its length and encoding. Constraints apply to the value of the field as
+// returned by its String method, e.g. to decimal digits of Numeric and to
+// hex digits of Binary and Hex fields. Empty values are not checked, as they
+// are used to reset fields.
+type Constraints struct {
+	// Class is the ISO 8583 attribute class of the value (n, a, an, ans, b
+	// or z).
+	Class Class
+	// MinLength is the minimum length of the value. The maximum length is
+	// defined by Spec.Length.
+	MinLength int
+	// Pattern is the regular expression the value must match.
+	Pattern *regexp.Regexp
+	// Enum is the list of allowed values.
+	Enum []string
+	// Min is the minimum numeric value.
+	Min *int64
+	// Max is the maximum numeric value.
+	Max *int64
+	// Strictness defines when the constraints are enforced.
+	Strictness Strictness
+}
+
+// Check checks the value against the constraints. The error does not
+// include the value as it may hold sensitive data.
+func (c *Constraints) Check(value string) error {
+	if c == nil || value == "" {
+		return nil
+	}
+
+	if c.Class != "" {
+		ok, err := c.Class.allows(value)
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			return fmt.Errorf("value contains characters not allowed in class %s", c.Class)
+		}
+	}
+
+	if c.MinLength > 0 && len(value) < c.MinLength {
+		return fmt.Errorf("value length %d is less than minimum length %d", len(value), c.MinLength)
+	}
+
+	if c.Pattern != nil && !c.Pattern.MatchString(value) {
+		return fmt.Errorf("value does not match pattern %s", c.Pattern)
+	}
+
+	if len(c.Enum) > 0 && !slices.Contains(c.Enum, value) {
+		return fmt.Errorf("value is not one of allowed values %v", c.Enum)
+	}
+
+	if c.Min != nil || c.Max != nil {
+		number, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("value is not a number")
+		}
+
+		if c.Min != nil && number < *c.Min {
+			return fmt.Errorf("value is less than minimum %d", *c.Min)
+		}
+
+		if c.Max != nil && number > *c.Max {
+			return fmt.Errorf("value is greater than maximum %d", *c.Max)
+		}
+	}
+
+	return nil
+}
+
+// checksConstraints reports whether the values should be checked against the
+// constraints of the spec. Fields use it to skip converting the value into a
+// string when there is nothing to check.
+func (spec *Spec) checksConstraints(unpacking bool) bool {
+	if spec == nil || spec.Constraints == nil {
+		return false
+	}
+
+	switch spec.Constraints.Strictness {
+	case StrictnessOff:
+		return false
+	case StrictnessLenient:
+		return !unpacking
+	}
+
+	return true
+}
+
+// checkConstraints checks the value against the constraints of the spec
+// according to their strictness. unpacking is true when the value comes
+// from the unpacked data.
+func (spec *Spec) checkConstraints(value string, unpacking bool) error {
+	if !spec.checksConstraints(unpacking) {
+		return nil
+	}
+
+	if err := spec.Constraints.Check(value); err != nil {
+		return fmt.Errorf("checking constraints: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,160 @@
+package field
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/moov-io/iso8583/encoding"
+	"github.com/moov-io/iso8583/prefix"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConstraintsCheck(t *testing.T) {
+	minValue := int64(10)
+	maxValue := int64(500)
+
+	tests := []struct {
+		name        string
+		constraints *Constraints
+		value       string
+		expectedErr string
+	}{
+		{"numeric class", &Constraints{Class: ClassNumeric}, "0123456789", ""},
+		{"numeric class with sign", &Constraints{Class: ClassNumeric}, "-100", "value contains characters not allowed in class n"},
+		{"alpha class", &Constraints{Class: ClassAlpha}, "abcXYZ", ""},
+		{"alpha class with digits", &Constraints{Class: ClassAlpha}, "abc1", "value contains characters not allowed in class a"},
+		{"alphanumeric class", &Constraints{Class: ClassAlphaNumeric}, "TERM01", ""},
+		{"alphanumeric class with space", &Constraints{Class: ClassAlphaNumeric}, "TERM 01", "value contains characters not allowed in class an"},
+		{"alphanumeric special class", &Constraints{Class: ClassAlphaNumericSpecial}, "Shop #1, Main St.", ""},
+		{"alphanumeric special class with control characters", &Constraints{Class: ClassAlphaNumericSpecial}, "Shop\n", "value contains characters not allowed in class ans"},
+		{"binary class", &Constraints{Class: ClassBinary}, "\x00\xff", ""},
+		{"track class", &Constraints{Class: ClassTrack}, "4242424242424242=2512", ""},
+		{"track class with letters", &Constraints{Class: ClassTrack}, "4242424242424242^2512", "value contains characters not allowed in class z"},
+		{"unknown class", &Constraints{Class: "x"}, "1", `unknown class "x"`},
+		{"min length", &Constraints{MinLength: 3}, "12", "value length 2 is less than minimum length 3"},
+		{"pattern", &Constraints{Pattern: regexp.MustCompile(`^\d{2}$`)}, "1A", `value does not match pattern ^\d{2}$`},
+		{"enum", &Constraints{Enum: []string{"00", "01"}}, "01", ""},
+		{"value not in enum", &Constraints{Enum: []string{"00", "01"}}, "02", "value is not one of allowed values [00 01]"},
+		{"range", &Constraints{Min: &minValue, Max: &maxValue}, "0100", ""},
+		{"less than min", &Constraints{Min: &minValue}, "9", "value is less than minimum 10"},
+		{"greater than max", &Constraints{Max: &maxValue}, "501", "value is greater than maximum 500"},
+		{"range of non numeric value", &Constraints{Max: &maxValue}, "ABC", "value is not a number"},
+		{"empty value", &Constraints{MinLength: 3}, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.constraints.Check(tt.value)
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			require.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestFieldConstraints(t *testing.T) {
+	newSpec := func(strictness Strictness) *Spec {
+		return &Spec{
+			Length:      6,
+			Description: "Field",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.ASCII.LL,
+			Constraints: &Constraints{
+				Class:      ClassNumeric,
+				MinLength:  2,
+				Strictness: strictness,
+			},
+		}
+	}
+
+	t.Run("String enforces constraints on SetBytes, Marshal and Unpack", func(t *testing.T) {
+		f := NewString(newSpec(StrictnessStrict))
+
+		require.EqualError(t, f.SetBytes([]byte("1A")), "checking constraints: value contains characters not allowed in class n")
+		require.EqualError(t, f.Marshal("1"), "checking constraints: value length 1 is less than minimum length 2")
+
+		require.NoError(t, f.Marshal("12"))
+		require.Equal(t, "12", f.Value())
+
+		// the value is not changed when constraints are not satisfied
+		require.Error(t, f.Marshal("1A"))
+		require.Equal(t, "12", f.Value())
+
+		_, err := f.Unpack([]byte("021A"))
+		require.EqualError(t, err, "checking constraints: value contains characters not allowed in class n")
+	})
+
+	t.Run("Numeric enforces constraints on SetBytes, Marshal and Unpack", func(t *testing.T) {
+		f := NewNumeric(newSpec(StrictnessStrict))
+
+		require.EqualError(t, f.Marshal(int64(-10)), "checking constraints: value contains characters not allowed in class n")
+		require.EqualError(t, f.SetBytes([]byte("-10")), "checking constraints: value contains characters not allowed in class n")
+
+		_, err := f.Unpack([]byte("03-10"))
+		require.EqualError(t, err, "failed to set bytes: checking constraints: value contains characters not allowed in class n")
+
+		require.NoError(t, f.Marshal(int64(10)))
+		require.Equal(t, int64(10), f.Value())
+	})
+
+	t.Run("Hex and Binary check hex representation of the value", func(t *testing.T) {
+		spec := &Spec{
+			Length:      4,
+			Description: "Field",
+			Enc:         encoding.Binary,
+			Pref:        prefix.Binary.Fixed,
+			Constraints: &Constraints{
+				Pattern: regexp.MustCompile(`^00`),
+			},
+		}
+
+		require.NoError(t, NewHex(spec).Marshal("00AABBCC"))
+		require.Error(t, NewHex(spec).SetBytes([]byte{0x01, 0x02, 0x03, 0x04}))
+		require.NoError(t, NewBinary(spec).SetBytes([]byte{0x00, 0x02, 0x03, 0x04}))
+
+		_, err := NewBinary(spec).Unpack([]byte{0x01, 0x02, 0x03, 0x04})
+		require.EqualError(t, err, "checking constraints: value does not match pattern ^00")
+	})
+
+	t.Run("lenient strictness does not enforce constraints on Unpack", func(t *testing.T) {
+		f := NewString(newSpec(StrictnessLenient))
+
+		_, err := f.Unpack([]byte("021A"))
+		require.NoError(t, err)
+		require.Equal(t, "1A", f.Value())
+
+		require.Error(t, f.SetBytes([]byte("1A")))
+	})
+
+	t.Run("constraints are not enforced when strictness is off", func(t *testing.T) {
+		f := NewString(newSpec(StrictnessOff))
+
+		require.NoError(t, f.SetBytes([]byte("1A")))
+		require.Error(t, f.Spec().Constraints.Check(f.Value()))
+	})
+
+	t.Run("values are not converted for check when there are no constraints", func(t *testing.T) {
+		spec := &Spec{
+			Length:      6,
+			Description: "Field",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.ASCII.LL,
+		}
+
+		numeric := NewNumeric(spec)
+		allocs := testing.AllocsPerRun(10, func() {
+			_ = numeric.SetBytes([]byte("123456"))
+		})
+		require.Zero(t, allocs)
+
+		binary := NewBinary(spec)
+		value := []byte{0x01, 0x02}
+		allocs = testing.AllocsPerRun(10, func() {
+			_ = binary.SetBytes(value)
+		})
+		require.Zero(t, allocs)
+	})
+}
@@ -0,0 +1,581 @@
+package field
+
+import (
+	"encoding"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Marshaler is implemented by types of struct fields that set the value of
+// the message field themselves, e.g. money types that set the amount and
+// the currency exponent of the Amount field.
+type Marshaler interface {
+	MarshalISO8583(f Field) error
+}
+
+// Unmarshaler is implemented by types of struct fields that get the value
+// from the message field themselves.
+type Unmarshaler interface {
+	UnmarshalISO8583(f Field) error
+}
+
+// Converter converts values of the struct field type to and from the string
+// value of the message field. Format is the value of the format option of
+// the struct field tag, e.g. `iso8583:"3,format=..."`.
+type Converter struct {
+	Marshal   func(v any, format string) (string, error)
+	Unmarshal func(s string, format string) (any, error)
+}
+
+// NewConverter creates the Converter of T values.
+func NewConverter[T any](marshal func(v T, format string) (string, error), unmarshal func(s string, format string) (T, error)) Converter {
+	return Converter{
+		Marshal: func(v any, format string) (string, error) {
+			return marshal(v.(T), format)
+		},
+		Unmarshal: func(s string, format string) (any, error) {
+			return unmarshal(s, format)
+		},
+	}
+}
+
+// Converters is the registry of converters keyed by the type of the struct
+// field.
+type Converters map[reflect.Type]Converter
+
+// has reports whether the converter of t or the type t points to is
+// registered.
+func (c Converters) has(t reflect.Type) bool {
+	if len(c) == 0 {
+		return false
+	}
+
+	if _, ok := c[t]; ok {
+		return true
+	}
+
+	if t.Kind() == reflect.Pointer {
+		_, ok := c[t.Elem()]
+		return ok
+	}
+
+	return false
+}
+
+var (
+	timeType            = reflect.TypeFor[time.Time]()
+	marshalerType       = reflect.TypeFor[Marshaler]()
+	unmarshalerType     = reflect.TypeFor[Unmarshaler]()
+	textMarshalerType   = reflect.TypeFor[encoding.TextMarshaler]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// StructFieldValue is the struct field passed as the value to
+// PathMarshaler.MarshalPath and PathUnmarshaler.UnmarshalPath, so it's
+// converted by MarshalStructField and UnmarshalStructField the same way as
+// the struct field marshaled without the path.
+type StructFieldValue struct {
+	Value      reflect.Value
+	Tag        IndexTag
+	Converters Converters
+}
+
+// structMarshaler is implemented by fields that marshal structs into their
+// subfields, so converters are passed down to the subfields.
+type structMarshaler interface {
+	marshalStruct(v any, converters Converters) error
+	unmarshalStruct(v any, converters Converters) error
+}
+
+// marshalAsIs passes v to f as is. Converters are passed down to the fields
+// with subfields.
+func marshalAsIs(f Field, v any, converters Converters) error {
+	if sm, ok := f.(structMarshaler); ok && len(converters) > 0 {
+		return sm.marshalStruct(v, converters)
+	}
+
+	return f.Marshal(v)
+}
+
+// unmarshalAsIs passes v to f as is. Converters are passed down to the
+// fields with subfields.
+func unmarshalAsIs(f Field, v any, converters Converters) error {
+	if sm, ok := f.(structMarshaler); ok && len(converters) > 0 {
+		return sm.unmarshalStruct(v, converters)
+	}
+
+	return f.Unmarshal(v)
+}
+
+// marshalValue sets the value of f from v. If v is StructFieldValue, it's
+// converted by MarshalStructField.
+func marshalValue(f Field, v any) error {
+	if sf, ok := v.(StructFieldValue); ok {
+		return MarshalStructField(f, sf.Value, sf.Tag, sf.Converters)
+	}
+
+	return f.Marshal(v)
+}
+
+// unmarshalValue sets v from the value of f. If v is StructFieldValue, it's
+// converted by UnmarshalStructField.
+func unmarshalValue(f Field, v any) error {
+	if sf, ok := v.(StructFieldValue); ok {
+		return UnmarshalStructField(f, sf.Value, sf.Tag, sf.Converters)
+	}
+
+	return f.Unmarshal(v)
+}
+
+// MarshalStructField sets the value of the message field f from the struct
+// field v. Besides the types supported by the field itself, the following
+// types are converted:
+//   - types registered in converters
+//   - types implementing Marshaler or encoding.TextMarshaler
+//   - time.Time formatted using the ISO 8583 layout of the format option
+//     (e.g. format=MMDDhhmmss)
+//   - bool formatted as 1 or 0 or as the true/false pair of the format
+//     option (e.g. format=Y/N)
+//   - signed and unsigned integers of any size and named string types
+//
+// Pointers to such types are converted as well.
+func MarshalStructField(f Field, v reflect.Value, tag IndexTag, converters Converters) error {
+	if conversionOf(v.Type()).marshalAsIs && !converters.has(v.Type()) {
+		return marshalAsIs(f, v.Interface(), converters)
+	}
+
+	// the value the pointer points to is converted, so it's formatted the
+	// same way as the value itself, unless the converter of the pointer
+	// type is registered
+	converted := v
+	if v.Kind() == reflect.Pointer && !v.IsNil() {
+		if _, ok := converters[v.Type()]; !ok {
+			converted = v.Elem()
+		}
+	}
+
+	handled, err := marshalConverted(f, converted, tag, converters)
+	if err != nil || handled {
+		return err
+	}
+
+	return marshalAsIs(f, v.Interface(), converters)
+}
+
+// marshalConverted marshals v into f if its type has to be converted. It
+// returns false if v should be passed to the field as is.
+func marshalConverted(f Field, v reflect.Value, tag IndexTag, converters Converters) (bool, error) {
+	if converter, ok := converters[v.Type()]; ok {
+		s, err := converter.Marshal(v.Interface(), tag.Format)
+		if err != nil {
+			return true, err
+		}
+
+		return true, f.Marshal(s)
+	}
+
+	if m, ok := asInterface[Marshaler](v, marshalerType); ok {
+		return true, m.MarshalISO8583(f)
+	}
+
+	if v.Type() == timeType {
+		if tag.Format != "" {
+			s, err := formatTime(v.Interface().(time.Time), tag.Format)
+			if err != nil {
+				return true, err
+			}
+
+			return true, f.Marshal(s)
+		}
+
+		// date/time fields support time.Time as is
+		if _, ok := f.(*DateTime); ok {
+			return false, nil
+		}
+	}
+
+	if m, ok := asInterface[encoding.TextMarshaler](v, textMarshalerType); ok {
+		text, err := m.MarshalText()
+		if err != nil {
+			return true, fmt.Errorf("marshaling %s into text: %w", v.Type(), err)
+		}
+
+		return true, f.Marshal(string(text))
+	}
+
+	switch v.Kind() { //nolint:exhaustive
+	case reflect.Bool:
+		s, err := formatBool(v.Bool(), tag.Format)
+		if err != nil {
+			return true, err
+		}
+
+		return true, f.Marshal(s)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32:
+		return true, f.Marshal(v.Int())
+	case reflect.Int64:
+		if v.Type() != reflect.TypeFor[int64]() {
+			return true, f.Marshal(v.Int())
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		// values that fit into int64 are marshaled as int64 so they are
+		// handled by all numeric fields the same way as int64 values
+		if u := v.Uint(); u <= math.MaxInt64 {
+			return true, f.Marshal(int64(u))
+		}
+
+		return true, f.Marshal(strconv.FormatUint(v.Uint(), 10))
+	case reflect.String:
+		if v.Type() != reflect.TypeFor[string]() {
+			return true, f.Marshal(v.String())
+		}
+	}
+
+	return false, nil
+}
+
+// UnmarshalStructField sets the value of the struct field v from the message
+// field f. It supports the same types as MarshalStructField. Nil pointers
+// are allocated.
+func UnmarshalStructField(f Field, v reflect.Value, tag IndexTag, converters Converters) error {
+	asIs := conversionOf(v.Type()).unmarshalAsIs && !converters.has(v.Type())
+
+	switch v.Kind() { //nolint:exhaustive
+	case reflect.Pointer:
+		if asIs {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+
+			return unmarshalAsIs(f, v.Interface(), converters)
+		}
+
+		if v.Type().Implements(unmarshalerType) {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+
+			return v.Interface().(Unmarshaler).UnmarshalISO8583(f) //nolint:forcetypeassert
+		}
+
+		// unmarshal into the new value so the pointer is not changed if
+		// the type of the value is not converted
+		elem := reflect.New(v.Type().Elem())
+
+		handled, err := unmarshalConverted(f, elem.Elem(), tag, converters)
+		if err != nil {
+			return err
+		}
+
+		if handled {
+			v.Set(elem)
+			return nil
+		}
+
+		if v.IsNil() {
+			v.Set(elem)
+		}
+
+		return unmarshalAsIs(f, v.Interface(), converters)
+	case reflect.Interface:
+		if v.IsNil() {
+			return fmt.Errorf("cannot unmarshal into nil interface %s", v.Type())
+		}
+
+		return f.Unmarshal(v.Interface())
+	}
+
+	if !asIs {
+		handled, err := unmarshalConverted(f, v, tag, converters)
+		if err != nil || handled {
+			return err
+		}
+	}
+
+	// pass reflect.Value for slices and native types so they can be
+	// modified
+	return f.Unmarshal(v)
+}
+
+// unmarshalConverted unmarshals f into addressable v if its type has to be
+// converted. It returns false if v should be passed to the field as is.
+func unmarshalConverted(f Field, v reflect.Value, tag IndexTag, converters Converters) (bool, error) {
+	if converter, ok := converters[v.Type()]; ok {
+		s, err := f.String()
+		if err != nil {
+			return true, err
+		}
+
+		value, err := converter.Unmarshal(s, tag.Format)
+		if err != nil {
+			return true, err
+		}
+
+		rv := reflect.ValueOf(value)
+		if !rv.IsValid() || !rv.Type().AssignableTo(v.Type()) {
+			return true, fmt.Errorf("converter of %s returned %T", v.Type(), value)
+		}
+
+		v.Set(rv)
+
+		return true, nil
+	}
+
+	if !v.CanAddr() {
+		return false, nil
+	}
+
+	if u, ok := v.Addr().Interface().(Unmarshaler); ok {
+		return true, u.UnmarshalISO8583(f)
+	}
+
+	if v.Type() == timeType {
+		if tag.Format != "" {
+			s, err := f.String()
+			if err != nil {
+				return true, err
+			}
+
+			t, err := parseTime(s, tag.Format)
+			if err != nil {
+				return true, err
+			}
+
+			v.Set(reflect.ValueOf(t))
+
+			return true, nil
+		}
+
+		// date/time fields support time.Time as is
+		if _, ok := f.(*DateTime); ok {
+			return false, nil
+		}
+	}
+
+	if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		s, err := f.String()
+		if err != nil {
+			return true, err
+		}
+
+		if err := u.UnmarshalText([]byte(s)); err != nil {
+			return true, fmt.Errorf("unmarshaling text into %s: %w", v.Type(), err)
+		}
+
+		return true, nil
+	}
+
+	switch v.Kind() { //nolint:exhaustive
+	case reflect.Bool:
+		s, err := f.String()
+		if err != nil {
+			return true, err
+		}
+
+		b, err := parseBool(s, tag.Format)
+		if err != nil {
+			return true, err
+		}
+
+		v.SetBool(b)
+
+		return true, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32:
+		s, err := f.String()
+		if err != nil {
+			return true, err
+		}
+
+		i, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return true, fmt.Errorf("failed to convert value into %s", v.Type())
+		}
+
+		v.SetInt(i)
+
+		return true, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		s, err := f.String()
+		if err != nil {
+			return true, err
+		}
+
+		u, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return true, fmt.Errorf("failed to convert value into %s", v.Type())
+		}
+
+		v.SetUint(u)
+
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// typeConversion describes whether values of the type are passed to the
+// field as is or have to be converted. It's computed once per type.
+type typeConversion struct {
+	marshalAsIs   bool
+	unmarshalAsIs bool
+}
+
+// typeConversions caches conversions of the types of struct fields, keyed by
+// the type.
+var typeConversions sync.Map // map[reflect.Type]typeConversion
+
+func conversionOf(t reflect.Type) typeConversion {
+	if c, ok := typeConversions.Load(t); ok {
+		return c.(typeConversion) //nolint:forcetypeassert
+	}
+
+	var c typeConversion
+
+	if t.Kind() == reflect.Pointer {
+		elem := conversionOf(t.Elem())
+
+		c.marshalAsIs = elem.marshalAsIs && !t.Implements(marshalerType) && !t.Implements(textMarshalerType)
+		c.unmarshalAsIs = elem.unmarshalAsIs && !t.Implements(unmarshalerType)
+	} else {
+		ptr := reflect.PointerTo(t)
+		asIs := t != timeType && !convertedKind(t)
+
+		c.marshalAsIs = asIs &&
+			!t.Implements(marshalerType) && !ptr.Implements(marshalerType) &&
+			!t.Implements(textMarshalerType) && !ptr.Implements(textMarshalerType)
+		c.unmarshalAsIs = asIs &&
+			!ptr.Implements(unmarshalerType) && !ptr.Implements(textUnmarshalerType)
+	}
+
+	typeConversions.Store(t, c)
+
+	return c
+}
+
+// convertedKind reports whether values of the kind of t are converted before
+// they are passed to the field.
+func convertedKind(t reflect.Type) bool {
+	switch t.Kind() { //nolint:exhaustive
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	case reflect.Int64:
+		return t != reflect.TypeFor[int64]()
+	case reflect.String:
+		return t != reflect.TypeFor[string]()
+	}
+
+	return false
+}
+
+// asInterface returns v as the interface of type typ if v or the pointer to
+// it implements it.
+func asInterface[T any](v reflect.Value, typ reflect.Type) (T, bool) {
+	var zero T
+
+	switch {
+	case v.Type().Implements(typ):
+		if v.Kind() == reflect.Pointer && v.IsNil() {
+			return zero, false
+		}
+
+		return v.Interface().(T), true //nolint:forcetypeassert
+	case v.CanAddr() && v.Addr().Type().Implements(typ):
+		return v.Addr().Interface().(T), true //nolint:forcetypeassert
+	}
+
+	return zero, false
+}
+
+// formatTime formats t using the ISO 8583 layout, e.g. MMDDhhmmss.
+func formatTime(t time.Time, layout string) (string, error) {
+	dt, err := newFormatDateTime(layout)
+	if err != nil {
+		return "", err
+	}
+
+	dt.SetValue(t)
+
+	return dt.format(), nil
+}
+
+// parseTime parses s using the ISO 8583 layout. The year missing in the
+// layout is inferred using NearestYear.
+func parseTime(s, layout string) (time.Time, error) {
+	dt, err := newFormatDateTime(layout)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if err := dt.SetBytes([]byte(s)); err != nil {
+		return time.Time{}, err
+	}
+
+	return dt.Value(), nil
+}
+
+func newFormatDateTime(layout string) (*DateTime, error) {
+	parsed, err := parseDateTimeLayout(layout)
+	if err != nil {
+		return nil, fmt.Errorf("invalid format option: %w", err)
+	}
+
+	return &DateTime{
+		spec: &Spec{
+			DateTime: &DateTimeSpec{
+				Layout: layout,
+			},
+		},
+		layout: parsed,
+	}, nil
+}
+
+// boolValues returns the true and false values of the bool format option,
+// e.g. Y/N. Default values are 1 and 0.
+func boolValues(format string) (string, string, error) {
+	if format == "" {
+		return "1", "0", nil
+	}
+
+	t, f, ok := strings.Cut(format, "/")
+	if !ok || t == "" || f == "" || t == f {
+		return "", "", fmt.Errorf("invalid format option %q for bool, expected true/false values, e.g. Y/N", format)
+	}
+
+	return t, f, nil
+}
+
+func formatBool(b bool, format string) (string, error) {
+	t, f, err := boolValues(format)
+	if err != nil {
+		return "", err
+	}
+
+	if b {
+		return t, nil
+	}
+
+	return f, nil
+}
+
+func parseBool(s, format string) (bool, error) {
+	t, f, err := boolValues(format)
+	if err != nil {
+		return false, err
+	}
+
+	switch s {
+	case t:
+		return true, nil
+	case f, "":
+		return false, nil
+	}
+
+	return false, fmt.Errorf("failed to convert value into bool, expected %s or %s", t, f)
+}
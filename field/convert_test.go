@@ -0,0 +1,222 @@
+package field
+
+import (
+	"errors"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/moov-io/iso8583/encoding"
+	"github.com/moov-io/iso8583/padding"
+	"github.com/moov-io/iso8583/prefix"
+	"github.com/stretchr/testify/require"
+)
+
+type responseCode string
+
+// cents implements Marshaler and Unmarshaler
+type cents struct {
+	value int64
+}
+
+func (c cents) MarshalISO8583(f Field) error {
+	return f.Marshal(c.value)
+}
+
+func (c *cents) UnmarshalISO8583(f Field) error {
+	numeric, ok := f.(*Numeric)
+	if !ok {
+		return errors.New("cents can be unmarshaled only from numeric field")
+	}
+
+	c.value = numeric.Value()
+
+	return nil
+}
+
+// upper is converted using the converter
+type upper string
+
+var upperConverter = NewConverter(
+	func(v upper, format string) (string, error) {
+		return strings.ToUpper(string(v)), nil
+	},
+	func(s string, format string) (upper, error) {
+		return upper(strings.ToLower(s)), nil
+	},
+)
+
+func TestStructFieldConversion(t *testing.T) {
+	stringSpec := &Spec{
+		Length:      20,
+		Description: "Field",
+		Enc:         encoding.ASCII,
+		Pref:        prefix.ASCII.LL,
+	}
+
+	numericSpec := &Spec{
+		Length:      12,
+		Description: "Field",
+		Enc:         encoding.ASCII,
+		Pref:        prefix.ASCII.Fixed,
+		Pad:         padding.Left('0'),
+	}
+
+	type data struct {
+		Code       responseCode  `iso8583:"1"`
+		Approved   bool          `iso8583:"2,format=Y/N"`
+		Partial    bool          `iso8583:"3"`
+		Count      uint32        `iso8583:"4"`
+		Small      int16         `iso8583:"5"`
+		Date       time.Time     `iso8583:"6,format=YYMMDD"`
+		IP         net.IP        `iso8583:"7"`
+		Amount     cents         `iso8583:"8"`
+		Code2      upper         `iso8583:"9"`
+		CountPtr   *uint64       `iso8583:"10"`
+		AmountPtr  *cents        `iso8583:"11"`
+		Name       string        `iso8583:"12"`
+		Timeout    time.Duration `iso8583:"13"`
+		StringTime time.Time     `iso8583:"14"`
+		DatePtr    *time.Time    `iso8583:"15,format=YYMMDD"`
+		TimePtr    *time.Time    `iso8583:"16"`
+		Code2Ptr   *upper        `iso8583:"17"`
+	}
+
+	converters := Converters{
+		reflect.TypeFor[upper](): upperConverter,
+	}
+
+	tests := []struct {
+		structField int
+		field       Field
+		expected    string
+	}{
+		{0, NewString(stringSpec), "00"},
+		{1, NewString(stringSpec), "Y"},
+		{2, NewString(stringSpec), "0"},
+		{3, NewNumeric(numericSpec), "4294967295"},
+		{4, NewString(stringSpec), "-42"},
+		{5, NewString(stringSpec), "241231"},
+		{6, NewString(stringSpec), "10.0.0.1"},
+		{7, NewNumeric(numericSpec), "150"},
+		{8, NewString(stringSpec), "ABC"},
+		{9, NewDigits(numericSpec), "18446744073709551615"},
+		{10, NewNumeric(numericSpec), "250"},
+		{11, NewString(stringSpec), "John"},
+		{12, NewNumeric(numericSpec), "30000000000"},
+		{13, NewString(stringSpec), "2024-12-31T00:00:00Z"},
+		{14, NewString(stringSpec), "241231"},
+		{15, NewString(stringSpec), "2024-12-31T00:00:00Z"},
+		{16, NewString(stringSpec), "DEF"},
+	}
+
+	maxUint64 := uint64(18446744073709551615)
+	date := time.Date(2024, time.December, 31, 0, 0, 0, 0, time.UTC)
+	code := upper("def")
+
+	src := data{
+		Code:       "00",
+		Approved:   true,
+		Partial:    false,
+		Count:      4294967295,
+		Small:      -42,
+		Date:       time.Date(2024, time.December, 31, 0, 0, 0, 0, time.UTC),
+		IP:         net.IPv4(10, 0, 0, 1),
+		Amount:     cents{value: 150},
+		Code2:      "abc",
+		CountPtr:   &maxUint64,
+		AmountPtr:  &cents{value: 250},
+		Name:       "John",
+		Timeout:    30 * time.Second,
+		StringTime: time.Date(2024, time.December, 31, 0, 0, 0, 0, time.UTC),
+		DatePtr:    &date,
+		TimePtr:    &date,
+		Code2Ptr:   &code,
+	}
+
+	srcValue := reflect.ValueOf(&src).Elem()
+	dst := data{}
+	dstValue := reflect.ValueOf(&dst).Elem()
+
+	for _, tt := range tests {
+		structField := srcValue.Type().Field(tt.structField)
+
+		t.Run(structField.Name, func(t *testing.T) {
+			tag := NewIndexTag(structField)
+
+			require.NoError(t, MarshalStructField(tt.field, srcValue.Field(tt.structField), tag, converters))
+
+			value, err := tt.field.String()
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, value)
+
+			require.NoError(t, UnmarshalStructField(tt.field, dstValue.Field(tt.structField), tag, converters))
+		})
+	}
+
+	// IP is unmarshaled into 4-byte representation
+	dst.IP = dst.IP.To16()
+	require.Equal(t, src, dst)
+
+	t.Run("date/time field supports time.Time as is", func(t *testing.T) {
+		dt := NewDateTime(&Spec{
+			Length:      10,
+			Description: "Transmission Date & Time",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.ASCII.Fixed,
+			DateTime:    &DateTimeSpec{Layout: "MMDDhhmmss"},
+		})
+
+		var value struct {
+			Time    time.Time  `iso8583:"7"`
+			TimePtr *time.Time `iso8583:"7"`
+		}
+		value.Time = time.Date(2024, time.December, 31, 23, 59, 59, 0, time.UTC)
+
+		rv := reflect.ValueOf(&value).Elem()
+		tag := NewIndexTag(rv.Type().Field(0))
+
+		require.NoError(t, MarshalStructField(dt, rv.Field(0), tag, nil))
+		require.Equal(t, value.Time, dt.Value())
+
+		value.Time = time.Time{}
+		require.NoError(t, UnmarshalStructField(dt, rv.Field(0), tag, nil))
+		require.Equal(t, dt.Value(), value.Time)
+
+		ptrTime := time.Date(2025, time.January, 2, 3, 4, 5, 0, time.UTC)
+		value.TimePtr = &ptrTime
+		tag = NewIndexTag(rv.Type().Field(1))
+
+		require.NoError(t, MarshalStructField(dt, rv.Field(1), tag, nil))
+		require.Equal(t, ptrTime, dt.Value())
+
+		value.TimePtr = nil
+		require.NoError(t, UnmarshalStructField(dt, rv.Field(1), tag, nil))
+		require.Equal(t, &ptrTime, value.TimePtr)
+	})
+
+	t.Run("returns error for invalid values", func(t *testing.T) {
+		var value struct {
+			Approved bool  `iso8583:"2,format=YN"`
+			Count    uint8 `iso8583:"4"`
+			Partial  bool  `iso8583:"3,format=Y/N"`
+		}
+
+		rv := reflect.ValueOf(&value).Elem()
+
+		value.Approved = true
+		err := MarshalStructField(NewString(stringSpec), rv.Field(0), NewIndexTag(rv.Type().Field(0)), nil)
+		require.EqualError(t, err, `invalid format option "YN" for bool, expected true/false values, e.g. Y/N`)
+
+		err = UnmarshalStructField(NewStringValue("256"), rv.Field(1), NewIndexTag(rv.Type().Field(1)), nil)
+		require.EqualError(t, err, "failed to convert value into uint8")
+
+		err = UnmarshalStructField(NewStringValue("X"), rv.Field(2), NewIndexTag(rv.Type().Field(2)), nil)
+		require.EqualError(t, err, "failed to convert value into bool, expected Y or N")
+
+		_, err = formatTime(time.Now(), "MMDDHH")
+		require.EqualError(t, err, `invalid format option: unsupported element of DateTime.Layout "MMDDHH" at position 4`)
+	})
+}
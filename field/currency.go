@@ -0,0 +1,49 @@
+package field
+
+// currencyExponents holds ISO 4217 numeric codes of the currencies which
+// exponent is not 2.
+var currencyExponents = map[string]int{
+	// currencies without minor units
+	"108": 0, // BIF
+	"152": 0, // CLP
+	"174": 0, // KMF
+	"262": 0, // DJF
+	"324": 0, // GNF
+	"352": 0, // ISK
+	"392": 0, // JPY
+	"410": 0, // KRW
+	"548": 0, // VUV
+	"600": 0, // PYG
+	"646": 0, // RWF
+	"704": 0, // VND
+	"800": 0, // UGX
+	"940": 0, // UYI
+	"950": 0, // XAF
+	"952": 0, // XOF
+	"953": 0, // XPF
+
+	// currencies with 3 digits of minor units
+	"048": 3, // BHD
+	"368": 3, // IQD
+	"400": 3, // JOD
+	"414": 3, // KWD
+	"434": 3, // LYD
+	"512": 3, // OMR
+	"788": 3, // TND
+
+	// currencies with 4 digits of minor units
+	"927": 4, // UYW
+	"990": 4, // CLF
+}
+
+// CurrencyExponent returns the ISO 4217 exponent (number of digits of the
+// minor unit) of the currency with the given numeric code, e.g. the value of
+// field 49. Currencies that are not listed in the table of exceptions are
+// assumed to have exponent 2.
+func CurrencyExponent(code string) int {
+	if exponent, ok := currencyExponents[code]; ok {
+		return exponent
+	}
+
+	return 2
+}
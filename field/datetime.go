@@ -0,0 +1,468 @@
+package field
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/moov-io/iso8583/utils"
+)
+
+var (
+	_ Field            = (*DateTime)(nil)
+	_ AppendPacker     = (*DateTime)(nil)
+	_ json.Marshaler   = (*DateTime)(nil)
+	_ json.Unmarshaler = (*DateTime)(nil)
+)
+
+// YearInference is a function type used to infer the year of the date
+// unpacked from the layout without the year, e.g. MMDD of field 13. It
+// returns t (parsed with the year 0) moved to the year inferred relative to
+// the reference time now, or the zero time if the date doesn't exist in the
+// inferred years (February 29 in non-leap years).
+type YearInference func(t, now time.Time) time.Time
+
+// NearestYear infers the year so the date is the closest to now: December
+// dates received on January 1 belong to the previous year and January dates
+// received on December 31 belong to the next one.
+func NearestYear(t, now time.Time) time.Time {
+	var nearest time.Time
+
+	for _, year := range []int{now.Year() - 1, now.Year(), now.Year() + 1} {
+		candidate, ok := withYear(t, year)
+		if !ok {
+			continue
+		}
+
+		if nearest.IsZero() || absDuration(candidate.Sub(now)) < absDuration(nearest.Sub(now)) {
+			nearest = candidate
+		}
+	}
+
+	return nearest
+}
+
+// PastYear infers the latest year so the date is not after now, e.g. for
+// dates that can't be in the future like the date of the original
+// transaction.
+func PastYear(t, now time.Time) time.Time {
+	// February 29 may be up to 8 years back
+	for year := now.Year(); year >= now.Year()-8; year-- {
+		candidate, ok := withYear(t, year)
+		if ok && !candidate.After(now) {
+			return candidate
+		}
+	}
+
+	return time.Time{}
+}
+
+// CurrentYear sets the year of now.
+func CurrentYear(t, now time.Time) time.Time {
+	candidate, ok := withYear(t, now.Year())
+	if !ok {
+		return time.Time{}
+	}
+
+	return candidate
+}
+
+// withYear returns t with the year set to year. It returns false if the
+// date doesn't exist in the year (February 29 in non-leap years).
+func withYear(t time.Time, year int) (time.Time, bool) {
+	candidate := time.Date(year, t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+
+	return candidate, candidate.Month() == t.Month()
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+
+	return d
+}
+
+// DateTimeSpec defines the layout and the location of the date/time field.
+type DateTimeSpec struct {
+	// Layout is the ISO 8583 layout of the value, e.g. MMDDhhmmss (field 7),
+	// hhmmss (field 12), MMDD (field 13) or YYMM (field 14). Supported
+	// elements are YYYY (or CCYY), YY, MM, DD, hh, mm and ss.
+	Layout string
+	// Location is the time zone of the value. If not set, UTC is used.
+	Location *time.Location
+	// YearInference infers the year of the value when the Layout has no
+	// year. If not set, NearestYear is used. Values with the time only
+	// (e.g. hhmmss) get the date of the reference time.
+	YearInference YearInference
+	// Now returns the reference time for the year inference. If not set,
+	// time.Now is used.
+	Now func() time.Time
+}
+
+// Validate validates the layout of the spec.
+func (s *DateTimeSpec) Validate() error {
+	_, err := parseDateTimeLayout(s.Layout)
+
+	return err
+}
+
+// layoutElements maps ISO 8583 layout elements to the ones of the time
+// package. Longer elements go first.
+var layoutElements = []struct {
+	iso, gotime string
+}{
+	{"YYYY", "2006"},
+	{"CCYY", "2006"},
+	{"YY", "06"},
+	{"MM", "01"},
+	{"DD", "02"},
+	{"hh", "15"},
+	{"mm", "04"},
+	{"ss", "05"},
+}
+
+// dateTimeLayout is the layout of the time package converted from the ISO
+// 8583 layout.
+type dateTimeLayout struct {
+	value   string
+	hasYear bool
+	hasDate bool
+}
+
+func parseDateTimeLayout(layout string) (dateTimeLayout, error) {
+	var result dateTimeLayout
+	var sb strings.Builder
+
+	if layout == "" {
+		return result, fmt.Errorf("DateTime spec requires DateTime.Layout to be defined")
+	}
+
+next:
+	for rest := layout; rest != ""; {
+		for _, el := range layoutElements {
+			if strings.HasPrefix(rest, el.iso) {
+				sb.WriteString(el.gotime)
+				rest = rest[len(el.iso):]
+
+				switch el.iso[0] {
+				case 'Y', 'C':
+					result.hasYear = true
+					result.hasDate = true
+				case 'M', 'D':
+					result.hasDate = true
+				}
+
+				continue next
+			}
+		}
+
+		// letters and digits have a meaning in the layouts of the time
+		// package, so only separators are allowed as is
+		if c := rest[0]; c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' {
+			return result, fmt.Errorf("unsupported element of DateTime.Layout %q at position %d", layout, len(layout)-len(rest))
+		}
+
+		sb.WriteByte(rest[0])
+		rest = rest[1:]
+	}
+
+	result.value = sb.String()
+
+	return result, nil
+}
+
+// DateTime is a field of date and/or time values like transmission date &
+// time (field 7) or expiration date (field 14). The value is stored as
+// time.Time and is packed using the layout and location of Spec.DateTime.
+type DateTime struct {
+	value  time.Time
+	spec   *Spec
+	layout dateTimeLayout
+}
+
+// NewDateTime creates a new instance of the *DateTime struct, validates and
+// sets its Spec before returning it.
+func NewDateTime(spec *Spec) *DateTime {
+	f := &DateTime{}
+	f.SetSpec(spec)
+
+	return f
+}
+
+// NewDateTimeValue creates the field with the value and without the spec.
+// Until the spec is set, the value is formatted as RFC 3339 in UTC and the
+// field can't be packed.
+func NewDateTimeValue(val time.Time) *DateTime {
+	return &DateTime{
+		value:  val,
+		layout: rfc3339Layout,
+	}
+}
+
+// rfc3339Layout is the layout of the field without the spec.
+var rfc3339Layout = dateTimeLayout{
+	value:   time.RFC3339,
+	hasYear: true,
+	hasDate: true,
+}
+
+var errNoDateTimeSpec = errors.New("date/time field has no spec")
+
+// NewInstance creates a new instance of the DateTime field with the same
+// Spec.
+func (f *DateTime) NewInstance() Field {
+	return &DateTime{
+		spec:   f.spec, // spec is validated already
+		layout: f.layout,
+	}
+}
+
+func (f *DateTime) Spec() *Spec {
+	return f.spec
+}
+
+// SetSpec validates the spec and sets it. It panics if the spec is invalid.
+func (f *DateTime) SetSpec(spec *Spec) {
+	if spec.DateTime == nil {
+		panic(fmt.Errorf("DateTime spec requires DateTime to be defined")) //nolint:forbidigo,nolintlint // as specs mostly static, we panic on spec validation errors
+	}
+
+	layout, err := parseDateTimeLayout(spec.DateTime.Layout)
+	if err != nil {
+		panic(err) //nolint:forbidigo,nolintlint // as specs mostly static, we panic on spec validation errors
+	}
+
+	f.spec = spec
+	f.layout = layout
+}
+
+// location returns the location of the value.
+func (f *DateTime) location() *time.Location {
+	if f.spec == nil || f.spec.DateTime.Location == nil {
+		return time.UTC
+	}
+
+	return f.spec.DateTime.Location
+}
+
+// now returns the reference time in the location of the value.
+func (f *DateTime) now() time.Time {
+	now := time.Now
+	if f.spec != nil && f.spec.DateTime.Now != nil {
+		now = f.spec.DateTime.Now
+	}
+
+	return now().In(f.location())
+}
+
+// SetBytes parses b using the layout of the spec and sets the value. The
+// year (and the date) missing in the layout is inferred relative to the
+// reference time.
+func (f *DateTime) SetBytes(b []byte) error {
+	if len(b) == 0 {
+		f.value = time.Time{}
+		return nil
+	}
+
+	value, err := time.ParseInLocation(f.layout.value, string(b), f.location())
+	if err != nil {
+		return utils.NewSafeError(err, "failed to parse date/time")
+	}
+
+	switch {
+	case !f.layout.hasDate:
+		now := f.now()
+		value = time.Date(now.Year(), now.Month(), now.Day(), value.Hour(), value.Minute(), value.Second(), 0, value.Location())
+	case !f.layout.hasYear:
+		inferYear := NearestYear
+		if f.spec.DateTime.YearInference != nil {
+			inferYear = f.spec.DateTime.YearInference
+		}
+
+		inferred := inferYear(value, f.now())
+		if inferred.IsZero() {
+			return fmt.Errorf("failed to infer year of date/time %s: date doesn't exist in inferred year", string(b))
+		}
+
+		value = inferred
+	}
+
+	f.value = value
+
+	return nil
+}
+
+// Bytes returns the value formatted using the layout of the spec. Zero value
+// is returned as empty bytes.
+func (f *DateTime) Bytes() ([]byte, error) {
+	if f == nil {
+		return nil, nil
+	}
+
+	return []byte(f.format()), nil
+}
+
+func (f *DateTime) String() (string, error) {
+	if f == nil {
+		return "", nil
+	}
+
+	return f.format(), nil
+}
+
+func (f *DateTime) format() string {
+	if f.value.IsZero() {
+		return ""
+	}
+
+	return f.value.In(f.location()).Format(f.layout.value)
+}
+
+func (f *DateTime) Value() time.Time {
+	if f == nil {
+		return time.Time{}
+	}
+	return f.value
+}
+
+func (f *DateTime) SetValue(v time.Time) {
+	f.value = v
+}
+
+func (f *DateTime) Pack() ([]byte, error) {
+	if f.spec == nil {
+		return nil, errNoDateTimeSpec
+	}
+
+	data := []byte(f.format())
+
+	packer := f.spec.getPacker()
+
+	return packer.Pack(data, f.spec)
+}
+
+// AppendPack appends the packed field to dst and returns the extended slice
+func (f *DateTime) AppendPack(dst []byte) ([]byte, error) {
+	if f.spec == nil {
+		return nil, errNoDateTimeSpec
+	}
+
+	return appendPackString(dst, f.format(), f.spec)
+}
+
+// PackedLen returns the length of the packed field
+func (f *DateTime) PackedLen() (int, error) {
+	if f.spec == nil {
+		return 0, errNoDateTimeSpec
+	}
+
+	if n, ok := packedLen(len(f.format()), f.spec); ok {
+		return n, nil
+	}
+
+	packed, err := f.Pack()
+	if err != nil {
+		return 0, err
+	}
+
+	return len(packed), nil
+}
+
+// returns number of bytes was read
+func (f *DateTime) Unpack(data []byte) (int, error) {
+	unpacker := f.spec.getUnpacker()
+
+	raw, bytesRead, err := unpacker.Unpack(data, f.spec)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := f.SetBytes(raw); err != nil {
+		return 0, fmt.Errorf("failed to set bytes: %w", err)
+	}
+
+	return bytesRead, nil
+}
+
+// Deprecated. Use Marshal instead
+func (f *DateTime) SetData(data interface{}) error {
+	return f.Marshal(data)
+}
+
+func (f *DateTime) Unmarshal(v interface{}) error {
+	switch val := v.(type) {
+	case reflect.Value:
+		if !val.CanSet() {
+			return fmt.Errorf("cannot set reflect.Value of type %s", val.Kind())
+		}
+
+		switch {
+		case val.Type() == reflect.TypeOf(time.Time{}):
+			val.Set(reflect.ValueOf(f.value))
+		case val.Kind() == reflect.String:
+			val.SetString(f.format())
+		default:
+			return fmt.Errorf("unsupported reflect.Value type: %s", val.Type())
+		}
+	case *time.Time:
+		*val = f.value
+	case *string:
+		*val = f.format()
+	case *DateTime:
+		val.value = f.value
+	default:
+		return fmt.Errorf("unsupported type: expected *DateTime, *time.Time, *string, or reflect.Value, got %T", v)
+	}
+
+	return nil
+}
+
+// Marshal sets the value of the field. Strings are parsed using the layout
+// of the spec.
+func (f *DateTime) Marshal(v any) error {
+	if v == nil || reflect.ValueOf(v).IsZero() {
+		f.value = time.Time{}
+		return nil
+	}
+
+	switch v := v.(type) {
+	case *DateTime:
+		f.value = v.value
+	case time.Time:
+		f.value = v
+	case *time.Time:
+		f.value = *v
+	case string:
+		return f.SetBytes([]byte(v))
+	case *string:
+		return f.SetBytes([]byte(*v))
+	default:
+		return fmt.Errorf("data does not match require *DateTime or (time.Time, *time.Time, string, *string) type")
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes the value as RFC 3339 string, so the inferred year is
+// preserved.
+func (f *DateTime) MarshalJSON() ([]byte, error) {
+	b, err := json.Marshal(f.value)
+	if err != nil {
+		return nil, utils.NewSafeError(err, "failed to JSON marshal time to bytes")
+	}
+	return b, nil
+}
+
+func (f *DateTime) UnmarshalJSON(b []byte) error {
+	var v time.Time
+	err := json.Unmarshal(b, &v)
+	if err != nil {
+		return utils.NewSafeError(err, "failed to JSON unmarshal bytes to time")
+	}
+	f.value = v
+	return nil
+}
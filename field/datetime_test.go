@@ -0,0 +1,246 @@
+package field
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/moov-io/iso8583/encoding"
+	"github.com/moov-io/iso8583/prefix"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDateTime(t *testing.T) {
+	newYear := time.Date(2025, time.January, 1, 0, 5, 0, 0, time.UTC)
+
+	transmissionSpec := &Spec{
+		Length:      10,
+		Description: "Transmission Date & Time",
+		Enc:         encoding.ASCII,
+		Pref:        prefix.ASCII.Fixed,
+		DateTime: &DateTimeSpec{
+			Layout: "MMDDhhmmss",
+			Now:    func() time.Time { return newYear },
+		},
+	}
+
+	t.Run("Pack and Unpack", func(t *testing.T) {
+		transmission := NewDateTime(transmissionSpec)
+		transmission.SetValue(time.Date(2024, time.December, 31, 23, 59, 59, 0, time.UTC))
+
+		packed, err := transmission.Pack()
+		require.NoError(t, err)
+		require.Equal(t, "1231235959", string(packed))
+
+		packedLen, err := transmission.PackedLen()
+		require.NoError(t, err)
+		require.Equal(t, 10, packedLen)
+
+		appended, err := transmission.AppendPack([]byte("x"))
+		require.NoError(t, err)
+		require.Equal(t, "x1231235959", string(appended))
+
+		transmission = NewDateTime(transmissionSpec)
+		n, err := transmission.Unpack([]byte("1231235959"))
+		require.NoError(t, err)
+		require.Equal(t, 10, n)
+
+		// December 31 received on January 1 belongs to the previous year
+		require.Equal(t, time.Date(2024, time.December, 31, 23, 59, 59, 0, time.UTC), transmission.Value())
+
+		_, err = transmission.Unpack([]byte("1332235959"))
+		require.ErrorContains(t, err, "failed to set bytes: failed to parse date/time")
+	})
+
+	t.Run("SetBytes returns error when year can't be inferred", func(t *testing.T) {
+		localDate := NewDateTime(&Spec{
+			Length:      4,
+			Description: "Local Transaction Date",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.ASCII.Fixed,
+			DateTime: &DateTimeSpec{
+				Layout: "MMDD",
+				Now:    func() time.Time { return time.Date(2026, time.June, 1, 0, 0, 0, 0, time.UTC) },
+			},
+		})
+		localDate.SetValue(time.Date(2026, time.May, 31, 0, 0, 0, 0, time.UTC))
+
+		// none of 2025, 2026 and 2027 is a leap year
+		err := localDate.SetBytes([]byte("0229"))
+		require.EqualError(t, err, "failed to infer year of date/time 0229: date doesn't exist in inferred year")
+
+		// the value is not changed
+		require.Equal(t, time.Date(2026, time.May, 31, 0, 0, 0, 0, time.UTC), localDate.Value())
+
+		_, err = localDate.Unpack([]byte("0229"))
+		require.ErrorContains(t, err, "failed to infer year of date/time 0229")
+	})
+
+	t.Run("Pack formats value in location of the spec", func(t *testing.T) {
+		localTime := NewDateTime(&Spec{
+			Length:      6,
+			Description: "Local Transaction Time",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.ASCII.Fixed,
+			DateTime: &DateTimeSpec{
+				Layout:   "hhmmss",
+				Location: time.FixedZone("UTC+3", 3*60*60),
+				Now:      func() time.Time { return newYear },
+			},
+		})
+		localTime.SetValue(time.Date(2025, time.January, 1, 10, 30, 0, 0, time.UTC))
+
+		packed, err := localTime.Pack()
+		require.NoError(t, err)
+		require.Equal(t, "133000", string(packed))
+
+		// time gets the date of the reference time in the location
+		require.NoError(t, localTime.SetBytes([]byte("133000")))
+		require.True(t, localTime.Value().Equal(time.Date(2025, time.January, 1, 10, 30, 0, 0, time.UTC)))
+	})
+
+	t.Run("Unpack expiration date", func(t *testing.T) {
+		expiration := NewDateTime(&Spec{
+			Length:      4,
+			Description: "Expiration Date",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+			DateTime: &DateTimeSpec{
+				Layout: "YYMM",
+			},
+		})
+
+		_, err := expiration.Unpack([]byte{0x27, 0x12})
+		require.NoError(t, err)
+		require.Equal(t, time.Date(2027, time.December, 1, 0, 0, 0, 0, time.UTC), expiration.Value())
+	})
+
+	t.Run("Marshal and Unmarshal", func(t *testing.T) {
+		transmission := NewDateTime(transmissionSpec)
+		value := time.Date(2025, time.January, 2, 3, 4, 5, 0, time.UTC)
+
+		require.NoError(t, transmission.Marshal(value))
+		require.Equal(t, value, transmission.Value())
+
+		require.NoError(t, transmission.Marshal("0102030405"))
+		require.Equal(t, value, transmission.Value())
+
+		require.NoError(t, transmission.Marshal(NewDateTimeValue(value)))
+		require.Equal(t, value, transmission.Value())
+
+		data := struct {
+			Time time.Time
+			Raw  string
+		}{}
+		require.NoError(t, transmission.Unmarshal(reflect.ValueOf(&data).Elem().Field(0)))
+		require.NoError(t, transmission.Unmarshal(reflect.ValueOf(&data).Elem().Field(1)))
+		require.Equal(t, value, data.Time)
+		require.Equal(t, "0102030405", data.Raw)
+
+		var tm time.Time
+		require.NoError(t, transmission.Unmarshal(&tm))
+		require.Equal(t, value, tm)
+
+		require.NoError(t, transmission.Marshal(nil))
+		require.True(t, transmission.Value().IsZero())
+
+		str, err := transmission.String()
+		require.NoError(t, err)
+		require.Equal(t, "", str)
+
+		require.EqualError(t, transmission.Marshal(42), "data does not match require *DateTime or (time.Time, *time.Time, string, *string) type")
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		transmission := NewDateTimeValue(time.Date(2024, time.December, 31, 23, 59, 59, 0, time.UTC))
+
+		data, err := json.Marshal(transmission)
+		require.NoError(t, err)
+		require.JSONEq(t, `"2024-12-31T23:59:59Z"`, string(data))
+
+		decoded := NewDateTime(transmissionSpec)
+		require.NoError(t, json.Unmarshal(data, decoded))
+		require.Equal(t, transmission.Value(), decoded.Value())
+	})
+
+	t.Run("value without spec", func(t *testing.T) {
+		value := time.Date(2024, time.December, 31, 23, 59, 59, 0, time.FixedZone("UTC+3", 3*60*60))
+		dt := NewDateTimeValue(value)
+
+		str, err := dt.String()
+		require.NoError(t, err)
+		require.Equal(t, "2024-12-31T20:59:59Z", str)
+
+		b, err := dt.Bytes()
+		require.NoError(t, err)
+		require.Equal(t, "2024-12-31T20:59:59Z", string(b))
+
+		_, err = dt.Pack()
+		require.EqualError(t, err, "date/time field has no spec")
+
+		_, err = dt.AppendPack(nil)
+		require.EqualError(t, err, "date/time field has no spec")
+
+		_, err = dt.PackedLen()
+		require.EqualError(t, err, "date/time field has no spec")
+
+		require.NoError(t, dt.SetBytes([]byte("2025-01-02T03:04:05Z")))
+		require.Equal(t, time.Date(2025, time.January, 2, 3, 4, 5, 0, time.UTC), dt.Value())
+
+		// value is formatted using the layout of the spec once it's set
+		dt.SetSpec(transmissionSpec)
+		packed, err := dt.Pack()
+		require.NoError(t, err)
+		require.Equal(t, "0102030405", string(packed))
+	})
+
+	t.Run("invalid spec panics", func(t *testing.T) {
+		require.PanicsWithError(t, "DateTime spec requires DateTime to be defined", func() {
+			NewDateTime(&Spec{Length: 4})
+		})
+
+		require.PanicsWithError(t, `unsupported element of DateTime.Layout "MMDDHH" at position 4`, func() {
+			NewDateTime(&Spec{Length: 6, DateTime: &DateTimeSpec{Layout: "MMDDHH"}})
+		})
+	})
+}
+
+func TestYearInference(t *testing.T) {
+	parse := func(t *testing.T, value string) time.Time {
+		t.Helper()
+
+		parsed, err := time.Parse("0102", value)
+		require.NoError(t, err)
+
+		return parsed
+	}
+
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name     string
+		infer    YearInference
+		value    string
+		now      time.Time
+		expected time.Time
+	}{
+		{"nearest: December on January 1", NearestYear, "1231", date(2025, time.January, 1), date(2024, time.December, 31)},
+		{"nearest: January on December 31", NearestYear, "0101", date(2024, time.December, 31), date(2025, time.January, 1)},
+		{"nearest: same year", NearestYear, "0615", date(2025, time.June, 1), date(2025, time.June, 15)},
+		{"nearest: February 29 in leap year", NearestYear, "0229", date(2024, time.March, 1), date(2024, time.February, 29)},
+		{"past: future date belongs to previous year", PastYear, "0615", date(2025, time.June, 1), date(2024, time.June, 15)},
+		{"past: February 29", PastYear, "0229", date(2027, time.March, 1), date(2024, time.February, 29)},
+		{"current: year of now", CurrentYear, "1231", date(2025, time.January, 1), date(2025, time.December, 31)},
+		{"nearest: February 29 without leap year nearby", NearestYear, "0229", date(2026, time.June, 1), time.Time{}},
+		{"current: February 29 in non-leap year", CurrentYear, "0229", date(2025, time.March, 1), time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, tt.infer(parse(t, tt.value), tt.now))
+		})
+	}
+}
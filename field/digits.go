@@ -0,0 +1,346 @@
+package field
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+
+	"github.com/moov-io/iso8583/utils"
+)
+
+var (
+	_ Field            = (*Digits)(nil)
+	_ AppendPacker     = (*Digits)(nil)
+	_ json.Marshaler   = (*Digits)(nil)
+	_ json.Unmarshaler = (*Digits)(nil)
+)
+
+// Digits is a numeric field of arbitrary length, e.g. 19-digit PAN or 20+
+// digit settlement counters that overflow int64 of the Numeric field. The
+// value is stored as a string of digits, so leading zeros are preserved
+// (unless they are removed as padding by the spec).
+type Digits struct {
+	value string
+	spec  *Spec
+}
+
+func NewDigits(spec *Spec) *Digits {
+	return &Digits{
+		spec: spec,
+	}
+}
+
+func NewDigitsValue(val string) *Digits {
+	return &Digits{
+		value: val,
+	}
+}
+
+// NewInstance creates a new instance of the Digits field with the same Spec.
+func (f *Digits) NewInstance() Field {
+	return &Digits{
+		spec: f.spec,
+	}
+}
+
+func (f *Digits) Spec() *Spec {
+	return f.spec
+}
+
+func (f *Digits) SetSpec(spec *Spec) {
+	f.spec = spec
+}
+
+func (f *Digits) SetBytes(b []byte) error {
+	return f.setBytes(b, false)
+}
+
+// setBytes checks that b contains only digits and satisfies the constraints
+// of the spec and sets it.
+func (f *Digits) setBytes(b []byte, unpacking bool) error {
+	value := string(b)
+
+	if err := checkDigits(value); err != nil {
+		return err
+	}
+
+	if err := f.spec.checkConstraints(value, unpacking); err != nil {
+		return err
+	}
+
+	f.value = value
+
+	return nil
+}
+
+func (f *Digits) Bytes() ([]byte, error) {
+	if f == nil {
+		return nil, nil
+	}
+	return []byte(f.value), nil
+}
+
+func (f *Digits) String() (string, error) {
+	if f == nil {
+		return "", nil
+	}
+	return f.value, nil
+}
+
+// Value returns the digits of the field including leading zeros.
+func (f *Digits) Value() string {
+	if f == nil {
+		return ""
+	}
+	return f.value
+}
+
+func (f *Digits) SetValue(v string) {
+	f.value = v
+}
+
+// Uint64 returns the value of the field as uint64. It returns an error if
+// the value overflows uint64.
+func (f *Digits) Uint64() (uint64, error) {
+	if f == nil || f.value == "" {
+		return 0, nil
+	}
+
+	val, err := strconv.ParseUint(f.value, 10, 64)
+	if err != nil {
+		return 0, utils.NewSafeError(err, "failed to convert digits into uint64")
+	}
+
+	return val, nil
+}
+
+// SetUint64 sets the value of the field to the digits of v.
+func (f *Digits) SetUint64(v uint64) {
+	f.value = strconv.FormatUint(v, 10)
+}
+
+// BigInt returns the value of the field as *big.Int.
+func (f *Digits) BigInt() (*big.Int, error) {
+	if f == nil || f.value == "" {
+		return new(big.Int), nil
+	}
+
+	val, ok := new(big.Int).SetString(f.value, 10)
+	if !ok {
+		return nil, errors.New("failed to convert digits into big.Int")
+	}
+
+	return val, nil
+}
+
+// SetBigInt sets the value of the field to the digits of v. It returns an
+// error if v is negative.
+func (f *Digits) SetBigInt(v *big.Int) error {
+	if v.Sign() < 0 {
+		return errors.New("negative value can't be set as digits")
+	}
+
+	f.value = v.String()
+
+	return nil
+}
+
+func (f *Digits) Pack() ([]byte, error) {
+	data := []byte(f.value)
+
+	packer := f.spec.getPacker()
+
+	return packer.Pack(data, f.spec)
+}
+
+// AppendPack appends the packed field to dst and returns the extended slice
+func (f *Digits) AppendPack(dst []byte) ([]byte, error) {
+	return appendPackString(dst, f.value, f.spec)
+}
+
+// PackedLen returns the length of the packed field
+func (f *Digits) PackedLen() (int, error) {
+	if n, ok := packedLen(len(f.value), f.spec); ok {
+		return n, nil
+	}
+
+	packed, err := f.Pack()
+	if err != nil {
+		return 0, err
+	}
+
+	return len(packed), nil
+}
+
+// returns number of bytes was read
+func (f *Digits) Unpack(data []byte) (int, error) {
+	unpacker := f.spec.getUnpacker()
+
+	raw, bytesRead, err := unpacker.Unpack(data, f.spec)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := f.setBytes(raw, true); err != nil {
+		return 0, fmt.Errorf("failed to set bytes: %w", err)
+	}
+
+	return bytesRead, nil
+}
+
+// Deprecated. Use Marshal instead
+func (f *Digits) SetData(data interface{}) error {
+	return f.Marshal(data)
+}
+
+func (f *Digits) Unmarshal(v interface{}) error {
+	switch val := v.(type) {
+	case reflect.Value:
+		if !val.CanSet() {
+			return fmt.Errorf("cannot set reflect.Value of type %s", val.Kind())
+		}
+
+		switch val.Kind() { //nolint:exhaustive
+		case reflect.String:
+			val.SetString(f.value)
+		case reflect.Uint64:
+			u, err := f.Uint64()
+			if err != nil {
+				return err
+			}
+			val.SetUint(u)
+		default:
+			return fmt.Errorf("unsupported reflect.Value type: %s", val.Kind())
+		}
+	case *string:
+		*val = f.value
+	case *uint64:
+		u, err := f.Uint64()
+		if err != nil {
+			return err
+		}
+		*val = u
+	case *big.Int:
+		b, err := f.BigInt()
+		if err != nil {
+			return err
+		}
+		val.Set(b)
+	case *Digits:
+		val.value = f.value
+	default:
+		return fmt.Errorf("unsupported type: expected *Digits, *string, *uint64, *big.Int, or reflect.Value, got %T", v)
+	}
+
+	return nil
+}
+
+// Marshal sets the value of the field. It returns an error if the value
+// contains anything other than digits or does not satisfy the constraints of
+// the spec.
+func (f *Digits) Marshal(v any) error {
+	value := f.value
+
+	if err := f.marshal(v); err != nil {
+		f.value = value
+		return err
+	}
+
+	// zero value resets the field
+	if f.value == "" {
+		return nil
+	}
+
+	if err := checkDigits(f.value); err != nil {
+		f.value = value
+		return err
+	}
+
+	if err := f.spec.checkConstraints(f.value, false); err != nil {
+		f.value = value
+		return err
+	}
+
+	return nil
+}
+
+func (f *Digits) marshal(v any) error {
+	if v == nil || reflect.ValueOf(v).IsZero() {
+		f.value = ""
+		return nil
+	}
+
+	switch v := v.(type) {
+	case *Digits:
+		f.value = v.value
+	case string:
+		f.value = v
+	case *string:
+		f.value = *v
+	case uint64:
+		f.SetUint64(v)
+	case *uint64:
+		f.SetUint64(*v)
+	case int64:
+		return f.setInt64(v)
+	case *int64:
+		return f.setInt64(*v)
+	case *big.Int:
+		return f.SetBigInt(v)
+	default:
+		return fmt.Errorf("data does not match require *Digits or (string, *string, uint64, *uint64, int64, *int64, *big.Int) type")
+	}
+
+	return nil
+}
+
+func (f *Digits) setInt64(v int64) error {
+	if v < 0 {
+		return errors.New("negative value can't be set as digits")
+	}
+
+	f.value = strconv.FormatInt(v, 10)
+
+	return nil
+}
+
+// MarshalJSON encodes the digits as a JSON string, so values wider than the
+// JSON number precision and leading zeros are preserved.
+func (f *Digits) MarshalJSON() ([]byte, error) {
+	b, err := json.Marshal(f.value)
+	if err != nil {
+		return nil, utils.NewSafeError(err, "failed to JSON marshal string to bytes")
+	}
+	return b, nil
+}
+
+// UnmarshalJSON decodes the digits from a JSON string or number.
+func (f *Digits) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+
+	// number is used as is to keep its precision
+	if len(b) > 0 && b[0] != '"' {
+		return f.SetBytes(b)
+	}
+
+	var v string
+	if err := json.Unmarshal(b, &v); err != nil {
+		return utils.NewSafeError(err, "failed to JSON unmarshal bytes to string")
+	}
+	return f.SetBytes([]byte(v))
+}
+
+// checkDigits returns an error if s contains anything other than digits.
+func checkDigits(s string) error {
+	for i := range len(s) {
+		if s[i] < '0' || s[i] > '9' {
+			return errors.New("value contains non-digit characters")
+		}
+	}
+
+	return nil
+}
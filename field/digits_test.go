@@ -0,0 +1,202 @@
+package field
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/moov-io/iso8583/encoding"
+	"github.com/moov-io/iso8583/padding"
+	"github.com/moov-io/iso8583/prefix"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDigits(t *testing.T) {
+	// 19-digit PAN overflows int64
+	panSpec := &Spec{
+		Length:      19,
+		Description: "Primary Account Number",
+		Enc:         encoding.ASCII,
+		Pref:        prefix.ASCII.LL,
+	}
+
+	// 22-digit settlement counter
+	countSpec := &Spec{
+		Length:      22,
+		Description: "Settlement Count",
+		Enc:         encoding.BCD,
+		Pref:        prefix.BCD.Fixed,
+	}
+
+	t.Run("Pack and Unpack", func(t *testing.T) {
+		pan := NewDigits(panSpec)
+		require.NoError(t, pan.SetBytes([]byte("9999999999999999999")))
+
+		packed, err := pan.Pack()
+		require.NoError(t, err)
+		require.Equal(t, "199999999999999999999", string(packed))
+
+		packedLen, err := pan.PackedLen()
+		require.NoError(t, err)
+		require.Equal(t, len(packed), packedLen)
+
+		appended, err := pan.AppendPack([]byte("x"))
+		require.NoError(t, err)
+		require.Equal(t, "x"+string(packed), string(appended))
+
+		pan = NewDigits(panSpec)
+		n, err := pan.Unpack([]byte("190000123456789012345"))
+		require.NoError(t, err)
+		require.Equal(t, 21, n)
+		require.Equal(t, "0000123456789012345", pan.Value())
+
+		count := NewDigits(countSpec)
+		count.SetValue("0012345678901234567890")
+
+		packed, err = count.Pack()
+		require.NoError(t, err)
+		require.Equal(t, []byte{0x00, 0x12, 0x34, 0x56, 0x78, 0x90, 0x12, 0x34, 0x56, 0x78, 0x90}, packed)
+
+		count = NewDigits(countSpec)
+		_, err = count.Unpack(packed)
+		require.NoError(t, err)
+		require.Equal(t, "0012345678901234567890", count.Value())
+	})
+
+	t.Run("Unpack returns error for non-digit characters", func(t *testing.T) {
+		_, err := NewDigits(panSpec).Unpack([]byte("04123A"))
+		require.EqualError(t, err, "failed to set bytes: value contains non-digit characters")
+	})
+
+	t.Run("Pack pads value", func(t *testing.T) {
+		digits := NewDigits(&Spec{
+			Length:      20,
+			Description: "Field",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.ASCII.Fixed,
+			Pad:         padding.Left('0'),
+		})
+		digits.SetUint64(18446744073709551615)
+
+		packed, err := digits.Pack()
+		require.NoError(t, err)
+		require.Equal(t, "18446744073709551615", string(packed))
+	})
+
+	t.Run("Value accessors", func(t *testing.T) {
+		digits := NewDigitsValue("00018446744073709551615")
+
+		u, err := digits.Uint64()
+		require.NoError(t, err)
+		require.Equal(t, uint64(18446744073709551615), u)
+
+		b, err := digits.BigInt()
+		require.NoError(t, err)
+		require.Equal(t, "18446744073709551615", b.String())
+
+		digits.SetValue("18446744073709551616")
+		_, err = digits.Uint64()
+		require.ErrorContains(t, err, "failed to convert digits into uint64")
+
+		wide, ok := new(big.Int).SetString("123456789012345678901234567890", 10)
+		require.True(t, ok)
+		require.NoError(t, digits.SetBigInt(wide))
+		require.Equal(t, "123456789012345678901234567890", digits.Value())
+
+		require.EqualError(t, digits.SetBigInt(big.NewInt(-1)), "negative value can't be set as digits")
+	})
+
+	t.Run("Marshal", func(t *testing.T) {
+		digits := NewDigits(panSpec)
+
+		require.NoError(t, digits.Marshal("0000123456789012345"))
+		require.Equal(t, "0000123456789012345", digits.Value())
+
+		require.NoError(t, digits.Marshal(uint64(4242424242424242)))
+		require.Equal(t, "4242424242424242", digits.Value())
+
+		wide, _ := new(big.Int).SetString("9999999999999999999", 10)
+		require.NoError(t, digits.Marshal(wide))
+		require.Equal(t, "9999999999999999999", digits.Value())
+
+		require.NoError(t, digits.Marshal(NewDigitsValue("42")))
+		require.Equal(t, "42", digits.Value())
+
+		require.EqualError(t, digits.Marshal("4242-4242"), "value contains non-digit characters")
+		require.Equal(t, "42", digits.Value())
+
+		require.EqualError(t, digits.Marshal(big.NewInt(-42)), "negative value can't be set as digits")
+		require.Equal(t, "42", digits.Value())
+
+		require.NoError(t, digits.Marshal(nil))
+		require.Equal(t, "", digits.Value())
+	})
+
+	t.Run("Unmarshal", func(t *testing.T) {
+		digits := NewDigitsValue("0000123456789012345")
+
+		var str string
+		require.NoError(t, digits.Unmarshal(&str))
+		require.Equal(t, "0000123456789012345", str)
+
+		var u uint64
+		require.NoError(t, digits.Unmarshal(&u))
+		require.Equal(t, uint64(123456789012345), u)
+
+		b := new(big.Int)
+		require.NoError(t, digits.Unmarshal(b))
+		require.Equal(t, "123456789012345", b.String())
+
+		other := NewDigits(panSpec)
+		require.NoError(t, digits.Unmarshal(other))
+		require.Equal(t, "0000123456789012345", other.Value())
+
+		data := struct {
+			PAN   string
+			Count uint64
+		}{}
+		require.NoError(t, digits.Unmarshal(reflect.ValueOf(&data).Elem().Field(0)))
+		require.NoError(t, digits.Unmarshal(reflect.ValueOf(&data).Elem().Field(1)))
+		require.Equal(t, "0000123456789012345", data.PAN)
+		require.Equal(t, uint64(123456789012345), data.Count)
+
+		var i int64
+		require.EqualError(t, digits.Unmarshal(&i), "unsupported type: expected *Digits, *string, *uint64, *big.Int, or reflect.Value, got *int64")
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		digits := NewDigitsValue("0012345678901234567890")
+
+		data, err := json.Marshal(digits)
+		require.NoError(t, err)
+		require.JSONEq(t, `"0012345678901234567890"`, string(data))
+
+		decoded := NewDigits(countSpec)
+		require.NoError(t, json.Unmarshal(data, decoded))
+		require.Equal(t, "0012345678901234567890", decoded.Value())
+
+		require.NoError(t, json.Unmarshal([]byte("12345678901234567890"), decoded))
+		require.Equal(t, "12345678901234567890", decoded.Value())
+
+		require.Error(t, json.Unmarshal([]byte(`"1.5"`), decoded))
+	})
+}
+
+func TestDigitsNil(t *testing.T) {
+	var digits *Digits = nil
+
+	bs, err := digits.Bytes()
+	require.NoError(t, err)
+	require.Nil(t, bs)
+
+	value, err := digits.String()
+	require.NoError(t, err)
+	require.Equal(t, "", value)
+
+	require.Equal(t, "", digits.Value())
+
+	u, err := digits.Uint64()
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), u)
+}
@@ -27,6 +27,66 @@ type PathUnsetter interface {
 	UnsetPath(idPaths ...string) error
 }
 
+// AppendPacker is an optional interface implemented by fields that can append
+// their packed value to the buffer provided by the caller and compute the
+// length of the packed value without packing it. Use AppendPack and PackedLen
+// functions to work with any field.
+type AppendPacker interface {
+	// AppendPack appends the packed field value to dst and returns the
+	// extended slice
+	AppendPack(dst []byte) ([]byte, error)
+
+	// PackedLen returns the number of bytes Pack would return
+	PackedLen() (int, error)
+}
+
+// AppendPack appends the packed field to dst and returns the extended slice.
+// If the field does not implement AppendPacker, the result of Pack is
+// appended.
+func AppendPack(dst []byte, f Field) ([]byte, error) {
+	if ap, ok := f.(AppendPacker); ok {
+		return ap.AppendPack(dst)
+	}
+
+	packed, err := f.Pack()
+	if err != nil {
+		return nil, err
+	}
+
+	return append(dst, packed...), nil
+}
+
+// PackedLen returns the length of the packed field. If the field does not
+// implement AppendPacker, the field is packed to get its length.
+func PackedLen(f Field) (int, error) {
+	if ap, ok := f.(AppendPacker); ok {
+		return ap.PackedLen()
+	}
+
+	packed, err := f.Pack()
+	if err != nil {
+		return 0, err
+	}
+
+	return len(packed), nil
+}
+
+// PeekLen returns the number of bytes the packed field f takes at the
+// beginning of data. Only the length prefix of the field is decoded, so the
+// value of the field is neither decoded nor validated. It returns false if
+// the length can't be determined without unpacking the field, e.g. when the
+// field has a custom Unpacker.
+func PeekLen(f Field, data []byte) (int, bool, error) {
+	switch f.(type) {
+	case *String, *Numeric, *Binary, *Hex:
+		return peekLen(data, f.Spec())
+	case *Composite:
+		return peekCompositeLen(data, f.Spec())
+	}
+
+	return 0, false, nil
+}
+
 type Field interface {
 	// Spec returns the field spec
 	Spec() *Spec
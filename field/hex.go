@@ -12,6 +12,7 @@ import (
 
 var (
 	_ Field            = (*Hex)(nil)
+	_ AppendPacker     = (*Hex)(nil)
 	_ json.Marshaler   = (*Hex)(nil)
 	_ json.Unmarshaler = (*Hex)(nil)
 )
@@ -57,7 +58,19 @@ func (f *Hex) SetSpec(spec *Spec) {
 }
 
 func (f *Hex) SetBytes(b []byte) error {
-	f.value = strings.ToUpper(hex.EncodeToString(b))
+	return f.setBytes(b, false)
+}
+
+// setBytes checks the hex representation of b against the constraints of
+// the spec and sets it as the value.
+func (f *Hex) setBytes(b []byte, unpacking bool) error {
+	value := strings.ToUpper(hex.EncodeToString(b))
+
+	if err := f.spec.checkConstraints(value, unpacking); err != nil {
+		return err
+	}
+
+	f.value = value
 	return nil
 }
 
@@ -97,6 +110,32 @@ func (f *Hex) Pack() ([]byte, error) {
 	return packer.Pack(data, f.spec)
 }
 
+// AppendPack appends the packed field to dst and returns the extended slice
+func (f *Hex) AppendPack(dst []byte) ([]byte, error) {
+	data, err := f.Bytes()
+	if err != nil {
+		return nil, utils.NewSafeErrorf(err, "converting hex field into bytes")
+	}
+
+	return appendPack(dst, data, f.spec)
+}
+
+// PackedLen returns the length of the packed field
+func (f *Hex) PackedLen() (int, error) {
+	if len(f.value)%2 == 0 {
+		if n, ok := packedLen(len(f.value)/2, f.spec); ok {
+			return n, nil
+		}
+	}
+
+	packed, err := f.Pack()
+	if err != nil {
+		return 0, err
+	}
+
+	return len(packed), nil
+}
+
 func (f *Hex) Unpack(data []byte) (int, error) {
 	unpacker := f.spec.getUnpacker()
 
@@ -105,7 +144,7 @@ func (f *Hex) Unpack(data []byte) (int, error) {
 		return 0, err
 	}
 
-	if err := f.SetBytes(raw); err != nil {
+	if err := f.setBytes(raw, true); err != nil {
 		return 0, fmt.Errorf("failed to set bytes: %w", err)
 	}
 
@@ -147,7 +186,24 @@ func (f *Hex) Unmarshal(v interface{}) error {
 	return nil
 }
 
+// Marshal sets the value of the field. It returns an error if the value
+// does not satisfy the constraints of the spec.
 func (f *Hex) Marshal(v interface{}) error {
+	value := f.value
+
+	if err := f.marshal(v); err != nil {
+		return err
+	}
+
+	if err := f.spec.checkConstraints(f.value, false); err != nil {
+		f.value = value
+		return err
+	}
+
+	return nil
+}
+
+func (f *Hex) marshal(v interface{}) error {
 	if v == nil || reflect.ValueOf(v).IsZero() {
 		f.value = ""
 		return nil
@@ -161,9 +217,9 @@ func (f *Hex) Marshal(v interface{}) error {
 	case *string:
 		f.value = *v
 	case []byte:
-		f.SetBytes(v)
+		f.value = strings.ToUpper(hex.EncodeToString(v))
 	case *[]byte:
-		f.SetBytes(*v)
+		f.value = strings.ToUpper(hex.EncodeToString(*v))
 	default:
 		return fmt.Errorf("data does not match required *Hex or (string, *string, []byte, *[]byte) type")
 	}
@@ -18,6 +18,10 @@ type IndexTag struct {
 	// 1 for this field. Default behavior is to omit the field from the
 	// message when we Marshal data if it's zero value.
 	KeepZero bool
+	// Format is the layout of the value converted to and from the type of
+	// the struct field, e.g. MMDDhhmmss for time.Time or Y/N for bool. It's
+	// set with the format option, e.g. `iso8583:"7,format=MMDDhhmmss"`.
+	Format string
 }
 
 func NewIndexTag(field reflect.StructField) IndexTag {
@@ -44,7 +48,7 @@ func extractTagInfo(field reflect.StructField) IndexTag {
 		}
 	}
 
-	// format of the value is "id[,keep_zero_value]"
+	// format of the value is "id[,keepzero][,format=layout]"
 	// id is the id of the field
 	// let's parse it
 	if value == "" {
@@ -63,6 +67,7 @@ func extractTagInfo(field reflect.StructField) IndexTag {
 		ID:       id,
 		Tag:      tag,
 		KeepZero: opts.Contains("keepzero"),
+		Format:   opts.Value("format"),
 	}
 }
 
@@ -96,3 +101,15 @@ func parseTag(tag string) (string, tagOptions) {
 func (o tagOptions) Contains(optionName string) bool {
 	return slices.Contains(o, optionName)
 }
+
+// Value returns the value of the option set as name=value or an empty
+// string if the option is not set.
+func (o tagOptions) Value(optionName string) string {
+	for _, opt := range o {
+		if name, value, ok := strings.Cut(opt, "="); ok && name == optionName {
+			return value
+		}
+	}
+
+	return ""
+}
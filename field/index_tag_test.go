@@ -46,6 +46,27 @@ func TestFieldIndex(t *testing.T) {
 		require.Equal(t, 3, indexTag.ID)
 	})
 
+	t.Run("returns options from field tag", func(t *testing.T) {
+		st := reflect.ValueOf(&struct {
+			Date     string `iso8583:"7,keepzero,format=MMDDhhmmss"`
+			Approved bool   `iso8583:"39,format=Y/N"`
+			Amount   int64  `iso8583:"4"`
+		}{}).Elem()
+
+		indexTag := NewIndexTag(st.Type().Field(0))
+		require.Equal(t, 7, indexTag.ID)
+		require.True(t, indexTag.KeepZero)
+		require.Equal(t, "MMDDhhmmss", indexTag.Format)
+
+		indexTag = NewIndexTag(st.Type().Field(1))
+		require.Equal(t, 39, indexTag.ID)
+		require.False(t, indexTag.KeepZero)
+		require.Equal(t, "Y/N", indexTag.Format)
+
+		indexTag = NewIndexTag(st.Type().Field(2))
+		require.Empty(t, indexTag.Format)
+	})
+
 	t.Run("returns empty string when no tag and field name does not match the pattern", func(t *testing.T) {
 		st := reflect.ValueOf(&struct {
 			Name string
@@ -11,6 +11,7 @@ import (
 
 var (
 	_ Field            = (*Numeric)(nil)
+	_ AppendPacker     = (*Numeric)(nil)
 	_ json.Marshaler   = (*Numeric)(nil)
 	_ json.Unmarshaler = (*Numeric)(nil)
 )
@@ -48,20 +49,34 @@ func (f *Numeric) SetSpec(spec *Spec) {
 }
 
 func (f *Numeric) SetBytes(b []byte) error {
+	return f.setBytes(b, false)
+}
+
+// setBytes parses b, checks the value against the constraints of the spec
+// and sets it.
+func (f *Numeric) setBytes(b []byte, unpacking bool) error {
 	if len(b) == 0 {
 		// for a length 0 raw, string(raw) would become "" which makes Atoi return an error
 		// however for example "0000" (value 0 left-padded with '0') should have 0 as output, not an error
 		// so if the length of raw is 0, set f.value to 0 instead of parsing the raw
 		f.value = 0
-	} else {
-		// otherwise parse the raw to an int
-		val, err := strconv.ParseInt(string(b), 10, 64)
-		if err != nil {
-			return utils.NewSafeError(err, "failed to convert into number")
+		return nil
+	}
+
+	// otherwise parse the raw to an int
+	val, err := strconv.ParseInt(string(b), 10, 64)
+	if err != nil {
+		return utils.NewSafeError(err, "failed to convert into number")
+	}
+
+	if f.spec.checksConstraints(unpacking) {
+		if err := f.spec.checkConstraints(strconv.FormatInt(val, 10), unpacking); err != nil {
+			return err
 		}
-		f.value = val
 	}
 
+	f.value = val
+
 	return nil
 }
 
@@ -98,6 +113,33 @@ func (f *Numeric) Pack() ([]byte, error) {
 	return packer.Pack(data, f.spec)
 }
 
+// AppendPack appends the packed field to dst and returns the extended slice
+func (f *Numeric) AppendPack(dst []byte) ([]byte, error) {
+	if _, _, ok := specAppenders(f.spec); !ok {
+		return appendPack(dst, []byte(strconv.FormatInt(f.value, 10)), f.spec)
+	}
+
+	start := len(dst)
+	dst = strconv.AppendInt(dst, f.value, 10)
+
+	return appendPackInPlace(dst, start, f.spec)
+}
+
+// PackedLen returns the length of the packed field
+func (f *Numeric) PackedLen() (int, error) {
+	var buf [20]byte
+	if n, ok := packedLen(len(strconv.AppendInt(buf[:0], f.value, 10)), f.spec); ok {
+		return n, nil
+	}
+
+	packed, err := f.Pack()
+	if err != nil {
+		return 0, err
+	}
+
+	return len(packed), nil
+}
+
 // returns number of bytes was read
 func (f *Numeric) Unpack(data []byte) (int, error) {
 	unpacker := f.spec.getUnpacker()
@@ -107,7 +149,7 @@ func (f *Numeric) Unpack(data []byte) (int, error) {
 		return 0, err
 	}
 
-	if err := f.SetBytes(raw); err != nil {
+	if err := f.setBytes(raw, true); err != nil {
 		return 0, fmt.Errorf("failed to set bytes: %w", err)
 	}
 
@@ -149,7 +191,31 @@ func (f *Numeric) Unmarshal(v interface{}) error {
 	return nil
 }
 
+// Marshal sets the value of the field. It returns an error if the value
+// does not satisfy the constraints of the spec.
 func (f *Numeric) Marshal(v any) error {
+	value := f.value
+
+	if err := f.marshal(v); err != nil {
+		return err
+	}
+
+	// zero value resets the field
+	if f.value == 0 {
+		return nil
+	}
+
+	if f.spec.checksConstraints(false) {
+		if err := f.spec.checkConstraints(strconv.FormatInt(f.value, 10), false); err != nil {
+			f.value = value
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (f *Numeric) marshal(v any) error {
 	if v == nil || reflect.ValueOf(v).IsZero() {
 		f.value = 0
 		return nil
@@ -2,6 +2,9 @@ package field
 
 import (
 	"fmt"
+
+	"github.com/moov-io/iso8583/encoding"
+	"github.com/moov-io/iso8583/prefix"
 )
 
 type defaultPacker struct{}
@@ -28,6 +31,155 @@ func (p defaultPacker) Pack(value []byte, spec *Spec) ([]byte, error) {
 	return append(lengthPrefix, encodedValue...), nil
 }
 
+// appendPack appends the value packed according to the spec to dst. When the
+// spec uses the default packer and its encoder and prefixer implement
+// encoding.Appender and prefix.Appender, the value is encoded directly into
+// dst. Otherwise, it falls back to the packer of the spec.
+func appendPack(dst, value []byte, spec *Spec) ([]byte, error) {
+	enc, pref, ok := specAppenders(spec)
+	if !ok {
+		packed, err := spec.getPacker().Pack(value, spec)
+		if err != nil {
+			return nil, err
+		}
+
+		return append(dst, packed...), nil
+	}
+
+	// pad the value if needed
+	if spec.Pad != nil {
+		value = spec.Pad.Pad(value, spec.Length)
+	}
+
+	// reserve space for the length prefix and encode the value after it,
+	// so errors are reported in the same order as by the default packer
+	start := len(dst)
+	for range pref.EncodedLen() {
+		dst = append(dst, 0)
+	}
+
+	dst, err := enc.AppendEncode(dst, value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode content: %w", err)
+	}
+
+	// encode the length into the reserved space
+	lengthPrefix, err := pref.AppendLength(dst[start:start], spec.Length, len(value))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode length: %w", err)
+	}
+
+	if len(lengthPrefix) != pref.EncodedLen() {
+		return nil, fmt.Errorf("failed to encode length: expected %d bytes, got %d", pref.EncodedLen(), len(lengthPrefix))
+	}
+
+	return dst, nil
+}
+
+// appendPackString appends the string value packed according to the spec to
+// dst. To avoid converting the value into a new byte slice, the value is
+// appended to dst, packed after itself and then moved into its place.
+func appendPackString(dst []byte, value string, spec *Spec) ([]byte, error) {
+	if _, _, ok := specAppenders(spec); !ok {
+		return appendPack(dst, []byte(value), spec)
+	}
+
+	start := len(dst)
+	dst = append(dst, value...)
+
+	return appendPackInPlace(dst, start, spec)
+}
+
+// appendPackInPlace packs the value stored in dst[start:] and replaces it with
+// the packed value.
+func appendPackInPlace(dst []byte, start int, spec *Spec) ([]byte, error) {
+	value := dst[start:]
+
+	dst, err := appendPack(dst, value, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	n := copy(dst[start:], dst[start+len(value):])
+
+	return dst[:start+n], nil
+}
+
+// packedLen returns the length of the value of valueLen bytes packed
+// according to the spec. It returns false if the length can't be computed
+// without packing the value, e.g. when the spec has a custom packer.
+func packedLen(valueLen int, spec *Spec) (int, bool) {
+	enc, pref, ok := specAppenders(spec)
+	if !ok {
+		return 0, false
+	}
+
+	if spec.Pad != nil && valueLen < spec.Length {
+		valueLen += (spec.Length - valueLen) * len(spec.Pad.Inspect())
+	}
+
+	return pref.EncodedLen() + enc.EncodedLen(valueLen), true
+}
+
+func specAppenders(spec *Spec) (encoding.Appender, prefix.Appender, bool) {
+	if spec.Packer != nil {
+		return nil, nil, false
+	}
+
+	enc, ok := spec.Enc.(encoding.Appender)
+	if !ok {
+		return nil, nil, false
+	}
+
+	pref, ok := spec.Pref.(prefix.Appender)
+	if !ok {
+		return nil, nil, false
+	}
+
+	return enc, pref, true
+}
+
+// peekLen returns the number of bytes the value packed by the default packer
+// takes at the beginning of data. It returns false if the spec uses custom
+// unpacker or the encoder does not report the encoded length.
+func peekLen(data []byte, spec *Spec) (int, bool, error) {
+	if spec.Unpacker != nil {
+		return 0, false, nil
+	}
+
+	enc, ok := spec.Enc.(encoding.Appender)
+	if !ok {
+		return 0, false, nil
+	}
+
+	valueLength, prefBytes, err := spec.Pref.DecodeLength(spec.Length, data)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to decode length: %w", err)
+	}
+
+	n := prefBytes + enc.EncodedLen(valueLength)
+	if n > len(data) {
+		return 0, false, fmt.Errorf("failed to decode content: not enough data to decode. expected len %d, got %d", n, len(data))
+	}
+
+	return n, true, nil
+}
+
+// peekCompositeLen returns the number of bytes the packed composite field
+// takes at the beginning of data.
+func peekCompositeLen(data []byte, spec *Spec) (int, bool, error) {
+	dataLen, offset, err := spec.Pref.DecodeLength(spec.Length, data)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to decode length: %w", err)
+	}
+
+	if offset+dataLen > len(data) {
+		return 0, false, fmt.Errorf("not enough data to unpack, expected: %d, got: %d", offset+dataLen, len(data))
+	}
+
+	return offset + dataLen, true, nil
+}
+
 type defaultUnpacker struct{}
 
 // Unpack unpacks the data according to the spec
@@ -184,3 +184,188 @@ func TestTrack2Packer(t *testing.T) {
 		})
 	}
 }
+
+func TestAppendPack(t *testing.T) {
+	tests := []struct {
+		name  string
+		field field.Field
+		spec  *field.Spec
+	}{
+		{
+			name:  "String ASCII LL",
+			field: field.NewStringValue("4242424242424242"),
+			spec: &field.Spec{
+				Length: 19,
+				Enc:    encoding.ASCII,
+				Pref:   prefix.ASCII.LL,
+			},
+		},
+		{
+			name:  "String ASCII fixed with padding",
+			field: field.NewStringValue("100"),
+			spec: &field.Spec{
+				Length: 12,
+				Enc:    encoding.ASCII,
+				Pref:   prefix.ASCII.Fixed,
+				Pad:    padding.Left('0'),
+			},
+		},
+		{
+			name:  "Numeric BCD LL",
+			field: field.NewNumericValue(12345),
+			spec: &field.Spec{
+				Length: 11,
+				Enc:    encoding.BCD,
+				Pref:   prefix.BCD.LL,
+			},
+		},
+		{
+			name:  "Binary with binary prefix",
+			field: field.NewBinaryValue([]byte{0x01, 0x02, 0x03}),
+			spec: &field.Spec{
+				Length: 999,
+				Enc:    encoding.Binary,
+				Pref:   prefix.Binary.LL,
+			},
+		},
+		{
+			name:  "Hex as ASCII hex",
+			field: field.NewHexValue("0102030405060708"),
+			spec: &field.Spec{
+				Length: 8,
+				Enc:    encoding.BytesToASCIIHex,
+				Pref:   prefix.ASCII.Fixed,
+			},
+		},
+		{
+			name:  "Track2 ASCII LL",
+			field: field.NewTrack2Value("4000340000000506", nil, "", "", "="),
+			spec: &field.Spec{
+				Length: 37,
+				Enc:    encoding.ASCII,
+				Pref:   prefix.ASCII.LL,
+			},
+		},
+		{
+			name:  "String with EBCDIC prefix (no append support)",
+			field: field.NewStringValue("hello"),
+			spec: &field.Spec{
+				Length: 99,
+				Enc:    encoding.EBCDIC,
+				Pref:   prefix.EBCDIC.LL,
+			},
+		},
+		{
+			name:  "String with custom packer",
+			field: field.NewStringValue("hello"),
+			spec: &field.Spec{
+				Length: 99,
+				Enc:    encoding.ASCII,
+				Pref:   prefix.ASCII.LL,
+				Packer: field.PackerFunc(func(data []byte, spec *field.Spec) ([]byte, error) {
+					return append([]byte("custom"), data...), nil
+				}),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.field.SetSpec(tt.spec)
+
+			packed, err := tt.field.Pack()
+			require.NoError(t, err)
+
+			appended, err := field.AppendPack([]byte("head"), tt.field)
+			require.NoError(t, err)
+			require.Equal(t, append([]byte("head"), packed...), appended)
+
+			packedLen, err := field.PackedLen(tt.field)
+			require.NoError(t, err)
+			require.Equal(t, len(packed), packedLen)
+		})
+	}
+
+	t.Run("returns packing errors", func(t *testing.T) {
+		str := field.NewStringValue("hello, 世界!")
+		str.SetSpec(&field.Spec{
+			Length: 5,
+			Enc:    encoding.ASCII,
+			Pref:   prefix.ASCII.LL,
+		})
+
+		_, packErr := str.Pack()
+		require.Error(t, packErr)
+
+		_, err := field.AppendPack(nil, str)
+		require.EqualError(t, err, packErr.Error())
+	})
+}
+
+func TestPeekLen(t *testing.T) {
+	t.Run("returns length of the packed field", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			field field.Field
+			value string
+		}{
+			{
+				name: "ASCII LL",
+				field: field.NewString(&field.Spec{
+					Length: 19,
+					Enc:    encoding.ASCII,
+					Pref:   prefix.ASCII.LL,
+				}),
+				value: "4242424242424242",
+			},
+			{
+				name: "BCD LL with odd length",
+				field: field.NewString(&field.Spec{
+					Length: 19,
+					Enc:    encoding.BCD,
+					Pref:   prefix.BCD.LL,
+				}),
+				value: "424242424242424",
+			},
+			{
+				name: "hex fixed",
+				field: field.NewHex(&field.Spec{
+					Length: 4,
+					Enc:    encoding.BytesToASCIIHex,
+					Pref:   prefix.ASCII.Fixed,
+				}),
+				value: "0A0B0C0D",
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				require.NoError(t, tt.field.Marshal(tt.value))
+
+				packed, err := tt.field.Pack()
+				require.NoError(t, err)
+
+				n, ok, err := field.PeekLen(tt.field, append(packed, "tail"...))
+				require.NoError(t, err)
+				require.True(t, ok)
+				require.Equal(t, len(packed), n)
+
+				_, _, err = field.PeekLen(tt.field, packed[:len(packed)-1])
+				require.Error(t, err)
+			})
+		}
+	})
+
+	t.Run("returns false when field length can't be determined", func(t *testing.T) {
+		f := field.NewString(&field.Spec{
+			Length:   19,
+			Enc:      encoding.ASCII,
+			Pref:     prefix.ASCII.LL,
+			Unpacker: field.Track2Unpacker{},
+		})
+
+		_, ok, err := field.PeekLen(f, []byte("164242424242424242"))
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+}
@@ -0,0 +1,476 @@
+package field
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"sync"
+
+	"github.com/moov-io/iso8583/padding"
+	"github.com/moov-io/iso8583/prefix"
+	"github.com/moov-io/iso8583/utils"
+)
+
+var (
+	_ Field            = (*Repeating)(nil)
+	_ json.Marshaler   = (*Repeating)(nil)
+	_ json.Unmarshaler = (*Repeating)(nil)
+)
+
+// RepeatMode defines how the number of elements of the repeating field is
+// determined.
+type RepeatMode string
+
+const (
+	// RepeatFixed is used when the field always holds RepeatSpec.Count
+	// elements.
+	RepeatFixed RepeatMode = "fixed"
+
+	// RepeatCounted is used when the number of elements is packed before
+	// them using RepeatSpec.CountPref.
+	RepeatCounted RepeatMode = "counted"
+
+	// RepeatFill is used when elements fill the whole length of the field,
+	// e.g. field 54 (Additional Amounts).
+	RepeatFill RepeatMode = "fill"
+)
+
+// RepeatSpec defines the element of the repeating field and how many times
+// it occurs.
+type RepeatSpec struct {
+	// Element defines the field that is repeated, e.g. a composite with
+	// positional subfields. It should have a fixed length or a length
+	// prefix, so the elements can be told apart.
+	Element Field
+	// Mode defines how the number of elements is determined.
+	Mode RepeatMode
+	// Count is the number of elements in RepeatFixed mode and the max
+	// number of elements in other modes. Zero means there is no limit.
+	Count int
+	// CountPref encodes the number of elements in RepeatCounted mode,
+	// e.g. prefix.ASCII.LL for the count of 2 digits.
+	CountPref prefix.Prefixer
+}
+
+// Repeating is the field that holds N instances of the same element, e.g.
+// the groups of field 54 (Additional Amounts), or arrays of fixed records
+// and counted lists in private fields. The element and the way its
+// occurrences are packed are defined by Spec.Repeat.
+//
+// The field is packed as follows:
+// - Length (if variable)
+// - Count of elements (in RepeatCounted mode)
+// - []Element
+//
+// As with Composite, the Length of the field is defined in bytes. The field
+// is marshaled from and unmarshaled into Go slices. The elements of struct
+// type are marshaled using their index tags.
+type Repeating struct {
+	spec *Spec
+
+	mu    sync.Mutex
+	items []Field
+}
+
+// NewRepeating creates a new instance of the *Repeating struct, validates
+// and sets its Spec before returning it.
+func NewRepeating(spec *Spec) *Repeating {
+	f := &Repeating{}
+	f.SetSpec(spec)
+
+	return f
+}
+
+func (f *Repeating) NewInstance() Field {
+	return &Repeating{
+		spec: f.spec, // spec is validated already
+	}
+}
+
+// Spec returns the receiver's spec.
+func (f *Repeating) Spec() *Spec {
+	return f.spec
+}
+
+// SetSpec validates the spec and sets it. It panics if the spec is invalid.
+func (f *Repeating) SetSpec(spec *Spec) {
+	if err := validateRepeatingSpec(spec); err != nil {
+		panic(err) //nolint:forbidigo,nolintlint // as specs mostly static, we panic on spec validation errors
+	}
+	f.spec = spec
+}
+
+func validateRepeatingSpec(spec *Spec) error {
+	if spec.Repeat == nil || spec.Repeat.Element == nil {
+		return fmt.Errorf("Repeating spec requires Repeat.Element to be defined")
+	}
+	if spec.Enc != nil {
+		return fmt.Errorf("Repeating spec only supports a nil Enc value")
+	}
+	if spec.Pad != nil && spec.Pad != padding.None {
+		return fmt.Errorf("Repeating spec only supports nil or None spec padding values")
+	}
+
+	switch spec.Repeat.Mode {
+	case RepeatFixed:
+		if spec.Repeat.Count <= 0 {
+			return fmt.Errorf("Repeating spec requires Repeat.Count greater than 0 in %s mode", RepeatFixed)
+		}
+	case RepeatCounted:
+		if spec.Repeat.CountPref == nil {
+			return fmt.Errorf("Repeating spec requires Repeat.CountPref in %s mode", RepeatCounted)
+		}
+	case RepeatFill:
+	default:
+		return fmt.Errorf("Repeating spec has unknown Repeat.Mode: %q", spec.Repeat.Mode)
+	}
+
+	return nil
+}
+
+// Items returns the elements of the field. The returned slice is a copy,
+// but the elements themselves are live references.
+func (f *Repeating) Items() []Field {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]Field(nil), f.items...)
+}
+
+// GetSubfields returns the elements of the field keyed by their positions
+// starting from 1. It allows to describe the field as a composite.
+func (f *Repeating) GetSubfields() map[string]Field {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	subfields := make(map[string]Field, len(f.items))
+	for i, item := range f.items {
+		subfields[strconv.Itoa(i+1)] = item
+	}
+
+	return subfields
+}
+
+// Pack packs the elements of the field and returns them with the length
+// prefix.
+func (f *Repeating) Pack() ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	packed, err := f.pack()
+	if err != nil {
+		return nil, err
+	}
+
+	packedLength, err := f.spec.Pref.EncodeLength(f.spec.Length, len(packed))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode length: %w", err)
+	}
+
+	return append(packedLength, packed...), nil
+}
+
+// Unpack reads the length prefix and unpacks the elements of the field. It
+// returns the number of bytes read.
+func (f *Repeating) Unpack(data []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	dataLen, offset, err := f.spec.Pref.DecodeLength(f.spec.Length, data)
+	if err != nil {
+		return 0, fmt.Errorf("failed to decode length: %w", err)
+	}
+
+	if offset+dataLen > len(data) {
+		return 0, fmt.Errorf("not enough data to unpack, expected: %d, got: %d", offset+dataLen, len(data))
+	}
+
+	read, err := f.unpack(data[offset : offset+dataLen])
+	if err != nil {
+		return 0, err
+	}
+
+	// elements of the variable length field must take all of its length
+	if offset != 0 && read != dataLen {
+		return 0, fmt.Errorf("data length: %d does not match aggregate data read from decoded elements: %d", dataLen, read)
+	}
+
+	return offset + read, nil
+}
+
+// SetBytes unpacks the elements of the field from data that has no length
+// prefix.
+func (f *Repeating) SetBytes(data []byte) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	_, err := f.unpack(data)
+	return err
+}
+
+// Bytes packs the elements of the field. The result does not incorporate
+// the length prefix.
+func (f *Repeating) Bytes() ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.pack()
+}
+
+// String returns the packed elements of the field as a string.
+func (f *Repeating) String() (string, error) {
+	b, err := f.Bytes()
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+// Deprecated. Use Marshal instead
+func (f *Repeating) SetData(v any) error {
+	return f.Marshal(v)
+}
+
+// Marshal sets the elements of the field from the slice (or pointer to the
+// slice) provided in v. Each element of the slice is marshaled into the new
+// instance of Spec.Repeat.Element, structs are passed to it by pointer. A
+// nil value removes all elements.
+func (f *Repeating) Marshal(v any) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if v == nil {
+		f.items = nil
+		return nil
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			f.items = nil
+			return nil
+		}
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return fmt.Errorf("data is not a slice: %T", v)
+	}
+
+	if maxCount := f.spec.Repeat.Count; maxCount > 0 && rv.Len() > maxCount {
+		return fmt.Errorf("too many elements: max %d, got %d", maxCount, rv.Len())
+	}
+
+	items := make([]Field, 0, rv.Len())
+	for i := range rv.Len() {
+		item := NewInstanceOf(f.spec.Repeat.Element)
+		if err := item.Marshal(elementData(rv.Index(i))); err != nil {
+			return fmt.Errorf("marshalling element %d: %w", i+1, err)
+		}
+		items = append(items, item)
+	}
+
+	f.items = items
+
+	return nil
+}
+
+// elementData returns the data of the slice element to marshal into the
+// element field. Structs are returned by pointer.
+func elementData(v reflect.Value) any {
+	if v.Kind() != reflect.Struct {
+		return v.Interface()
+	}
+
+	if v.CanAddr() {
+		return v.Addr().Interface()
+	}
+
+	ptr := reflect.New(v.Type())
+	ptr.Elem().Set(v)
+
+	return ptr.Interface()
+}
+
+// Unmarshal sets the elements of the field into the slice provided as a
+// pointer or reflect.Value. The slice is replaced with the new one holding
+// all elements of the field.
+func (f *Repeating) Unmarshal(v any) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	rv, ok := v.(reflect.Value)
+	if !ok {
+		rv = reflect.ValueOf(v)
+		if rv.Kind() != reflect.Pointer || rv.IsNil() {
+			return errors.New("data is not a pointer or nil")
+		}
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.Slice {
+		return fmt.Errorf("unsupported type: expected pointer to slice or reflect.Value of slice, got %s", rv.Type())
+	}
+
+	if !rv.CanSet() {
+		return fmt.Errorf("cannot set reflect.Value of type %s", rv.Type())
+	}
+
+	slice := reflect.MakeSlice(rv.Type(), len(f.items), len(f.items))
+	for i, item := range f.items {
+		if err := unmarshalElement(item, slice.Index(i)); err != nil {
+			return fmt.Errorf("unmarshalling element %d: %w", i+1, err)
+		}
+	}
+
+	rv.Set(slice)
+
+	return nil
+}
+
+// unmarshalElement sets the value of the element field into the slice
+// element.
+func unmarshalElement(item Field, dst reflect.Value) error {
+	switch dst.Kind() { //nolint:exhaustive
+	case reflect.Pointer:
+		dst.Set(reflect.New(dst.Type().Elem()))
+		return item.Unmarshal(dst.Interface())
+	case reflect.Struct:
+		return item.Unmarshal(dst.Addr().Interface())
+	default: // Native types
+		return item.Unmarshal(dst)
+	}
+}
+
+// MarshalJSON implements the encoding/json.Marshaler interface. Elements
+// are marshaled into the JSON array.
+func (f *Repeating) MarshalJSON() ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	items := f.items
+	if items == nil {
+		items = []Field{}
+	}
+
+	bytes, err := json.Marshal(items)
+	if err != nil {
+		return nil, utils.NewSafeError(err, "failed to JSON marshal elements to bytes")
+	}
+
+	return bytes, nil
+}
+
+// UnmarshalJSON implements the encoding/json.Unmarshaler interface.
+func (f *Repeating) UnmarshalJSON(b []byte) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var data []json.RawMessage
+	if err := json.Unmarshal(b, &data); err != nil {
+		return utils.NewSafeError(err, "failed to JSON unmarshal bytes to slice")
+	}
+
+	items := make([]Field, 0, len(data))
+	for i, rawMsg := range data {
+		item := NewInstanceOf(f.spec.Repeat.Element)
+		if err := json.Unmarshal(rawMsg, item); err != nil {
+			return utils.NewSafeErrorf(err, "failed to unmarshal element %d", i+1)
+		}
+		items = append(items, item)
+	}
+
+	f.items = items
+
+	return nil
+}
+
+// maxCount returns the max number of elements used to encode and decode
+// the count of elements.
+func (f *Repeating) maxCount() int {
+	if f.spec.Repeat.Count > 0 {
+		return f.spec.Repeat.Count
+	}
+
+	return math.MaxInt
+}
+
+func (f *Repeating) pack() ([]byte, error) {
+	repeat := f.spec.Repeat
+
+	var packed []byte
+
+	switch repeat.Mode { //nolint:exhaustive
+	case RepeatFixed:
+		if len(f.items) != repeat.Count {
+			return nil, fmt.Errorf("expected %d elements, got %d", repeat.Count, len(f.items))
+		}
+	case RepeatCounted:
+		count, err := repeat.CountPref.EncodeLength(f.maxCount(), len(f.items))
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode count: %w", err)
+		}
+		packed = count
+	}
+
+	for i, item := range f.items {
+		var err error
+		packed, err = AppendPack(packed, item)
+		if err != nil {
+			return nil, fmt.Errorf("failed to pack element %d: %w", i+1, err)
+		}
+	}
+
+	return packed, nil
+}
+
+// unpack unpacks the elements from data and returns the number of bytes
+// read. In RepeatFill mode all data is read.
+func (f *Repeating) unpack(data []byte) (int, error) {
+	repeat := f.spec.Repeat
+
+	f.items = nil
+
+	var offset, count int
+
+	switch repeat.Mode { //nolint:exhaustive
+	case RepeatFixed:
+		count = repeat.Count
+	case RepeatCounted:
+		n, read, err := repeat.CountPref.DecodeLength(f.maxCount(), data)
+		if err != nil {
+			return 0, fmt.Errorf("failed to decode count: %w", err)
+		}
+		count = n
+		offset = read
+	}
+
+	fill := repeat.Mode == RepeatFill
+
+	for i := 0; (fill && offset < len(data)) || (!fill && i < count); i++ {
+		if fill && repeat.Count > 0 && i == repeat.Count {
+			return 0, fmt.Errorf("too many elements: max %d", repeat.Count)
+		}
+
+		item := NewInstanceOf(repeat.Element)
+
+		read, err := item.Unpack(data[offset:])
+		if err != nil {
+			return 0, fmt.Errorf("failed to unpack element %d: %w", i+1, err)
+		}
+
+		// element that takes no bytes would fill the field forever
+		if fill && read == 0 {
+			return 0, fmt.Errorf("failed to unpack element %d: element has zero length", i+1)
+		}
+
+		f.items = append(f.items, item)
+		offset += read
+	}
+
+	return offset, nil
+}
@@ -0,0 +1,274 @@
+package field
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/moov-io/iso8583/encoding"
+	"github.com/moov-io/iso8583/padding"
+	"github.com/moov-io/iso8583/prefix"
+	"github.com/moov-io/iso8583/sort"
+	"github.com/stretchr/testify/require"
+)
+
+var additionalAmountsSpec = &Spec{
+	Length:      120,
+	Description: "Additional Amounts",
+	Pref:        prefix.ASCII.LLL,
+	Repeat: &RepeatSpec{
+		Mode:  RepeatFill,
+		Count: 6,
+		Element: NewComposite(&Spec{
+			Length:      20,
+			Description: "Additional Amount",
+			Pref:        prefix.ASCII.Fixed,
+			Tag: &TagSpec{
+				Sort: sort.StringsByInt,
+			},
+			Subfields: map[string]Field{
+				"1": NewString(&Spec{
+					Length:      2,
+					Description: "Account Type",
+					Enc:         encoding.ASCII,
+					Pref:        prefix.ASCII.Fixed,
+				}),
+				"2": NewString(&Spec{
+					Length:      2,
+					Description: "Amount Type",
+					Enc:         encoding.ASCII,
+					Pref:        prefix.ASCII.Fixed,
+				}),
+				"3": NewString(&Spec{
+					Length:      3,
+					Description: "Currency Code",
+					Enc:         encoding.ASCII,
+					Pref:        prefix.ASCII.Fixed,
+				}),
+				"4": NewString(&Spec{
+					Length:      1,
+					Description: "Sign",
+					Enc:         encoding.ASCII,
+					Pref:        prefix.ASCII.Fixed,
+				}),
+				"5": NewNumeric(&Spec{
+					Length:      12,
+					Description: "Amount",
+					Enc:         encoding.ASCII,
+					Pref:        prefix.ASCII.Fixed,
+					Pad:         padding.Left('0'),
+				}),
+			},
+		}),
+	},
+}
+
+type additionalAmount struct {
+	AccountType  string `index:"1"`
+	AmountType   string `index:"2"`
+	CurrencyCode string `index:"3"`
+	Sign         string `index:"4"`
+	Amount       int64  `index:"5"`
+}
+
+func TestRepeating(t *testing.T) {
+	amounts := []additionalAmount{
+		{AccountType: "00", AmountType: "02", CurrencyCode: "840", Sign: "C", Amount: 1000},
+		{AccountType: "00", AmountType: "01", CurrencyCode: "840", Sign: "D", Amount: 250},
+	}
+
+	packed := "040" + "0002840C000000001000" + "0001840D000000000250"
+
+	t.Run("Pack packs elements filling the field", func(t *testing.T) {
+		f := NewRepeating(additionalAmountsSpec)
+		require.NoError(t, f.Marshal(amounts))
+
+		got, err := f.Pack()
+		require.NoError(t, err)
+		require.Equal(t, packed, string(got))
+
+		require.Len(t, f.Items(), 2)
+	})
+
+	t.Run("Unpack unpacks elements until the end of the field", func(t *testing.T) {
+		f := NewRepeating(additionalAmountsSpec)
+
+		read, err := f.Unpack([]byte(packed))
+		require.NoError(t, err)
+		require.Equal(t, len(packed), read)
+
+		var data []additionalAmount
+		require.NoError(t, f.Unmarshal(&data))
+		require.Equal(t, amounts, data)
+
+		var pointers []*additionalAmount
+		require.NoError(t, f.Unmarshal(&pointers))
+		require.Len(t, pointers, 2)
+		require.Equal(t, amounts[1], *pointers[1])
+	})
+
+	t.Run("GetSubfields returns elements by position", func(t *testing.T) {
+		f := NewRepeating(additionalAmountsSpec)
+		require.NoError(t, f.Marshal(amounts))
+
+		subfields := f.GetSubfields()
+		require.Len(t, subfields, 2)
+
+		str, err := subfields["2"].String()
+		require.NoError(t, err)
+		require.Equal(t, "0001840D000000000250", str)
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		f := NewRepeating(additionalAmountsSpec)
+		require.NoError(t, f.Marshal(amounts))
+
+		data, err := json.Marshal(f)
+		require.NoError(t, err)
+		require.JSONEq(t, `[{"1":"00","2":"02","3":"840","4":"C","5":1000},{"1":"00","2":"01","3":"840","4":"D","5":250}]`, string(data))
+
+		fromJSON := NewRepeating(additionalAmountsSpec)
+		require.NoError(t, json.Unmarshal(data, fromJSON))
+
+		got, err := fromJSON.Pack()
+		require.NoError(t, err)
+		require.Equal(t, packed, string(got))
+
+		data, err = json.Marshal(NewRepeating(additionalAmountsSpec))
+		require.NoError(t, err)
+		require.Equal(t, `[]`, string(data))
+	})
+
+	t.Run("returns error for too many elements", func(t *testing.T) {
+		f := NewRepeating(additionalAmountsSpec)
+
+		err := f.Marshal(make([]additionalAmount, 7))
+		require.EqualError(t, err, "too many elements: max 6, got 7")
+
+		// field length allows more elements than the max count
+		spec := *additionalAmountsSpec
+		spec.Length = 999
+
+		data := "140"
+		for range 7 {
+			data += "0002840C000000001000"
+		}
+
+		_, err = NewRepeating(&spec).Unpack([]byte(data))
+		require.EqualError(t, err, "too many elements: max 6")
+	})
+
+	t.Run("returns error for element that fails to unpack", func(t *testing.T) {
+		f := NewRepeating(additionalAmountsSpec)
+
+		_, err := f.Unpack([]byte("025" + "0002840C000000001000" + "0001X"))
+		require.ErrorContains(t, err, "failed to unpack element 2")
+	})
+}
+
+func TestRepeatingCounted(t *testing.T) {
+	spec := &Spec{
+		Length:      99,
+		Description: "Terminal IDs",
+		Pref:        prefix.ASCII.LL,
+		Repeat: &RepeatSpec{
+			Mode:      RepeatCounted,
+			CountPref: prefix.ASCII.LL,
+			Element: NewString(&Spec{
+				Length:      4,
+				Description: "Terminal ID",
+				Enc:         encoding.ASCII,
+				Pref:        prefix.ASCII.Fixed,
+			}),
+		},
+	}
+
+	f := NewRepeating(spec)
+	require.NoError(t, f.Marshal([]string{"T001", "T002", "T003"}))
+
+	packed, err := f.Pack()
+	require.NoError(t, err)
+	require.Equal(t, "14"+"03"+"T001T002T003", string(packed))
+
+	f = NewRepeating(spec)
+	read, err := f.Unpack(packed)
+	require.NoError(t, err)
+	require.Equal(t, len(packed), read)
+
+	var ids []string
+	require.NoError(t, f.Unmarshal(&ids))
+	require.Equal(t, []string{"T001", "T002", "T003"}, ids)
+
+	// count does not match the length of the field
+	_, err = f.Unpack([]byte("14" + "02" + "T001T002T003"))
+	require.EqualError(t, err, "data length: 14 does not match aggregate data read from decoded elements: 10")
+}
+
+func TestRepeatingFixed(t *testing.T) {
+	spec := &Spec{
+		Length:      8,
+		Description: "Amounts",
+		Pref:        prefix.ASCII.Fixed,
+		Repeat: &RepeatSpec{
+			Mode:  RepeatFixed,
+			Count: 2,
+			Element: NewNumeric(&Spec{
+				Length:      4,
+				Description: "Amount",
+				Enc:         encoding.ASCII,
+				Pref:        prefix.ASCII.Fixed,
+				Pad:         padding.Left('0'),
+			}),
+		},
+	}
+
+	f := NewRepeating(spec)
+	require.NoError(t, f.Marshal([]int64{12, 345}))
+
+	packed, err := f.Pack()
+	require.NoError(t, err)
+	require.Equal(t, "00120345", string(packed))
+
+	f = NewRepeating(spec)
+	_, err = f.Unpack(packed)
+	require.NoError(t, err)
+
+	var amounts []int64
+	require.NoError(t, f.Unmarshal(&amounts))
+	require.Equal(t, []int64{12, 345}, amounts)
+
+	require.NoError(t, f.Marshal([]int64{12}))
+	_, err = f.Pack()
+	require.EqualError(t, err, "expected 2 elements, got 1")
+}
+
+func TestRepeatingSpecValidation(t *testing.T) {
+	element := NewString(&Spec{
+		Length:      4,
+		Description: "Element",
+		Enc:         encoding.ASCII,
+		Pref:        prefix.ASCII.Fixed,
+	})
+
+	tests := []struct {
+		name        string
+		repeat      *RepeatSpec
+		expectedErr string
+	}{
+		{"no element", &RepeatSpec{Mode: RepeatFill}, "Repeating spec requires Repeat.Element to be defined"},
+		{"fixed without count", &RepeatSpec{Element: element, Mode: RepeatFixed}, "Repeating spec requires Repeat.Count greater than 0 in fixed mode"},
+		{"counted without count prefix", &RepeatSpec{Element: element, Mode: RepeatCounted}, "Repeating spec requires Repeat.CountPref in counted mode"},
+		{"unknown mode", &RepeatSpec{Element: element}, `Repeating spec has unknown Repeat.Mode: ""`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.PanicsWithError(t, tt.expectedErr, func() {
+				NewRepeating(&Spec{
+					Length: 99,
+					Pref:   prefix.ASCII.LL,
+					Repeat: tt.repeat,
+				})
+			})
+		})
+	}
+}
@@ -94,6 +94,20 @@ type Spec struct {
 	// Unpacker unpackes the field value according to its spec. Default is
 	// defaultUnpacker.
 	Unpacker Unpacker
+	// Constraints defines constraints on the field value (e.g. attribute
+	// class, pattern or allowed values). Only applicable to String,
+	// Numeric, Binary and Hex field types.
+	Constraints *Constraints
+	// Repeat defines the element that is repeated in the field and the way
+	// the number of elements is determined. Only applicable to repeating
+	// field types.
+	Repeat *RepeatSpec
+	// Amount defines the sign indicator and the currency exponent of the
+	// amount. Only applicable to amount field types.
+	Amount *AmountSpec
+	// DateTime defines the layout, the location and the year inference of
+	// the value. Only applicable to date/time field types.
+	DateTime *DateTimeSpec
 }
 
 // Packer is the interface that wraps the Pack method.
@@ -12,6 +12,7 @@ import (
 
 var (
 	_ Field            = (*String)(nil)
+	_ AppendPacker     = (*String)(nil)
 	_ json.Marshaler   = (*String)(nil)
 	_ json.Unmarshaler = (*String)(nil)
 )
@@ -49,7 +50,13 @@ func (f *String) SetSpec(spec *Spec) {
 }
 
 func (f *String) SetBytes(b []byte) error {
-	f.value = string(b)
+	value := string(b)
+
+	if err := f.spec.checkConstraints(value, false); err != nil {
+		return err
+	}
+
+	f.value = value
 	return nil
 }
 
@@ -86,6 +93,25 @@ func (f *String) Pack() ([]byte, error) {
 	return packer.Pack(data, f.spec)
 }
 
+// AppendPack appends the packed field to dst and returns the extended slice
+func (f *String) AppendPack(dst []byte) ([]byte, error) {
+	return appendPackString(dst, f.value, f.spec)
+}
+
+// PackedLen returns the length of the packed field
+func (f *String) PackedLen() (int, error) {
+	if n, ok := packedLen(len(f.value), f.spec); ok {
+		return n, nil
+	}
+
+	packed, err := f.Pack()
+	if err != nil {
+		return 0, err
+	}
+
+	return len(packed), nil
+}
+
 func (f *String) Unpack(data []byte) (int, error) {
 	unpacker := f.spec.getUnpacker()
 
@@ -94,10 +120,14 @@ func (f *String) Unpack(data []byte) (int, error) {
 		return 0, err
 	}
 
-	if err := f.SetBytes(raw); err != nil {
-		return 0, fmt.Errorf("failed to set bytes: %w", err)
+	value := string(raw)
+
+	if err := f.spec.checkConstraints(value, true); err != nil {
+		return 0, err
 	}
 
+	f.value = value
+
 	return bytesRead, nil
 }
 
@@ -155,7 +185,24 @@ func (f *String) Unmarshal(v interface{}) error {
 	return nil
 }
 
+// Marshal sets the value of the field. It returns an error if the value
+// does not satisfy the constraints of the spec.
 func (f *String) Marshal(v interface{}) error {
+	value := f.value
+
+	if err := f.marshal(v); err != nil {
+		return err
+	}
+
+	if err := f.spec.checkConstraints(f.value, false); err != nil {
+		f.value = value
+		return err
+	}
+
+	return nil
+}
+
+func (f *String) marshal(v interface{}) error {
 	if v == nil {
 		f.value = ""
 		return nil
@@ -0,0 +1,50 @@
+package field
+
+import (
+	"reflect"
+	"sync"
+)
+
+// StructField is the field of the struct marshaled into (or unmarshaled
+// from) the message or composite field.
+type StructField struct {
+	// Index is the index of the field in the struct.
+	Index int
+	// Tag is the parsed index tag of the field.
+	Tag IndexTag
+	// Anonymous is true for embedded fields.
+	Anonymous bool
+}
+
+// structFieldsCache caches the fields of the struct types, keyed by the type.
+var structFieldsCache sync.Map // map[reflect.Type][]StructField
+
+// StructFields returns the fields of the struct type t that have the index
+// tag (or the name that matches it) or are embedded. Fields are parsed once
+// per type and cached, so the result is shared and must not be modified.
+func StructFields(t reflect.Type) []StructField {
+	if fields, ok := structFieldsCache.Load(t); ok {
+		return fields.([]StructField) //nolint:forcetypeassert
+	}
+
+	fields := make([]StructField, 0, t.NumField())
+
+	for i := range t.NumField() {
+		structField := t.Field(i)
+		indexTag := NewIndexTag(structField)
+
+		if indexTag.Tag == "" && !structField.Anonymous {
+			continue
+		}
+
+		fields = append(fields, StructField{
+			Index:     i,
+			Tag:       indexTag,
+			Anonymous: structField.Anonymous,
+		})
+	}
+
+	actual, _ := structFieldsCache.LoadOrStore(t, fields)
+
+	return actual.([]StructField) //nolint:forcetypeassert
+}
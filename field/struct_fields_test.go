@@ -0,0 +1,63 @@
+package field
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStructFields(t *testing.T) {
+	type embedded struct {
+		F4 string
+	}
+
+	type data struct {
+		embedded
+		F2      string
+		Skipped string
+		Code    string `iso8583:"3,keepzero"`
+		Date    string `index:"7,format=MMDD"`
+	}
+
+	typ := reflect.TypeFor[data]()
+	fields := StructFields(typ)
+
+	require.Equal(t, []StructField{
+		{Index: 0, Tag: IndexTag{ID: -1}, Anonymous: true},
+		{Index: 1, Tag: IndexTag{ID: 2, Tag: "2"}},
+		{Index: 3, Tag: IndexTag{ID: 3, Tag: "3", KeepZero: true}},
+		{Index: 4, Tag: IndexTag{ID: 7, Tag: "7", Format: "MMDD"}},
+	}, fields)
+
+	// fields are parsed once and shared between calls
+	require.Same(t, &fields[0], &StructFields(typ)[0])
+}
+
+func TestConversionOf(t *testing.T) {
+	tests := []struct {
+		typ           reflect.Type
+		marshalAsIs   bool
+		unmarshalAsIs bool
+	}{
+		{reflect.TypeFor[string](), true, true},
+		{reflect.TypeFor[int64](), true, true},
+		{reflect.TypeFor[*String](), true, true},
+		{reflect.TypeFor[[]string](), true, true},
+		{reflect.TypeFor[int](), false, false},
+		{reflect.TypeFor[responseCode](), false, false},
+		{reflect.TypeFor[time.Time](), false, false},
+		{reflect.TypeFor[cents](), false, false},
+		{reflect.TypeFor[*cents](), false, false},
+		{reflect.TypeFor[*uint64](), false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.typ.String(), func(t *testing.T) {
+			c := conversionOf(tt.typ)
+			require.Equal(t, tt.marshalAsIs, c.marshalAsIs)
+			require.Equal(t, tt.unmarshalAsIs, c.unmarshalAsIs)
+		})
+	}
+}
@@ -8,7 +8,10 @@ import (
 	"time"
 )
 
-var _ Field = (*Track1)(nil)
+var (
+	_ Field        = (*Track1)(nil)
+	_ AppendPacker = (*Track1)(nil)
+)
 
 type Track1 struct {
 	FixedLength          bool       `json:"fixed_length,omitempty"`
@@ -97,6 +100,35 @@ func (f *Track1) Pack() ([]byte, error) {
 	return packer.Pack(data, f.spec)
 }
 
+// AppendPack appends the packed field to dst and returns the extended slice
+func (f *Track1) AppendPack(dst []byte) ([]byte, error) {
+	data, err := f.pack()
+	if err != nil {
+		return nil, err
+	}
+
+	return appendPack(dst, data, f.spec)
+}
+
+// PackedLen returns the length of the packed field
+func (f *Track1) PackedLen() (int, error) {
+	data, err := f.pack()
+	if err != nil {
+		return 0, err
+	}
+
+	if n, ok := packedLen(len(data), f.spec); ok {
+		return n, nil
+	}
+
+	packed, err := f.spec.getPacker().Pack(data, f.spec)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(packed), nil
+}
+
 // returns number of bytes was read
 func (f *Track1) Unpack(data []byte) (int, error) {
 	unpacker := f.spec.getUnpacker()
@@ -7,7 +7,10 @@ import (
 	"strings"
 )
 
-var _ Field = (*Track3)(nil)
+var (
+	_ Field        = (*Track3)(nil)
+	_ AppendPacker = (*Track3)(nil)
+)
 
 type Track3 struct {
 	FormatCode           string `json:"format_code,omitempty"`
@@ -72,6 +75,35 @@ func (f *Track3) Pack() ([]byte, error) {
 	return packer.Pack(data, f.spec)
 }
 
+// AppendPack appends the packed field to dst and returns the extended slice
+func (f *Track3) AppendPack(dst []byte) ([]byte, error) {
+	data, err := f.pack()
+	if err != nil {
+		return nil, err
+	}
+
+	return appendPack(dst, data, f.spec)
+}
+
+// PackedLen returns the length of the packed field
+func (f *Track3) PackedLen() (int, error) {
+	data, err := f.pack()
+	if err != nil {
+		return 0, err
+	}
+
+	if n, ok := packedLen(len(data), f.spec); ok {
+		return n, nil
+	}
+
+	packed, err := f.spec.getPacker().Pack(data, f.spec)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(packed), nil
+}
+
 // returns number of bytes was read
 func (f *Track3) Unpack(data []byte) (int, error) {
 	unpacker := f.spec.getUnpacker()
@@ -1,6 +1,7 @@
 package iso8583
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -22,6 +23,10 @@ var _ json.Unmarshaler = (*Message)(nil)
 const (
 	mtiIdx    = 0
 	bitmapIdx = 1
+
+	// headerID is the ID of the protocol header used in struct tags and
+	// errors
+	headerID = "header"
 )
 
 type Message struct {
@@ -33,6 +38,21 @@ type Message struct {
 
 	// stores all fields according to the spec
 	fields map[int]field.Field
+
+	// stores the protocol header packed before the MTI when it's
+	// defined in the spec
+	header field.Field
+
+	// stores bytes of the fields that were unpacked lazily and not
+	// decoded yet
+	lazyFields map[int][]byte
+
+	// stores original bytes of the unpacked fields when the message is
+	// unpacked with WithOriginalBytes
+	originalFields map[int]originalField
+
+	// errors of the fields collected during lenient unpacking
+	unpackErrs []error
 }
 
 func NewMessage(spec *MessageSpec) *Message {
@@ -47,11 +67,31 @@ func NewMessage(spec *MessageSpec) *Message {
 	}
 }
 
+// Header returns the protocol header of the message that is packed before
+// the MTI. The header is created when the spec defines it and it's not set
+// yet. It returns nil if the spec has no header.
+func (m *Message) Header() field.Field {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.headerField()
+}
+
+func (m *Message) headerField() field.Field {
+	if m.header == nil && m.spec.Header != nil {
+		m.header = field.NewInstanceOf(m.spec.Header)
+	}
+
+	return m.header
+}
+
 // Deprecated. Use Marshal instead.
 func (m *Message) SetData(data any) error {
 	return m.Marshal(data)
 }
 
+// Bitmap returns the bitmap of the message. It returns nil if the spec is
+// bitmapless.
 func (m *Message) Bitmap() *field.Bitmap {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -62,6 +102,10 @@ func (m *Message) Bitmap() *field.Bitmap {
 // bitmap creates and returns the bitmap field, it's not thread safe
 // and should be called from a thread safe function
 func (m *Message) bitmap() *field.Bitmap {
+	if m.spec.Bitmapless {
+		return nil
+	}
+
 	if m.cachedBitmap != nil {
 		return m.cachedBitmap
 	}
@@ -78,6 +122,10 @@ func (m *Message) bitmap() *field.Bitmap {
 }
 
 func (m *Message) resetBitmap() {
+	if m.spec.Bitmapless {
+		return
+	}
+
 	m.fields[bitmapIdx] = m.bitmap()
 	m.bitmap().Reset()
 }
@@ -197,7 +245,11 @@ func (m *Message) GetField(id int) field.Field {
 }
 
 func (m *Message) getField(id int) (field.Field, error) {
-	f := m.fields[id]
+	f, err := m.fieldByID(id)
+	if err != nil {
+		return nil, err
+	}
+
 	if f == nil {
 		if _, ok := m.spec.Fields[id]; !ok {
 			return nil, fmt.Errorf("field %d is not defined in the spec", id)
@@ -221,6 +273,8 @@ func (m *Message) GetFields() map[int]field.Field {
 // getFields returns the map of the set fields. It assumes that the mutex
 // is already locked by the caller.
 func (m *Message) getFields() map[int]field.Field {
+	m.decodeLazyFields()
+
 	return maps.Clone(m.fields)
 }
 
@@ -246,11 +300,164 @@ func (m *Message) wrapErrorPack() ([]byte, error) {
 	return data, nil
 }
 
+// AppendPack locks the message, appends the packed message to dst and
+// returns the extended slice. It allows to reuse the buffer for packing of
+// many messages. If any errors are encountered during packing, they will be
+// wrapped in a *PackError before being returned.
+func (m *Message) AppendPack(dst []byte) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	packed, err := m.appendPack(dst)
+	if err != nil {
+		return nil, &iso8583errors.PackError{Err: err}
+	}
+
+	return packed, nil
+}
+
+// PackedLen locks the message and returns the length of the packed message.
+// For the fields that implement field.AppendPacker the length is computed
+// without packing them.
+func (m *Message) PackedLen() (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ids, err := m.prepareBitmap()
+	if err != nil {
+		return 0, &iso8583errors.PackError{Err: err}
+	}
+
+	var n int
+	if header := m.headerField(); header != nil {
+		headerLen, err := field.PackedLen(header)
+		if err != nil {
+			return 0, &iso8583errors.PackError{
+				Err: fmt.Errorf("failed to pack header: %w", err),
+			}
+		}
+		n += headerLen
+	}
+
+	for _, i := range ids {
+		if raw, ok := m.lazyFields[i]; ok {
+			n += len(raw)
+			continue
+		}
+
+		f := m.packableField(i)
+
+		if original, ok := m.originalFields[i]; ok {
+			packed, err := f.Pack()
+			if err != nil {
+				return 0, &iso8583errors.PackError{
+					Err: fmt.Errorf("failed to pack field %d (%s): %w", i, f.Spec().Description, err),
+				}
+			}
+
+			n += len(original.pack(packed))
+			continue
+		}
+
+		fieldLen, err := field.PackedLen(f)
+		if err != nil {
+			return 0, &iso8583errors.PackError{
+				Err: fmt.Errorf("failed to pack field %d (%s): %w", i, f.Spec().Description, err),
+			}
+		}
+		n += fieldLen
+	}
+
+	return n, nil
+}
+
+// maxPooledPackBufferSize is the maximum capacity of the buffer that is
+// returned into packBufferPool, so a few large messages do not keep large
+// buffers in memory.
+const maxPooledPackBufferSize = 64 * 1024
+
+// packBufferPool holds buffers the messages are packed into before they are
+// copied into the result of Pack.
+var packBufferPool = sync.Pool{
+	New: func() any {
+		buf := make([]byte, 0, 1024)
+		return &buf
+	},
+}
+
 // pack contains the core logic for packing the message. This method does not
 // handle locking or error wrapping and should typically be used internally
 // after ensuring concurrency safety.
 func (m *Message) pack() ([]byte, error) {
-	packed := []byte{}
+	buf := packBufferPool.Get().(*[]byte)
+
+	packed, err := m.appendPack((*buf)[:0])
+	if err != nil {
+		packBufferPool.Put(buf)
+		return nil, err
+	}
+
+	// copy packed message into the slice of the exact size as the buffer
+	// is reused
+	result := make([]byte, len(packed))
+	copy(result, packed)
+
+	if cap(packed) <= maxPooledPackBufferSize {
+		*buf = packed[:0]
+		packBufferPool.Put(buf)
+	}
+
+	return result, nil
+}
+
+// appendPack appends packed fields of the message to dst.
+func (m *Message) appendPack(dst []byte) ([]byte, error) {
+	ids, err := m.prepareBitmap()
+	if err != nil {
+		return nil, err
+	}
+
+	// the header is packed before the MTI
+	if header := m.headerField(); header != nil {
+		dst, err = field.AppendPack(dst, header)
+		if err != nil {
+			return nil, fmt.Errorf("failed to pack header: %w", err)
+		}
+	}
+
+	// pack fields
+	for _, i := range ids {
+		// fields that were not decoded are packed using their original bytes
+		if raw, ok := m.lazyFields[i]; ok {
+			dst = append(dst, raw...)
+			continue
+		}
+
+		f := m.packableField(i)
+
+		start := len(dst)
+
+		dst, err = field.AppendPack(dst, f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to pack field %d (%s): %w", i, f.Spec().Description, err)
+		}
+
+		// replace the field that was not modified with its original bytes
+		if original, ok := m.originalFields[i]; ok && bytes.Equal(dst[start:], original.packed) {
+			dst = append(dst[:start], original.raw...)
+		}
+	}
+
+	return dst, nil
+}
+
+// prepareBitmap resets the bitmap and sets bits of the fields that will be
+// packed. It returns the IDs of the fields to pack.
+func (m *Message) prepareBitmap() ([]int, error) {
+	// all fields of the bitmapless message are packed
+	if m.spec.Bitmapless {
+		return m.spec.fieldIDs(), nil
+	}
 
 	m.resetBitmap()
 
@@ -259,34 +466,36 @@ func (m *Message) pack() ([]byte, error) {
 		return nil, fmt.Errorf("failed to pack message: %w", err)
 	}
 
+	packIDs := ids[:0]
 	for _, id := range ids {
+		// do not pack presence bits other than the first one as it's the
+		// bitmap itself
+		if id != bitmapIdx && m.bitmap().IsBitmapPresenceBit(id) {
+			continue
+		}
+
+		packIDs = append(packIDs, id)
+
 		// indexes 0 and 1 are for mti and bitmap
 		// regular field number startd from index 2
-		// do not pack presence bits as well
-		if id < 2 || m.bitmap().IsBitmapPresenceBit(id) {
+		if id < 2 {
 			continue
 		}
 		m.bitmap().Set(id)
 	}
 
-	// pack fields
-	for _, i := range ids {
-		// do not pack presence bits other than the first one as it's the bitmap itself
-		if i != 1 && m.bitmap().IsBitmapPresenceBit(i) {
-			continue
-		}
-
-		// m.fields[i] must have the field as we got i from packableFieldIDs()
-		field := m.fields[i]
+	return packIDs, nil
+}
 
-		packedField, err := field.Pack()
-		if err != nil {
-			return nil, fmt.Errorf("failed to pack field %d (%s): %w", i, field.Spec().Description, err)
-		}
-		packed = append(packed, packedField...)
+// packableField returns the field with the given ID to pack. Fields of the
+// bitmapless message that are not set are packed with zero values.
+func (m *Message) packableField(id int) field.Field {
+	if f, ok := m.fields[id]; ok {
+		return f
 	}
 
-	return packed, nil
+	// m.spec.Fields has the field as we got id from spec.fieldIDs()
+	return field.NewInstanceOf(m.spec.Fields[id])
 }
 
 // Unpack unpacks the message from the given byte slice or returns an error
@@ -295,44 +504,95 @@ func (m *Message) Unpack(src []byte) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	return m.wrapErrorUnpack(src)
+	return m.wrapErrorUnpack(src, unpackOptions{})
 }
 
 // wrapErrorUnpack calls the core unpacking logic and wraps any
-// errors in a *UnpackError. It assumes that the mutex is already
-// locked by the caller.
-func (m *Message) wrapErrorUnpack(src []byte) error {
-	if fieldID, err := m.unpack(src); err != nil {
-		return &iso8583errors.UnpackError{
+// errors in a *UnpackError. In lenient mode, errors of all fields
+// are joined. It assumes that the mutex is already locked by the caller.
+func (m *Message) wrapErrorUnpack(src []byte, opts unpackOptions) error {
+	fieldID, err := m.unpack(src, opts)
+
+	unpackErrs := m.unpackErrs
+	m.unpackErrs = nil
+
+	if err != nil {
+		unpackErrs = append(unpackErrs, &iso8583errors.UnpackError{
 			Err:        err,
 			FieldID:    fieldID,
 			RawMessage: src,
-		}
+		})
 	}
-	return nil
+
+	if len(unpackErrs) == 0 {
+		return nil
+	}
+
+	if !opts.lenient {
+		return unpackErrs[0]
+	}
+
+	return errors.Join(unpackErrs...)
 }
 
 // unpack contains the core logic for unpacking the message. This method does
 // not handle locking or error wrapping and should typically be used internally
 // after ensuring concurrency safety.
-func (m *Message) unpack(src []byte) (string, error) {
+func (m *Message) unpack(src []byte, opts unpackOptions) (string, error) {
 	// reset fields
 	m.fields = make(map[int]field.Field)
+	m.lazyFields = nil
+	m.originalFields = nil
+	m.unpackErrs = nil
+
+	if opts.preserveOriginal {
+		m.originalFields = make(map[int]originalField)
+	}
 
 	// it implicitly sets the bitmap field in m.fields
 	m.resetBitmap()
 
+	offset := 0
+
+	m.header = nil
+	if m.spec.Header != nil {
+		m.header = field.NewInstanceOf(m.spec.Header)
+
+		read, err := m.header.Unpack(src)
+		if err != nil {
+			return headerID, fmt.Errorf("failed to unpack header: %w", err)
+		}
+
+		offset = read
+	}
+
+	// fields of the bitmapless message are unpacked in order
+	if m.spec.Bitmapless {
+		for _, i := range m.spec.fieldIDs() {
+			read, err := m.unpackField(i, src[offset:], opts)
+			if err != nil {
+				return strconv.Itoa(i), err
+			}
+
+			offset += read
+		}
+
+		return "", nil
+	}
+
 	mti, err := m.createField(mtiIdx)
 	if err != nil {
 		return strconv.Itoa(mtiIdx), fmt.Errorf("getting or creating MTI field: %w", err)
 	}
 
-	read, err := mti.Unpack(src)
+	read, err := mti.Unpack(src[offset:])
 	if err != nil {
 		return strconv.Itoa(mtiIdx), fmt.Errorf("failed to unpack MTI: %w", err)
 	}
 
-	offset := read
+	m.rememberOriginal(mtiIdx, mti, src[offset:offset+read])
+
+	offset += read
 
 	// unpack Bitmap
 	read, err = m.bitmap().Unpack(src[offset:])
@@ -340,6 +600,8 @@ func (m *Message) unpack(src []byte) (string, error) {
 		return strconv.Itoa(bitmapIdx), fmt.Errorf("failed to unpack bitmap: %w", err)
 	}
 
+	m.rememberOriginal(bitmapIdx, m.bitmap(), src[offset:offset+read])
+
 	offset += read
 
 	for i := 2; i <= m.bitmap().Len(); i++ {
@@ -349,14 +611,9 @@ func (m *Message) unpack(src []byte) (string, error) {
 		}
 
 		if m.bitmap().IsSet(i) {
-			fl, err := m.createField(i)
+			read, err = m.unpackField(i, src[offset:], opts)
 			if err != nil {
-				return strconv.Itoa(i), fmt.Errorf("creating field %d: %w", i, err)
-			}
-
-			read, err = fl.Unpack(src[offset:])
-			if err != nil {
-				return strconv.Itoa(i), fmt.Errorf("failed to unpack field %d (%s): %w", i, fl.Spec().Description, err)
+				return strconv.Itoa(i), err
 			}
 
 			offset += read
@@ -366,6 +623,43 @@ func (m *Message) unpack(src []byte) (string, error) {
 	return "", nil
 }
 
+// unpackField unpacks the field i located at the beginning of src and
+// returns its length.
+func (m *Message) unpackField(i int, src []byte, opts unpackOptions) (int, error) {
+	// the MTI is always decoded
+	if opts.lazy && i != mtiIdx {
+		read, ok, err := m.unpackLazyField(i, src)
+		if err != nil {
+			return 0, err
+		}
+
+		if ok {
+			return read, nil
+		}
+	}
+
+	fl, err := m.createField(i)
+	if err != nil {
+		return 0, fmt.Errorf("creating field %d: %w", i, err)
+	}
+
+	m.preserveOriginalSubfields(fl)
+	if opts.lenient {
+		m.unpackSubfieldsLeniently(fl)
+	}
+
+	read, err := fl.Unpack(src)
+	if err != nil {
+		if read, err = m.recoverField(i, fl, src, err, opts); err != nil {
+			return 0, fmt.Errorf("failed to unpack field %d (%s): %w", i, fl.Spec().Description, err)
+		}
+	}
+
+	m.rememberOriginal(i, fl, src[:read])
+
+	return read, nil
+}
+
 func (m *Message) MarshalJSON() ([]byte, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -420,7 +714,11 @@ func (m *Message) UnmarshalJSON(b []byte) error {
 }
 
 func (m *Message) packableFieldIDs() ([]int, error) {
-	return slices.Sorted(maps.Keys(m.fields)), nil
+	ids := make([]int, 0, len(m.fields)+len(m.lazyFields))
+	ids = slices.AppendSeq(slices.AppendSeq(ids, maps.Keys(m.fields)), maps.Keys(m.lazyFields))
+	slices.Sort(ids)
+
+	return ids, nil
 }
 
 // Clone clones the message by creating a new message from the binary
@@ -436,12 +734,6 @@ func (m *Message) Clone() (*Message, error) {
 	}
 	m.mu.Unlock()
 
-	mti, err := m.GetMTI()
-	if err != nil {
-		return nil, err
-	}
-
-	newMessage.MTI(mti)
 	newMessage.Unpack(bytes)
 
 	_, err = newMessage.Pack()
@@ -486,9 +778,8 @@ func (m *Message) Marshal(v interface{}) error {
 // don't have index tags themselves.
 func (m *Message) marshalStruct(dataStruct reflect.Value) error {
 	// iterate over struct fields
-	for i := 0; i < dataStruct.NumField(); i++ {
-		structField := dataStruct.Type().Field(i)
-		indexTag := field.NewIndexTag(structField)
+	for _, structField := range field.StructFields(dataStruct.Type()) {
+		i, indexTag := structField.Index, structField.Tag
 
 		// If the field has an index tag, process it normally
 		if indexTag.ID >= 0 {
@@ -504,13 +795,51 @@ func (m *Message) marshalStruct(dataStruct reflect.Value) error {
 				return fmt.Errorf("getting or creating field %d: %w", indexTag.ID, err)
 			}
 
-			if err := messageField.Marshal(dataField.Interface()); err != nil {
+			if err := field.MarshalStructField(messageField, dataField, indexTag, m.spec.Converters); err != nil {
 				return fmt.Errorf("failed to set value to field %d: %w", indexTag.ID, err)
 			}
 
 			continue
 		}
 
+		// flattened tag addresses the subfield by path, e.g. 48.1
+		if isFieldPath(indexTag.Tag) {
+			dataField := dataStruct.Field(i)
+			if dataField.IsZero() && !indexTag.KeepZero {
+				continue
+			}
+
+			value := field.StructFieldValue{
+				Value:      dataField,
+				Tag:        indexTag,
+				Converters: m.spec.Converters,
+			}
+
+			if err := m.marshalPath(indexTag.Tag, value); err != nil {
+				return fmt.Errorf("failed to set value to field %s: %w", indexTag.Tag, err)
+			}
+
+			continue
+		}
+
+		if indexTag.Tag == headerID {
+			dataField := dataStruct.Field(i)
+			if dataField.IsZero() && !indexTag.KeepZero {
+				continue
+			}
+
+			header := m.headerField()
+			if header == nil {
+				return fmt.Errorf("header is not defined in the spec")
+			}
+
+			if err := field.MarshalStructField(header, dataField, indexTag, m.spec.Converters); err != nil {
+				return fmt.Errorf("failed to set value to header: %w", err)
+			}
+
+			continue
+		}
+
 		// If it's an anonymous embedded struct without an index tag, traverse into it
 		if structField.Anonymous {
 			fieldValue := dataStruct.Field(i)
@@ -563,39 +892,48 @@ func (m *Message) Unmarshal(v interface{}) error {
 // don't have index tags themselves.
 func (m *Message) unmarshalStruct(dataStruct reflect.Value) error {
 	// iterate over struct fields
-	for i := range dataStruct.NumField() {
-		structField := dataStruct.Type().Field(i)
-		indexTag := field.NewIndexTag(structField)
+	for _, structField := range field.StructFields(dataStruct.Type()) {
+		i, indexTag := structField.Index, structField.Tag
 
 		// If the field has an index tag, process it normally
 		if indexTag.ID >= 0 {
+			messageField, err := m.fieldByID(indexTag.ID)
+			if err != nil {
+				return err
+			}
+
 			// skip if field is not set in the message
-			messageField := m.fields[indexTag.ID]
 			if messageField == nil {
 				continue
 			}
 
-			dataField := dataStruct.Field(i)
-			switch dataField.Kind() { //nolint:exhaustive
-			case reflect.Pointer, reflect.Interface:
-				if dataField.IsNil() {
-					dataField.Set(reflect.New(dataField.Type().Elem()))
-				}
-				err := messageField.Unmarshal(dataField.Interface())
-				if err != nil {
-					return fmt.Errorf("failed to get value from field %d: %w", indexTag.ID, err)
-				}
-			case reflect.Slice:
-				// Pass reflect.Value for slices so they can be modified
-				err := messageField.Unmarshal(dataField)
-				if err != nil {
-					return fmt.Errorf("failed to get value from field %d: %w", indexTag.ID, err)
-				}
-			default: // Native types
-				err := messageField.Unmarshal(dataField)
-				if err != nil {
-					return fmt.Errorf("failed to get value from field %d: %w", indexTag.ID, err)
-				}
+			if err := field.UnmarshalStructField(messageField, dataStruct.Field(i), indexTag, m.spec.Converters); err != nil {
+				return fmt.Errorf("failed to get value from field %d: %w", indexTag.ID, err)
+			}
+			continue
+		}
+
+		if isFieldPath(indexTag.Tag) {
+			value := field.StructFieldValue{
+				Value:      dataStruct.Field(i),
+				Tag:        indexTag,
+				Converters: m.spec.Converters,
+			}
+
+			if err := m.unmarshalPath(indexTag.Tag, value); err != nil {
+				return fmt.Errorf("failed to get value from field %s: %w", indexTag.Tag, err)
+			}
+			continue
+		}
+
+		if indexTag.Tag == headerID {
+			// skip if header is not set in the message
+			if m.header == nil {
+				continue
+			}
+
+			if err := field.UnmarshalStructField(m.header, dataStruct.Field(i), indexTag, m.spec.Converters); err != nil {
+				return fmt.Errorf("failed to get value from header: %w", err)
 			}
 			continue
 		}
@@ -632,6 +970,19 @@ func (m *Message) unmarshalStruct(dataStruct reflect.Value) error {
 	return nil
 }
 
+// isFieldPath reports whether the tag addresses the subfield of the
+// composite field by path, e.g. 48.1 or 55.9F02.
+func isFieldPath(tag string) bool {
+	id, subPath, ok := strings.Cut(tag, ".")
+	if !ok || subPath == "" {
+		return false
+	}
+
+	_, err := strconv.Atoi(id)
+
+	return err == nil
+}
+
 // UnsetField marks the field with the given ID as not set and replaces it with
 // a new zero-valued field. This effectively removes the field's value and excludes
 // it from operations like Pack() or Marshal().
@@ -644,15 +995,21 @@ func (m *Message) UnsetField(id int) {
 
 func (m *Message) unsetField(id int) {
 	delete(m.fields, id)
+	delete(m.lazyFields, id)
+	delete(m.originalFields, id)
 }
 
 func (m *Message) getOrCreateField(id int) (field.Field, error) {
-	f := m.fields[id]
+	f, err := m.fieldByID(id)
+	if err != nil {
+		return nil, err
+	}
+
 	if f != nil {
 		return f, nil
 	}
 
-	f, err := m.createField(id)
+	f, err = m.createField(id)
 	if err != nil {
 		return nil, fmt.Errorf("creating field %d: %w", id, err)
 	}
@@ -668,6 +1025,9 @@ func (m *Message) createField(id int) (field.Field, error) {
 	f := field.NewInstanceOf(specField)
 	m.fields[id] = f
 
+	// the field is replaced, so it's packed as a new one
+	delete(m.originalFields, id)
+
 	return f, nil
 }
 
@@ -699,7 +1059,11 @@ func (m *Message) UnsetPath(idPaths ...string) error {
 			return fmt.Errorf("conversion of %s to int failed: %w", id, err)
 		}
 
-		f := m.fields[idx]
+		f, err := m.fieldByID(idx)
+		if err != nil {
+			return err
+		}
+
 		// field is not set, continue
 		if f == nil {
 			continue
@@ -733,6 +1097,10 @@ func (m *Message) MarshalPath(path string, value any) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	return m.marshalPath(path, value)
+}
+
+func (m *Message) marshalPath(path string, value any) error {
 	id, subPath, hasSubPath := strings.Cut(path, ".")
 	idx, err := strconv.Atoi(id)
 	if err != nil {
@@ -774,13 +1142,21 @@ func (m *Message) UnmarshalPath(path string, value any) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	return m.unmarshalPath(path, value)
+}
+
+func (m *Message) unmarshalPath(path string, value any) error {
 	id, subPath, hasSubPath := strings.Cut(path, ".")
 	idx, err := strconv.Atoi(id)
 	if err != nil {
 		return fmt.Errorf("conversion of %s to int failed: %w", id, err)
 	}
 
-	f := m.fields[idx]
+	f, err := m.fieldByID(idx)
+	if err != nil {
+		return err
+	}
+
 	if f == nil {
 		// check if field exists in spec
 		_, ok := m.spec.Fields[idx]
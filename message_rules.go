@@ -0,0 +1,312 @@
+package iso8583
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	iso8583errors "github.com/moov-io/iso8583/errors"
+	"github.com/moov-io/iso8583/field"
+)
+
+// Presence defines whether a field has to be present in the message.
+type Presence string
+
+const (
+	// Mandatory fields must be present in the message
+	Mandatory Presence = "mandatory"
+	// Conditional fields are present only when conditions defined in
+	// MessageRule.Conditions are met. Without a matching condition they are
+	// treated as optional.
+	Conditional Presence = "conditional"
+	// Optional fields may or may not be present in the message
+	Optional Presence = "optional"
+	// Forbidden fields must not be present in the message
+	Forbidden Presence = "forbidden"
+)
+
+func (p Presence) isValid() bool {
+	switch p {
+	case Mandatory, Conditional, Optional, Forbidden:
+		return true
+	}
+
+	return false
+}
+
+// MessageRule defines which fields must, may or must not be present in the
+// message with a specific MTI. Rules are set in the MessageSpec.Rules map
+// keyed by MTI.
+type MessageRule struct {
+	// Fields maps field paths (e.g. "11" or "48.1") to their presence.
+	// Fields that are not listed are optional.
+	Fields map[string]Presence
+
+	// Conditions define cross-field dependencies, e.g. field 14 is
+	// mandatory when field 35 is absent.
+	Conditions []FieldCondition
+}
+
+// FieldCondition makes the field identified by Field mandatory or forbidden
+// when all fields from WhenPresent are present and all fields from
+// WhenAbsent are absent in the message.
+type FieldCondition struct {
+	// Field is the path of the field the condition applies to
+	Field string
+
+	// Presence is applied to the Field when the condition is met. Only
+	// Mandatory and Forbidden are supported.
+	Presence Presence
+
+	// WhenPresent is the list of field paths that must be present for the
+	// condition to be met
+	WhenPresent []string
+
+	// WhenAbsent is the list of field paths that must be absent for the
+	// condition to be met
+	WhenAbsent []string
+}
+
+// isMet returns true if all fields from WhenPresent are set and all fields
+// from WhenAbsent are not set in the message.
+func (c FieldCondition) isMet(isSet func(path string) bool) bool {
+	for _, path := range c.WhenPresent {
+		if !isSet(path) {
+			return false
+		}
+	}
+
+	for _, path := range c.WhenAbsent {
+		if isSet(path) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (c FieldCondition) String() string {
+	var parts []string
+	if len(c.WhenPresent) > 0 {
+		parts = append(parts, fmt.Sprintf("%s present", strings.Join(c.WhenPresent, ", ")))
+	}
+	if len(c.WhenAbsent) > 0 {
+		parts = append(parts, fmt.Sprintf("%s absent", strings.Join(c.WhenAbsent, ", ")))
+	}
+
+	return strings.Join(parts, " and ")
+}
+
+// validateRules checks that rules reference fields defined in the spec and
+// use known presence values.
+func (s *MessageSpec) validateRules() error {
+	for mti, rule := range s.Rules {
+		if rule == nil {
+			return fmt.Errorf("rule for MTI %s is nil", mti)
+		}
+
+		for path, presence := range rule.Fields {
+			if !presence.isValid() {
+				return fmt.Errorf("rule for MTI %s: unknown presence %q for field %s", mti, presence, path)
+			}
+
+			if err := s.validateRulePath(path); err != nil {
+				return fmt.Errorf("rule for MTI %s: %w", mti, err)
+			}
+		}
+
+		for _, cond := range rule.Conditions {
+			if cond.Presence != Mandatory && cond.Presence != Forbidden {
+				return fmt.Errorf("rule for MTI %s: condition for field %s supports only %s or %s presence, got %q", mti, cond.Field, Mandatory, Forbidden, cond.Presence)
+			}
+
+			paths := append([]string{cond.Field}, cond.WhenPresent...)
+			paths = append(paths, cond.WhenAbsent...)
+
+			for _, path := range paths {
+				if err := s.validateRulePath(path); err != nil {
+					return fmt.Errorf("rule for MTI %s: %w", mti, err)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateRulePath checks that the first element of the path is a field
+// defined in the spec. Subfield paths are not checked as composite subfields
+// may be unknown TLV tags.
+func (s *MessageSpec) validateRulePath(path string) error {
+	id, _, _ := strings.Cut(path, ".")
+	idx, err := strconv.Atoi(id)
+	if err != nil {
+		return fmt.Errorf("invalid field path %q: %w", path, err)
+	}
+
+	if _, ok := s.Fields[idx]; !ok {
+		return fmt.Errorf("field %d from path %q is not defined in the spec", idx, path)
+	}
+
+	return nil
+}
+
+// Validate checks the message against the rule defined in the spec for the
+// message MTI. If the message doesn't satisfy the rule, it returns
+// *errors.ValidationError with all found violations. If there is no rule
+// for the MTI, nil is returned.
+func (m *Message) Validate() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.spec.Rules) == 0 {
+		return nil
+	}
+
+	var mti string
+	if f := m.fields[mtiIdx]; f != nil {
+		var err error
+		mti, err = f.String()
+		if err != nil {
+			return fmt.Errorf("getting MTI: %w", err)
+		}
+	}
+
+	rule, ok := m.spec.Rules[mti]
+	if !ok {
+		return nil
+	}
+
+	var violations []*iso8583errors.FieldViolation
+
+	paths := make([]string, 0, len(rule.Fields))
+	for path := range rule.Fields {
+		paths = append(paths, path)
+	}
+	sortFieldPaths(paths)
+
+	for _, path := range paths {
+		isSet := m.isPathSet(path)
+
+		switch rule.Fields[path] {
+		case Mandatory:
+			if !isSet {
+				violations = append(violations, &iso8583errors.FieldViolation{
+					FieldID: path,
+					Reason:  "mandatory field is missing",
+				})
+			}
+		case Forbidden:
+			if isSet {
+				violations = append(violations, &iso8583errors.FieldViolation{
+					FieldID: path,
+					Reason:  fmt.Sprintf("field is not allowed in message %s", mti),
+				})
+			}
+		case Optional, Conditional:
+		}
+	}
+
+	for _, cond := range rule.Conditions {
+		if !cond.isMet(m.isPathSet) {
+			continue
+		}
+
+		isSet := m.isPathSet(cond.Field)
+
+		if cond.Presence == Mandatory && !isSet {
+			violations = append(violations, &iso8583errors.FieldViolation{
+				FieldID: cond.Field,
+				Reason:  fmt.Sprintf("field is required when %s", cond),
+			})
+		}
+
+		if cond.Presence == Forbidden && isSet {
+			violations = append(violations, &iso8583errors.FieldViolation{
+				FieldID: cond.Field,
+				Reason:  fmt.Sprintf("field is not allowed when %s", cond),
+			})
+		}
+	}
+
+	if len(violations) > 0 {
+		return &iso8583errors.ValidationError{
+			MTI:        mti,
+			Violations: violations,
+		}
+	}
+
+	return nil
+}
+
+// isPathSet returns true if the field (or subfield) identified by the
+// dot-separated path is set in the message. It assumes that the mutex is
+// already locked by the caller.
+func (m *Message) isPathSet(path string) bool {
+	id, subPath, hasSubPath := strings.Cut(path, ".")
+	idx, err := strconv.Atoi(id)
+	if err != nil {
+		return false
+	}
+
+	// the field that was not decoded yet is set
+	if _, ok := m.lazyFields[idx]; ok && !hasSubPath {
+		return true
+	}
+
+	f, err := m.fieldByID(idx)
+	if err != nil || f == nil {
+		return false
+	}
+
+	if !hasSubPath {
+		return true
+	}
+
+	return isSubfieldPathSet(f, subPath)
+}
+
+func isSubfieldPathSet(f field.Field, path string) bool {
+	for _, tag := range strings.Split(path, ".") {
+		container, ok := f.(FieldContainer)
+		if !ok {
+			return false
+		}
+
+		f = container.GetSubfields()[tag]
+		if f == nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+// sortFieldPaths sorts dot-separated field paths element by element. Elements
+// are compared as integers when possible, otherwise as strings.
+func sortFieldPaths(paths []string) {
+	slices.SortFunc(paths, func(a, b string) int {
+		partsA := strings.Split(a, ".")
+		partsB := strings.Split(b, ".")
+
+		for i := 0; i < len(partsA) && i < len(partsB); i++ {
+			intA, errA := strconv.Atoi(partsA[i])
+			intB, errB := strconv.Atoi(partsB[i])
+
+			var c int
+			if errA == nil && errB == nil {
+				c = cmp.Compare(intA, intB)
+			} else {
+				c = cmp.Compare(partsA[i], partsB[i])
+			}
+
+			if c != 0 {
+				return c
+			}
+		}
+
+		return cmp.Compare(len(partsA), len(partsB))
+	})
+}
@@ -0,0 +1,176 @@
+package iso8583
+
+import (
+	"testing"
+
+	"github.com/moov-io/iso8583/encoding"
+	iso8583errors "github.com/moov-io/iso8583/errors"
+	"github.com/moov-io/iso8583/field"
+	"github.com/moov-io/iso8583/padding"
+	"github.com/moov-io/iso8583/prefix"
+	"github.com/moov-io/iso8583/sort"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageValidate(t *testing.T) {
+	newSpec := func(rules map[string]*MessageRule) *MessageSpec {
+		return &MessageSpec{
+			Fields: map[int]field.Field{
+				0: field.NewString(&field.Spec{
+					Length:      4,
+					Description: "Message Type Indicator",
+					Enc:         encoding.ASCII,
+					Pref:        prefix.ASCII.Fixed,
+				}),
+				1: field.NewBitmap(&field.Spec{
+					Description: "Bitmap",
+					Enc:         encoding.BytesToASCIIHex,
+					Pref:        prefix.Hex.Fixed,
+				}),
+				2: field.NewString(&field.Spec{
+					Length:      19,
+					Description: "Primary Account Number",
+					Enc:         encoding.ASCII,
+					Pref:        prefix.ASCII.LL,
+				}),
+				3: field.NewComposite(&field.Spec{
+					Length:      6,
+					Description: "Processing Code",
+					Pref:        prefix.ASCII.Fixed,
+					Tag: &field.TagSpec{
+						Sort: sort.StringsByInt,
+					},
+					Subfields: map[string]field.Field{
+						"1": field.NewString(&field.Spec{
+							Length:      2,
+							Description: "Transaction Type",
+							Enc:         encoding.ASCII,
+							Pref:        prefix.ASCII.Fixed,
+						}),
+						"2": field.NewString(&field.Spec{
+							Length:      2,
+							Description: "From Account",
+							Enc:         encoding.ASCII,
+							Pref:        prefix.ASCII.Fixed,
+						}),
+					},
+				}),
+				11: field.NewString(&field.Spec{
+					Length:      6,
+					Description: "Systems Trace Audit Number (STAN)",
+					Enc:         encoding.ASCII,
+					Pref:        prefix.ASCII.Fixed,
+					Pad:         padding.Left('0'),
+				}),
+				14: field.NewString(&field.Spec{
+					Length:      4,
+					Description: "Expiration Date",
+					Enc:         encoding.ASCII,
+					Pref:        prefix.ASCII.Fixed,
+				}),
+				35: field.NewString(&field.Spec{
+					Length:      37,
+					Description: "Track 2 Data",
+					Enc:         encoding.ASCII,
+					Pref:        prefix.ASCII.LL,
+				}),
+				39: field.NewString(&field.Spec{
+					Length:      2,
+					Description: "Response Code",
+					Enc:         encoding.ASCII,
+					Pref:        prefix.ASCII.Fixed,
+				}),
+			},
+			Rules: rules,
+		}
+	}
+
+	spec := newSpec(map[string]*MessageRule{
+		"0100": {
+			Fields: map[string]Presence{
+				"2":   Mandatory,
+				"3.1": Mandatory,
+				"11":  Mandatory,
+				"14":  Conditional,
+				"39":  Forbidden,
+			},
+			Conditions: []FieldCondition{
+				{Field: "14", Presence: Mandatory, WhenAbsent: []string{"35"}},
+			},
+		},
+		"0110": {
+			Fields: map[string]Presence{
+				"11": Mandatory,
+				"39": Mandatory,
+			},
+		},
+	})
+
+	t.Run("valid message passes validation", func(t *testing.T) {
+		message := NewMessage(spec)
+		message.MTI("0100")
+		require.NoError(t, message.Field(2, "4242424242424242"))
+		require.NoError(t, message.MarshalPath("3.1", "00"))
+		require.NoError(t, message.Field(11, "123456"))
+		require.NoError(t, message.Field(35, "4242424242424242=2512"))
+
+		require.NoError(t, message.Validate())
+	})
+
+	t.Run("returns all violations with field paths", func(t *testing.T) {
+		message := NewMessage(spec)
+		message.MTI("0100")
+		require.NoError(t, message.MarshalPath("3.2", "00"))
+		require.NoError(t, message.Field(39, "00"))
+
+		err := message.Validate()
+		require.Error(t, err)
+
+		var validationErr *iso8583errors.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		require.Equal(t, "0100", validationErr.MTI)
+		require.Equal(t, []string{"2", "3.1", "11", "39", "14"}, validationErr.FieldIDs())
+		require.EqualError(t, err, "message 0100 is invalid: "+
+			"field 2: mandatory field is missing; "+
+			"field 3.1: mandatory field is missing; "+
+			"field 11: mandatory field is missing; "+
+			"field 39: field is not allowed in message 0100; "+
+			"field 14: field is required when 35 absent")
+	})
+
+	t.Run("conditional field is optional when condition is not met", func(t *testing.T) {
+		message := NewMessage(spec)
+		message.MTI("0110")
+		require.NoError(t, message.Field(11, "123456"))
+
+		err := message.Validate()
+
+		var validationErr *iso8583errors.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		require.Equal(t, []string{"39"}, validationErr.FieldIDs())
+	})
+
+	t.Run("message without rule for its MTI is valid", func(t *testing.T) {
+		message := NewMessage(spec)
+		message.MTI("0800")
+
+		require.NoError(t, message.Validate())
+	})
+
+	t.Run("spec with invalid rules fails validation", func(t *testing.T) {
+		err := newSpec(map[string]*MessageRule{
+			"0100": {Fields: map[string]Presence{"2": "required"}},
+		}).Validate()
+		require.EqualError(t, err, `validating rules: rule for MTI 0100: unknown presence "required" for field 2`)
+
+		err = newSpec(map[string]*MessageRule{
+			"0100": {Fields: map[string]Presence{"64": Mandatory}},
+		}).Validate()
+		require.EqualError(t, err, `validating rules: rule for MTI 0100: field 64 from path "64" is not defined in the spec`)
+
+		err = newSpec(map[string]*MessageRule{
+			"0100": {Conditions: []FieldCondition{{Field: "14", Presence: Optional}}},
+		}).Validate()
+		require.EqualError(t, err, `validating rules: rule for MTI 0100: condition for field 14 supports only mandatory or forbidden presence, got "optional"`)
+	})
+}
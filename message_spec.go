@@ -2,6 +2,8 @@ package iso8583
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 
 	"github.com/moov-io/iso8583/field"
 )
@@ -9,10 +11,60 @@ import (
 type MessageSpec struct {
 	Name   string
 	Fields map[int]field.Field
+
+	// Header defines the protocol header that is packed before the MTI,
+	// e.g. BASE24 "ISO" header or Visa header. It's usually a composite
+	// field with positional subfields. Header is optional.
+	Header field.Field
+
+	// Bitmapless defines the message without the bitmap, used by some
+	// host-to-host batch and proprietary formats. All fields of the spec
+	// are always present and are packed in the order of their IDs. Field 1
+	// is a regular field and the MTI (field 0) is optional.
+	Bitmapless bool
+
+	// Rules defines mandatory, conditional, optional and forbidden fields
+	// for messages keyed by MTI. Rules are checked by Message.Validate.
+	Rules map[string]*MessageRule
+
+	// Converters defines converters of custom types of struct fields used
+	// by Message.Marshal and Message.Unmarshal, keyed by the type. Types of
+	// the struct fields can also implement FieldMarshaler and
+	// FieldUnmarshaler or encoding.TextMarshaler and
+	// encoding.TextUnmarshaler.
+	Converters field.Converters
 }
 
+// FieldMarshaler is implemented by types of struct fields that set the value
+// of the message field themselves.
+type FieldMarshaler = field.Marshaler
+
+// FieldUnmarshaler is implemented by types of struct fields that get the
+// value from the message field themselves.
+type FieldUnmarshaler = field.Unmarshaler
+
 // Validate checks if the MessageSpec is valid.
 func (s *MessageSpec) Validate() error {
+	if err := s.validateFields(); err != nil {
+		return err
+	}
+
+	if err := s.validateRules(); err != nil {
+		return fmt.Errorf("validating rules: %w", err)
+	}
+
+	return nil
+}
+
+func (s *MessageSpec) validateFields() error {
+	if s.Bitmapless {
+		if _, ok := s.Fields[bitmapIdx].(*field.Bitmap); ok {
+			return fmt.Errorf("Bitmap field (%d) must not be defined in the bitmapless spec", bitmapIdx)
+		}
+
+		return nil
+	}
+
 	// we require MTI and Bitmap fields
 	if _, ok := s.Fields[mtiIdx]; !ok {
 		return fmt.Errorf("MTI field (%d) is required", mtiIdx)
@@ -29,3 +81,9 @@ func (s *MessageSpec) Validate() error {
 
 	return nil
 }
+
+// fieldIDs returns the IDs of the fields defined in the spec in ascending
+// order.
+func (s *MessageSpec) fieldIDs() []int {
+	return slices.Sorted(maps.Keys(s.Fields))
+}
@@ -3,6 +3,9 @@ package iso8583
 import (
 	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"maps"
+	"math/big"
 	"reflect"
 	"strings"
 	"sync"
@@ -3650,3 +3653,906 @@ func TestAnonymousEmbeddedStructSupport(t *testing.T) {
 		require.Equal(t, []byte("0100"), packed[:4]) // MTI
 	})
 }
+
+func TestMessageAppendPack(t *testing.T) {
+	message := NewMessage(Spec87)
+	message.MTI("0100")
+	require.NoError(t, message.Field(2, "4242424242424242"))
+	require.NoError(t, message.Field(3, "123456"))
+	require.NoError(t, message.Field(4, "100"))
+	require.NoError(t, message.Field(70, "301"))
+
+	packed, err := message.Pack()
+	require.NoError(t, err)
+
+	t.Run("appends packed message to the buffer", func(t *testing.T) {
+		buf := make([]byte, 0, 512)
+		buf = append(buf, "header"...)
+
+		buf, err := message.AppendPack(buf)
+		require.NoError(t, err)
+		require.Equal(t, "header"+string(packed), string(buf))
+	})
+
+	t.Run("returns packed length", func(t *testing.T) {
+		packedLen, err := message.PackedLen()
+		require.NoError(t, err)
+		require.Equal(t, len(packed), packedLen)
+	})
+
+	t.Run("returns pack error", func(t *testing.T) {
+		message := NewMessage(Spec87)
+		message.MTI("0100")
+		require.NoError(t, message.Field(2, "4242424242424242424242"))
+
+		_, err := message.AppendPack(nil)
+		require.Error(t, err)
+
+		var packErr *iso8583errors.PackError
+		require.ErrorAs(t, err, &packErr)
+		require.Contains(t, err.Error(), "failed to pack field 2")
+	})
+}
+
+func TestMessageHeader(t *testing.T) {
+	headerSpec := field.NewComposite(&field.Spec{
+		Length:      12,
+		Description: "BASE24 Header",
+		Pref:        prefix.ASCII.Fixed,
+		Tag: &field.TagSpec{
+			Sort: sort.StringsByInt,
+		},
+		Subfields: map[string]field.Field{
+			"1": field.NewString(&field.Spec{
+				Length:      3,
+				Description: "Indicator",
+				Enc:         encoding.ASCII,
+				Pref:        prefix.ASCII.Fixed,
+			}),
+			"2": field.NewString(&field.Spec{
+				Length:      2,
+				Description: "Product Indicator",
+				Enc:         encoding.ASCII,
+				Pref:        prefix.ASCII.Fixed,
+			}),
+			"3": field.NewString(&field.Spec{
+				Length:      2,
+				Description: "Release Number",
+				Enc:         encoding.ASCII,
+				Pref:        prefix.ASCII.Fixed,
+			}),
+			"4": field.NewString(&field.Spec{
+				Length:      3,
+				Description: "Status",
+				Enc:         encoding.ASCII,
+				Pref:        prefix.ASCII.Fixed,
+			}),
+			"5": field.NewString(&field.Spec{
+				Length:      1,
+				Description: "Originator Code",
+				Enc:         encoding.ASCII,
+				Pref:        prefix.ASCII.Fixed,
+			}),
+			"6": field.NewString(&field.Spec{
+				Length:      1,
+				Description: "Responder Code",
+				Enc:         encoding.ASCII,
+				Pref:        prefix.ASCII.Fixed,
+			}),
+		},
+	})
+
+	spec := &MessageSpec{
+		Name:   "BASE24",
+		Header: headerSpec,
+		Fields: Spec87.Fields,
+	}
+
+	type base24Header struct {
+		Indicator        *field.String `index:"1"`
+		ProductIndicator *field.String `index:"2"`
+		ReleaseNumber    *field.String `index:"3"`
+		Status           *field.String `index:"4"`
+		OriginatorCode   *field.String `index:"5"`
+		ResponderCode    *field.String `index:"6"`
+	}
+
+	type networkMessage struct {
+		Header *base24Header `iso8583:"header"`
+		MTI    *field.String `index:"0"`
+		STAN   *field.String `index:"11"`
+		Code   *field.String `index:"70"`
+	}
+
+	header := &base24Header{
+		Indicator:        field.NewStringValue("ISO"),
+		ProductIndicator: field.NewStringValue("00"),
+		ReleaseNumber:    field.NewStringValue("60"),
+		Status:           field.NewStringValue("000"),
+		OriginatorCode:   field.NewStringValue("3"),
+		ResponderCode:    field.NewStringValue("0"),
+	}
+
+	packMessage := func(t *testing.T) []byte {
+		t.Helper()
+
+		message := NewMessage(spec)
+		err := message.Marshal(&networkMessage{
+			Header: header,
+			MTI:    field.NewStringValue("0800"),
+			STAN:   field.NewStringValue("000001"),
+			Code:   field.NewStringValue("301"),
+		})
+		require.NoError(t, err)
+
+		packed, err := message.Pack()
+		require.NoError(t, err)
+
+		return packed
+	}
+
+	t.Run("header is packed before the MTI", func(t *testing.T) {
+		packed := packMessage(t)
+
+		require.Equal(t, "ISO006000030", string(packed[:12]))
+		require.Equal(t, "0800", string(packed[12:16]))
+
+		message := NewMessage(spec)
+		require.NoError(t, message.Marshal(&networkMessage{Header: header, MTI: field.NewStringValue("0800")}))
+
+		packedLen, err := message.PackedLen()
+		require.NoError(t, err)
+
+		packed, err = message.Pack()
+		require.NoError(t, err)
+		require.Equal(t, len(packed), packedLen)
+	})
+
+	t.Run("header is unpacked and unmarshaled", func(t *testing.T) {
+		message := NewMessage(spec)
+		require.NoError(t, message.Unpack(packMessage(t)))
+
+		mti, err := message.GetMTI()
+		require.NoError(t, err)
+		require.Equal(t, "0800", mti)
+
+		status, err := message.Header().(*field.Composite).GetSubfields()["4"].String()
+		require.NoError(t, err)
+		require.Equal(t, "000", status)
+
+		data := &networkMessage{}
+		require.NoError(t, message.Unmarshal(data))
+		require.Equal(t, "ISO", data.Header.Indicator.Value())
+		require.Equal(t, "3", data.Header.OriginatorCode.Value())
+		require.Equal(t, "000001", data.STAN.Value())
+		require.Equal(t, "301", data.Code.Value())
+	})
+
+	t.Run("header is described before the MTI", func(t *testing.T) {
+		message := NewMessage(spec)
+		require.NoError(t, message.Unpack(packMessage(t)))
+
+		out := strings.Builder{}
+		require.NoError(t, Describe(message, &out))
+
+		described := out.String()
+		require.Contains(t, described, "HEADER BASE24 Header SUBFIELDS:")
+		require.Contains(t, described, "Release Number")
+		require.Less(t, strings.Index(described, "HEADER"), strings.Index(described, "MTI"))
+	})
+
+	t.Run("scanner skips header", func(t *testing.T) {
+		scanner := NewMessageScanner(spec, packMessage(t))
+
+		mti, err := scanner.ScanField(0)
+		require.NoError(t, err)
+
+		value, err := mti.String()
+		require.NoError(t, err)
+		require.Equal(t, "0800", value)
+	})
+
+	t.Run("returns unpack error for invalid header", func(t *testing.T) {
+		message := NewMessage(spec)
+		err := message.Unpack([]byte("ISO0060"))
+		require.Error(t, err)
+
+		var unpackErr *iso8583errors.UnpackError
+		require.ErrorAs(t, err, &unpackErr)
+		require.Equal(t, "header", unpackErr.FieldID)
+	})
+
+	t.Run("returns error when header is not defined in the spec", func(t *testing.T) {
+		message := NewMessage(Spec87)
+		err := message.Marshal(&networkMessage{Header: header})
+		require.EqualError(t, err, "marshaling struct: header is not defined in the spec")
+	})
+}
+
+var bitmaplessSpec = &MessageSpec{
+	Name:       "Batch Record",
+	Bitmapless: true,
+	Fields: map[int]field.Field{
+		1: field.NewString(&field.Spec{
+			Length:      2,
+			Description: "Record Type",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.ASCII.Fixed,
+		}),
+		2: field.NewString(&field.Spec{
+			Length:      19,
+			Description: "Primary Account Number",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.ASCII.LL,
+		}),
+		3: field.NewNumeric(&field.Spec{
+			Length:      12,
+			Description: "Transaction Amount",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.ASCII.Fixed,
+			Pad:         padding.Left('0'),
+		}),
+		4: field.NewString(&field.Spec{
+			Length:      5,
+			Description: "Reserved",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.ASCII.Fixed,
+			Pad:         padding.Right(' '),
+		}),
+	},
+}
+
+func TestBitmaplessMessage(t *testing.T) {
+	type batchRecord struct {
+		RecordType string `index:"1"`
+		PAN        string `index:"2"`
+		Amount     int64  `index:"3"`
+	}
+
+	packed := []byte("01164242424242424242000000000100     ")
+
+	t.Run("Pack packs all fields in order", func(t *testing.T) {
+		message := NewMessage(bitmaplessSpec)
+		require.NoError(t, message.Marshal(&batchRecord{
+			RecordType: "01",
+			PAN:        "4242424242424242",
+			Amount:     100,
+		}))
+
+		require.Nil(t, message.Bitmap())
+
+		got, err := message.Pack()
+		require.NoError(t, err)
+		require.Equal(t, string(packed), string(got))
+
+		packedLen, err := message.PackedLen()
+		require.NoError(t, err)
+		require.Equal(t, len(packed), packedLen)
+	})
+
+	t.Run("Unpack unpacks all fields in order", func(t *testing.T) {
+		message := NewMessage(bitmaplessSpec)
+		require.NoError(t, message.Unpack(packed))
+
+		data := &batchRecord{}
+		require.NoError(t, message.Unmarshal(data))
+		require.Equal(t, "01", data.RecordType)
+		require.Equal(t, "4242424242424242", data.PAN)
+		require.Equal(t, int64(100), data.Amount)
+
+		require.Len(t, message.GetFields(), 4)
+	})
+
+	t.Run("Unpack with lazy unpacking", func(t *testing.T) {
+		message := NewMessage(bitmaplessSpec)
+		require.NoError(t, message.UnpackWithOptions(packed, WithLazyUnpacking()))
+
+		pan, err := message.GetString(2)
+		require.NoError(t, err)
+		require.Equal(t, "4242424242424242", pan)
+
+		got, err := message.Pack()
+		require.NoError(t, err)
+		require.Equal(t, packed, got)
+	})
+
+	t.Run("Unpack returns error for truncated message", func(t *testing.T) {
+		message := NewMessage(bitmaplessSpec)
+		err := message.Unpack(packed[:30])
+		require.Error(t, err)
+
+		var unpackErr *iso8583errors.UnpackError
+		require.ErrorAs(t, err, &unpackErr)
+		require.Equal(t, "3", unpackErr.FieldID)
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		message := NewMessage(bitmaplessSpec)
+		require.NoError(t, message.Unpack(packed))
+
+		data, err := json.Marshal(message)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"1":"01","2":"4242424242424242","3":100,"4":""}`, string(data))
+
+		messageFromJSON := NewMessage(bitmaplessSpec)
+		require.NoError(t, json.Unmarshal(data, messageFromJSON))
+
+		got, err := messageFromJSON.Pack()
+		require.NoError(t, err)
+		require.Equal(t, packed, got)
+	})
+
+	t.Run("Describe", func(t *testing.T) {
+		message := NewMessage(bitmaplessSpec)
+		require.NoError(t, message.Unpack(packed))
+
+		out := strings.Builder{}
+		require.NoError(t, Describe(message, &out, DoNotFilterFields()...))
+
+		expected := `Batch Record Message:
+F1   Record Type.............: 01
+F2   Primary Account Number..: 4242424242424242
+F3   Transaction Amount......: 100
+F4   Reserved................: 
+`
+		require.Equal(t, expected, out.String())
+	})
+
+	t.Run("Validate returns error when bitmap is defined", func(t *testing.T) {
+		spec := &MessageSpec{
+			Bitmapless: true,
+			Fields: map[int]field.Field{
+				1: Spec87.Fields[1],
+			},
+		}
+
+		require.EqualError(t, spec.Validate(), "Bitmap field (1) must not be defined in the bitmapless spec")
+	})
+}
+
+func TestMessageRepeatingField(t *testing.T) {
+	fields := maps.Clone(Spec87.Fields)
+	fields[54] = field.NewRepeating(&field.Spec{
+		Length:      120,
+		Description: "Additional Amounts",
+		Pref:        prefix.ASCII.LLL,
+		Repeat: &field.RepeatSpec{
+			Mode:  field.RepeatFill,
+			Count: 6,
+			Element: field.NewComposite(&field.Spec{
+				Length:      20,
+				Description: "Additional Amount",
+				Pref:        prefix.ASCII.Fixed,
+				Tag: &field.TagSpec{
+					Sort: sort.StringsByInt,
+				},
+				Subfields: map[string]field.Field{
+					"1": field.NewString(&field.Spec{
+						Length:      4,
+						Description: "Amount Type",
+						Enc:         encoding.ASCII,
+						Pref:        prefix.ASCII.Fixed,
+					}),
+					"2": field.NewNumeric(&field.Spec{
+						Length:      16,
+						Description: "Amount",
+						Enc:         encoding.ASCII,
+						Pref:        prefix.ASCII.Fixed,
+						Pad:         padding.Left('0'),
+					}),
+				},
+			}),
+		},
+	})
+
+	spec := &MessageSpec{
+		Name:   "Spec with additional amounts",
+		Fields: fields,
+	}
+
+	type additionalAmount struct {
+		Type   string `index:"1"`
+		Amount int64  `index:"2"`
+	}
+
+	type authorizationRequest struct {
+		MTI               string             `index:"0"`
+		AdditionalAmounts []additionalAmount `iso8583:"54"`
+	}
+
+	message := NewMessage(spec)
+	require.NoError(t, message.Marshal(&authorizationRequest{
+		MTI: "0100",
+		AdditionalAmounts: []additionalAmount{
+			{Type: "0002", Amount: 1000},
+			{Type: "0001", Amount: 250},
+		},
+	}))
+
+	packed, err := message.Pack()
+	require.NoError(t, err)
+
+	message = NewMessage(spec)
+	require.NoError(t, message.Unpack(packed))
+
+	data := &authorizationRequest{}
+	require.NoError(t, message.Unmarshal(data))
+	require.Equal(t, []additionalAmount{
+		{Type: "0002", Amount: 1000},
+		{Type: "0001", Amount: 250},
+	}, data.AdditionalAmounts)
+
+	out := strings.Builder{}
+	require.NoError(t, Describe(message, &out, DoNotFilterFields()...))
+	require.Contains(t, out.String(), "F54  Additional Amounts SUBFIELDS:")
+	require.Contains(t, out.String(), "F2   Additional Amount SUBFIELDS:")
+}
+
+func TestMessageDigitsFields(t *testing.T) {
+	fields := maps.Clone(Spec87.Fields)
+	fields[2] = field.NewDigits(&field.Spec{
+		Length:      19,
+		Description: "Primary Account Number",
+		Enc:         encoding.ASCII,
+		Pref:        prefix.ASCII.LL,
+	})
+	fields[74] = field.NewDigits(&field.Spec{
+		Length:      20,
+		Description: "Credits, Number",
+		Enc:         encoding.ASCII,
+		Pref:        prefix.ASCII.Fixed,
+		Pad:         padding.Left('0'),
+	})
+	fields[97] = field.NewDigits(&field.Spec{
+		Length:      24,
+		Description: "Amount, Net Settlement",
+		Enc:         encoding.ASCII,
+		Pref:        prefix.ASCII.Fixed,
+		Pad:         padding.Left('0'),
+	})
+
+	spec := &MessageSpec{
+		Name:   "Spec with wide numeric fields",
+		Fields: fields,
+	}
+
+	total, ok := new(big.Int).SetString("123456789012345678901234", 10)
+	require.True(t, ok)
+
+	type settlementRequest struct {
+		MTI         string   `iso8583:"0"`
+		PAN         string   `iso8583:"2"`
+		CreditCount uint64   `iso8583:"74"`
+		NetAmount   *big.Int `iso8583:"97"`
+	}
+
+	message := NewMessage(spec)
+	require.NoError(t, message.Marshal(&settlementRequest{
+		MTI:         "0500",
+		PAN:         "0000123456789012345",
+		CreditCount: 18446744073709551615,
+		NetAmount:   total,
+	}))
+
+	packed, err := message.Pack()
+	require.NoError(t, err)
+
+	message = NewMessage(spec)
+	require.NoError(t, message.Unpack(packed))
+
+	data := &settlementRequest{}
+	require.NoError(t, message.Unmarshal(data))
+
+	require.Equal(t, "0000123456789012345", data.PAN)
+	require.Equal(t, uint64(18446744073709551615), data.CreditCount)
+	require.Equal(t, 0, total.Cmp(data.NetAmount))
+}
+
+func TestMessageDateTimeFields(t *testing.T) {
+	now := time.Date(2025, time.January, 1, 0, 5, 0, 0, time.UTC)
+
+	fields := maps.Clone(Spec87.Fields)
+	fields[7] = field.NewDateTime(&field.Spec{
+		Length:      10,
+		Description: "Transmission Date & Time",
+		Enc:         encoding.ASCII,
+		Pref:        prefix.ASCII.Fixed,
+		DateTime: &field.DateTimeSpec{
+			Layout: "MMDDhhmmss",
+			Now:    func() time.Time { return now },
+		},
+	})
+	fields[13] = field.NewDateTime(&field.Spec{
+		Length:      4,
+		Description: "Local Transaction Date",
+		Enc:         encoding.ASCII,
+		Pref:        prefix.ASCII.Fixed,
+		DateTime: &field.DateTimeSpec{
+			Layout:        "MMDD",
+			YearInference: field.PastYear,
+			Now:           func() time.Time { return now },
+		},
+	})
+
+	spec := &MessageSpec{
+		Name:   "Spec with date/time fields",
+		Fields: fields,
+	}
+
+	type authorizationRequest struct {
+		MTI                  string    `iso8583:"0"`
+		TransmissionDateTime time.Time `iso8583:"7"`
+		LocalDate            time.Time `iso8583:"13"`
+	}
+
+	message := NewMessage(spec)
+	require.NoError(t, message.Marshal(&authorizationRequest{
+		MTI:                  "0100",
+		TransmissionDateTime: time.Date(2024, time.December, 31, 23, 59, 59, 0, time.UTC),
+		LocalDate:            time.Date(2024, time.December, 31, 0, 0, 0, 0, time.UTC),
+	}))
+
+	packed, err := message.Pack()
+	require.NoError(t, err)
+	require.Contains(t, string(packed), "12312359591231")
+
+	message = NewMessage(spec)
+	require.NoError(t, message.Unpack(packed))
+
+	data := &authorizationRequest{}
+	require.NoError(t, message.Unmarshal(data))
+
+	require.Equal(t, time.Date(2024, time.December, 31, 23, 59, 59, 0, time.UTC), data.TransmissionDateTime)
+	require.Equal(t, time.Date(2024, time.December, 31, 0, 0, 0, 0, time.UTC), data.LocalDate)
+}
+
+// money implements FieldMarshaler and FieldUnmarshaler
+type money struct {
+	minorUnits int64
+}
+
+func (m money) MarshalISO8583(f field.Field) error {
+	return f.Marshal(m.minorUnits)
+}
+
+func (m *money) UnmarshalISO8583(f field.Field) error {
+	return f.Unmarshal(&m.minorUnits)
+}
+
+var (
+	_ FieldMarshaler   = money{}
+	_ FieldUnmarshaler = (*money)(nil)
+)
+
+type responseCode string
+
+type processingCode struct {
+	transactionType string
+	accountFrom     string
+	accountTo       string
+}
+
+func TestMessageStructFieldConversion(t *testing.T) {
+	spec := &MessageSpec{
+		Name:   "Spec with converters",
+		Fields: Spec87.Fields,
+		Converters: field.Converters{
+			reflect.TypeFor[processingCode](): field.NewConverter(
+				func(v processingCode, format string) (string, error) {
+					return v.transactionType + v.accountFrom + v.accountTo, nil
+				},
+				func(s string, format string) (processingCode, error) {
+					if len(s) != 6 {
+						return processingCode{}, errors.New("invalid processing code")
+					}
+
+					return processingCode{s[0:2], s[2:4], s[4:6]}, nil
+				},
+			),
+		},
+	}
+
+	type authorizationRequest struct {
+		MTI                  string         `iso8583:"0"`
+		ProcessingCode       processingCode `iso8583:"3"`
+		Amount               money          `iso8583:"4"`
+		TransmissionDateTime time.Time      `iso8583:"7,format=MMDDhhmmss"`
+		STAN                 uint32         `iso8583:"11"`
+		ResponseCode         responseCode   `iso8583:"39"`
+	}
+
+	request := &authorizationRequest{
+		MTI:                  "0100",
+		ProcessingCode:       processingCode{"31", "20", "00"},
+		Amount:               money{minorUnits: 150},
+		TransmissionDateTime: time.Now().UTC().Truncate(time.Second),
+		STAN:                 123456,
+		ResponseCode:         "00",
+	}
+
+	message := NewMessage(spec)
+	require.NoError(t, message.Marshal(request))
+
+	processing, err := message.GetString(3)
+	require.NoError(t, err)
+	require.Equal(t, "312000", processing)
+
+	transmissionDateTime, err := message.GetString(7)
+	require.NoError(t, err)
+	require.Equal(t, request.TransmissionDateTime.Format("0102150405"), transmissionDateTime)
+
+	packed, err := message.Pack()
+	require.NoError(t, err)
+
+	message = NewMessage(spec)
+	require.NoError(t, message.Unpack(packed))
+
+	data := &authorizationRequest{}
+	require.NoError(t, message.Unmarshal(data))
+	require.Equal(t, request, data)
+}
+
+type customerID struct {
+	branch string
+	number string
+}
+
+func TestMessageStructFieldConversionInComposite(t *testing.T) {
+	tag := &field.TagSpec{
+		Length: 2,
+		Enc:    encoding.ASCII,
+		Pad:    padding.Left('0'),
+		Sort:   sort.StringsByInt,
+	}
+
+	spec := &MessageSpec{
+		Name: "Spec with converters of subfields",
+		Fields: map[int]field.Field{
+			0: Spec87.Fields[0],
+			1: Spec87.Fields[1],
+			48: field.NewComposite(&field.Spec{
+				Length:      999,
+				Description: "Additional Data",
+				Pref:        prefix.ASCII.LLL,
+				Tag:         tag,
+				Subfields: map[string]field.Field{
+					"1": field.NewComposite(&field.Spec{
+						Length:      99,
+						Description: "Customer Data",
+						Pref:        prefix.ASCII.LL,
+						Tag:         tag,
+						Subfields: map[string]field.Field{
+							"1": field.NewString(&field.Spec{
+								Length:      20,
+								Description: "Customer ID",
+								Enc:         encoding.ASCII,
+								Pref:        prefix.ASCII.LL,
+							}),
+						},
+					}),
+					"2": field.NewNumeric(&field.Spec{
+						Length:      6,
+						Description: "Installments",
+						Enc:         encoding.ASCII,
+						Pref:        prefix.ASCII.LL,
+					}),
+				},
+			}),
+		},
+		Converters: field.Converters{
+			reflect.TypeFor[customerID](): field.NewConverter(
+				func(v customerID, format string) (string, error) {
+					return v.branch + "-" + v.number, nil
+				},
+				func(s string, format string) (customerID, error) {
+					branch, number, ok := strings.Cut(s, "-")
+					if !ok {
+						return customerID{}, errors.New("invalid customer ID")
+					}
+
+					return customerID{branch, number}, nil
+				},
+			),
+		},
+	}
+
+	type customerData struct {
+		ID customerID `iso8583:"1"`
+	}
+
+	type additionalData struct {
+		Customer     *customerData `iso8583:"1"`
+		Installments uint8         `iso8583:"2"`
+	}
+
+	type authorizationRequest struct {
+		MTI            string          `iso8583:"0"`
+		AdditionalData *additionalData `iso8583:"48"`
+	}
+
+	request := &authorizationRequest{
+		MTI: "0100",
+		AdditionalData: &additionalData{
+			Customer: &customerData{
+				ID: customerID{"042", "123456"},
+			},
+			Installments: 3,
+		},
+	}
+
+	message := NewMessage(spec)
+	require.NoError(t, message.Marshal(request))
+
+	// subfield 1 of subfield 1 is set by the converter
+	additional, err := message.GetString(48)
+	require.NoError(t, err)
+	require.Equal(t, "01140110042-12345602013", additional)
+
+	packed, err := message.Pack()
+	require.NoError(t, err)
+
+	message = NewMessage(spec)
+	require.NoError(t, message.Unpack(packed))
+
+	data := &authorizationRequest{}
+	require.NoError(t, message.Unmarshal(data))
+	require.Equal(t, request, data)
+}
+
+func TestMessageFlattenedPathTags(t *testing.T) {
+	spec := &MessageSpec{
+		Name: "Spec with composite fields",
+		Fields: map[int]field.Field{
+			0: Spec87.Fields[0],
+			1: Spec87.Fields[1],
+			3: field.NewComposite(&field.Spec{
+				Length:      6,
+				Description: "Processing Code",
+				Pref:        prefix.ASCII.Fixed,
+				Tag: &field.TagSpec{
+					Sort: sort.StringsByInt,
+				},
+				Subfields: map[string]field.Field{
+					"1": field.NewString(&field.Spec{
+						Length:      2,
+						Description: "Transaction Type",
+						Enc:         encoding.ASCII,
+						Pref:        prefix.ASCII.Fixed,
+					}),
+					"2": field.NewString(&field.Spec{
+						Length:      2,
+						Description: "From Account",
+						Enc:         encoding.ASCII,
+						Pref:        prefix.ASCII.Fixed,
+					}),
+					"3": field.NewString(&field.Spec{
+						Length:      2,
+						Description: "To Account",
+						Enc:         encoding.ASCII,
+						Pref:        prefix.ASCII.Fixed,
+					}),
+				},
+			}),
+			48: field.NewComposite(&field.Spec{
+				Length:      999,
+				Description: "Additional Data",
+				Pref:        prefix.ASCII.LLL,
+				Tag: &field.TagSpec{
+					Length: 2,
+					Enc:    encoding.ASCII,
+					Pad:    padding.Left('0'),
+					Sort:   sort.StringsByInt,
+				},
+				Subfields: map[string]field.Field{
+					"1": field.NewString(&field.Spec{
+						Length:      20,
+						Description: "Customer Reference",
+						Enc:         encoding.ASCII,
+						Pref:        prefix.ASCII.LL,
+					}),
+					"2": field.NewNumeric(&field.Spec{
+						Length:      6,
+						Description: "Installments",
+						Enc:         encoding.ASCII,
+						Pref:        prefix.ASCII.LL,
+					}),
+					"3": field.NewString(&field.Spec{
+						Length:      1,
+						Description: "Recurring Indicator",
+						Enc:         encoding.ASCII,
+						Pref:        prefix.ASCII.LL,
+					}),
+				},
+			}),
+			55: field.NewComposite(&field.Spec{
+				Length:      999,
+				Description: "ICC Data – EMV Having Multiple Tags",
+				Pref:        prefix.ASCII.LLL,
+				Tag: &field.TagSpec{
+					Enc:  encoding.BerTLVTag,
+					Sort: sort.StringsByHex,
+				},
+				Subfields: map[string]field.Field{
+					"9A": field.NewString(&field.Spec{
+						Description: "Transaction Date",
+						Enc:         encoding.Binary,
+						Pref:        prefix.BerTLV,
+					}),
+					"9F02": field.NewString(&field.Spec{
+						Description: "Amount, Authorized (Numeric)",
+						Enc:         encoding.Binary,
+						Pref:        prefix.BerTLV,
+					}),
+				},
+			}),
+		},
+	}
+
+	type processingCode struct {
+		TransactionType string `iso8583:"1"`
+		FromAccount     string `iso8583:"2"`
+	}
+
+	type authorizationRequest struct {
+		MTI string `iso8583:"0"`
+
+		// nested and flattened styles are mixed for field 3
+		ProcessingCode *processingCode `iso8583:"3"`
+		ToAccount      string          `iso8583:"3.3"`
+
+		CustomerReference string `iso8583:"48.1"`
+		Installments      uint8  `iso8583:"48.2"`
+		Recurring         bool   `iso8583:"48.3,format=Y/N"`
+
+		TransactionDate  string        `iso8583:"55.9A"`
+		AuthorizedAmount *field.String `iso8583:"55.9F02"`
+	}
+
+	request := &authorizationRequest{
+		MTI: "0100",
+		ProcessingCode: &processingCode{
+			TransactionType: "00",
+			FromAccount:     "20",
+		},
+		ToAccount:         "30",
+		CustomerReference: "INV-42",
+		Installments:      12,
+		Recurring:         true,
+		TransactionDate:   "250101",
+		AuthorizedAmount:  field.NewStringValue("000000000150"),
+	}
+
+	message := NewMessage(spec)
+	require.NoError(t, message.Marshal(request))
+
+	processing, err := message.GetString(3)
+	require.NoError(t, err)
+	require.Equal(t, "002030", processing)
+
+	additionalData, err := message.GetString(48)
+	require.NoError(t, err)
+	require.Equal(t, "0106INV-420202120301Y", additionalData)
+
+	var amount string
+	require.NoError(t, message.UnmarshalPath("55.9F02", &amount))
+	require.Equal(t, "000000000150", amount)
+
+	packed, err := message.Pack()
+	require.NoError(t, err)
+
+	message = NewMessage(spec)
+	require.NoError(t, message.Unpack(packed))
+
+	data := &authorizationRequest{}
+	require.NoError(t, message.Unmarshal(data))
+	require.Equal(t, request, data)
+
+	t.Run("returns error for subfield not defined in the spec", func(t *testing.T) {
+		type invalidRequest struct {
+			Unknown string `iso8583:"48.9"`
+		}
+
+		err := NewMessage(spec).Marshal(&invalidRequest{Unknown: "x"})
+		require.EqualError(t, err, "marshaling struct: failed to set value to field 48.9: marshaling filed 48: getting or creating subfield 9: creating field 9: field 9 is not defined in the spec")
+	})
+}
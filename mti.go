@@ -0,0 +1,337 @@
+package iso8583
+
+import (
+	"fmt"
+)
+
+// MTIVersion is the first digit of the MTI that defines the version of the
+// ISO 8583 standard.
+type MTIVersion byte
+
+const (
+	MTIVersion1987     MTIVersion = '0'
+	MTIVersion1993     MTIVersion = '1'
+	MTIVersion2003     MTIVersion = '2'
+	MTIVersionNational MTIVersion = '8'
+	MTIVersionPrivate  MTIVersion = '9'
+)
+
+func (v MTIVersion) String() string {
+	switch v {
+	case MTIVersion1987:
+		return "ISO 8583:1987"
+	case MTIVersion1993:
+		return "ISO 8583:1993"
+	case MTIVersion2003:
+		return "ISO 8583:2003"
+	case MTIVersionNational:
+		return "National use"
+	case MTIVersionPrivate:
+		return "Private use"
+	}
+
+	return "Reserved by ISO"
+}
+
+// MessageClass is the second digit of the MTI that defines the overall
+// purpose of the message.
+type MessageClass byte
+
+const (
+	ClassAuthorization     MessageClass = '1'
+	ClassFinancial         MessageClass = '2'
+	ClassFileAction        MessageClass = '3'
+	ClassReversal          MessageClass = '4'
+	ClassReconciliation    MessageClass = '5'
+	ClassAdministrative    MessageClass = '6'
+	ClassFeeCollection     MessageClass = '7'
+	ClassNetworkManagement MessageClass = '8'
+)
+
+func (c MessageClass) String() string {
+	switch c {
+	case ClassAuthorization:
+		return "Authorization"
+	case ClassFinancial:
+		return "Financial"
+	case ClassFileAction:
+		return "File Actions"
+	case ClassReversal:
+		return "Reversal or Chargeback"
+	case ClassReconciliation:
+		return "Reconciliation"
+	case ClassAdministrative:
+		return "Administrative"
+	case ClassFeeCollection:
+		return "Fee Collection"
+	case ClassNetworkManagement:
+		return "Network Management"
+	}
+
+	return "Reserved by ISO"
+}
+
+// MessageFunction is the third digit of the MTI that defines how the message
+// flows within the payment system.
+type MessageFunction byte
+
+const (
+	FunctionRequest         MessageFunction = '0'
+	FunctionRequestResponse MessageFunction = '1'
+	FunctionAdvice          MessageFunction = '2'
+	FunctionAdviceResponse  MessageFunction = '3'
+	FunctionNotification    MessageFunction = '4'
+	FunctionNotificationAck MessageFunction = '5'
+	// instructions are defined only in ISO 8583:2003
+	FunctionInstruction    MessageFunction = '6'
+	FunctionInstructionAck MessageFunction = '7'
+	// acknowledgements of responses are not defined by ISO, but are widely
+	// used by networks (e.g. 0180 and 0190)
+	FunctionResponseAck         MessageFunction = '8'
+	FunctionResponseNegativeAck MessageFunction = '9'
+)
+
+func (f MessageFunction) String() string {
+	switch f {
+	case FunctionRequest:
+		return "Request"
+	case FunctionRequestResponse:
+		return "Request Response"
+	case FunctionAdvice:
+		return "Advice"
+	case FunctionAdviceResponse:
+		return "Advice Response"
+	case FunctionNotification:
+		return "Notification"
+	case FunctionNotificationAck:
+		return "Notification Acknowledgement"
+	case FunctionInstruction:
+		return "Instruction"
+	case FunctionInstructionAck:
+		return "Instruction Acknowledgement"
+	case FunctionResponseAck:
+		return "Response Acknowledgement"
+	case FunctionResponseNegativeAck:
+		return "Response Negative Acknowledgement"
+	}
+
+	return "Unknown"
+}
+
+// MessageOrigin is the fourth digit of the MTI that defines the source of the
+// message within the payment chain.
+type MessageOrigin byte
+
+const (
+	OriginAcquirer       MessageOrigin = '0'
+	OriginAcquirerRepeat MessageOrigin = '1'
+	OriginIssuer         MessageOrigin = '2'
+	OriginIssuerRepeat   MessageOrigin = '3'
+	OriginOther          MessageOrigin = '4'
+	OriginOtherRepeat    MessageOrigin = '5'
+)
+
+func (o MessageOrigin) String() string {
+	switch o {
+	case OriginAcquirer:
+		return "Acquirer"
+	case OriginAcquirerRepeat:
+		return "Acquirer Repeat"
+	case OriginIssuer:
+		return "Issuer"
+	case OriginIssuerRepeat:
+		return "Issuer Repeat"
+	case OriginOther:
+		return "Other"
+	case OriginOtherRepeat:
+		return "Other Repeat"
+	}
+
+	return "Reserved by ISO"
+}
+
+// MTI is the parsed Message Type Indicator. Use ParseMTI to create it from
+// the four-digit string.
+type MTI struct {
+	Version  MTIVersion
+	Class    MessageClass
+	Function MessageFunction
+	Origin   MessageOrigin
+}
+
+// ParseMTI parses the four-digit MTI and validates its digits against the
+// ISO 8583 tables. Digits of the MTI with national (8) or private (9)
+// version are not validated, as their meaning is defined by the network.
+func ParseMTI(s string) (MTI, error) {
+	if len(s) != 4 {
+		return MTI{}, fmt.Errorf("invalid MTI %q: expected 4 digits", s)
+	}
+
+	for i := range len(s) {
+		if s[i] < '0' || s[i] > '9' {
+			return MTI{}, fmt.Errorf("invalid MTI %q: expected 4 digits", s)
+		}
+	}
+
+	mti := MTI{
+		Version:  MTIVersion(s[0]),
+		Class:    MessageClass(s[1]),
+		Function: MessageFunction(s[2]),
+		Origin:   MessageOrigin(s[3]),
+	}
+
+	if err := mti.validate(); err != nil {
+		return MTI{}, fmt.Errorf("invalid MTI %s: %w", s, err)
+	}
+
+	return mti, nil
+}
+
+func (m MTI) validate() error {
+	switch m.Version {
+	case MTIVersion1987, MTIVersion1993, MTIVersion2003:
+	case MTIVersionNational, MTIVersionPrivate:
+		return nil
+	default:
+		return fmt.Errorf("version %c is reserved by ISO", m.Version)
+	}
+
+	if m.Class < ClassAuthorization || m.Class > ClassNetworkManagement {
+		return fmt.Errorf("message class %c is reserved by ISO", m.Class)
+	}
+
+	if (m.Function == FunctionInstruction || m.Function == FunctionInstructionAck) && m.Version != MTIVersion2003 {
+		return fmt.Errorf("message function %c is not defined in %s", m.Function, m.Version)
+	}
+
+	if m.Origin > OriginOtherRepeat {
+		return fmt.Errorf("message origin %c is reserved by ISO", m.Origin)
+	}
+
+	return nil
+}
+
+// String returns the four-digit representation of the MTI.
+func (m MTI) String() string {
+	return string([]byte{byte(m.Version), byte(m.Class), byte(m.Function), byte(m.Origin)})
+}
+
+// IsRequest returns true if the message is a request.
+func (m MTI) IsRequest() bool {
+	return m.Function == FunctionRequest
+}
+
+// IsResponse returns true if the message is a response to a request or an
+// advice.
+func (m MTI) IsResponse() bool {
+	return m.Function == FunctionRequestResponse || m.Function == FunctionAdviceResponse
+}
+
+// IsAdvice returns true if the message is an advice.
+func (m MTI) IsAdvice() bool {
+	return m.Function == FunctionAdvice
+}
+
+// IsNotification returns true if the message is a notification.
+func (m MTI) IsNotification() bool {
+	return m.Function == FunctionNotification
+}
+
+// IsInstruction returns true if the message is an instruction.
+func (m MTI) IsInstruction() bool {
+	return m.Function == FunctionInstruction
+}
+
+// IsAcknowledgement returns true if the message acknowledges a notification,
+// an instruction or a response.
+func (m MTI) IsAcknowledgement() bool {
+	switch m.Function { //nolint:exhaustive
+	case FunctionNotificationAck, FunctionInstructionAck, FunctionResponseAck, FunctionResponseNegativeAck:
+		return true
+	}
+
+	return false
+}
+
+// IsRepeat returns true if the message is a repeat of the previously sent
+// message (e.g. 0121 or 0401).
+func (m MTI) IsRepeat() bool {
+	return m.Origin == OriginAcquirerRepeat || m.Origin == OriginIssuerRepeat || m.Origin == OriginOtherRepeat
+}
+
+// IsReversal returns true if the message belongs to the reversal (or
+// chargeback) message class.
+func (m MTI) IsReversal() bool {
+	return m.Class == ClassReversal
+}
+
+// IsAcquirerOriginated returns true if the message was sent by the acquirer.
+func (m MTI) IsAcquirerOriginated() bool {
+	return m.Origin == OriginAcquirer || m.Origin == OriginAcquirerRepeat
+}
+
+// IsIssuerOriginated returns true if the message was sent by the issuer.
+func (m MTI) IsIssuerOriginated() bool {
+	return m.Origin == OriginIssuer || m.Origin == OriginIssuerRepeat
+}
+
+// expectsResponse returns true if the message function has a matching
+// response or acknowledgement function.
+func (m MTI) expectsResponse() bool {
+	switch m.Function { //nolint:exhaustive
+	case FunctionRequest, FunctionAdvice, FunctionNotification, FunctionInstruction:
+		return true
+	}
+
+	return false
+}
+
+// ResponseMTI returns the MTI of the response to the message: request ->
+// request response, advice -> advice response, notification -> notification
+// acknowledgement and instruction -> instruction acknowledgement. The repeat
+// flag of the origin is cleared, so the response to 0121 is 0130.
+func (m MTI) ResponseMTI() (MTI, error) {
+	if !m.expectsResponse() {
+		return MTI{}, fmt.Errorf("MTI %s is not a request, advice, notification or instruction and has no response", m)
+	}
+
+	res := m
+	res.Function = m.Function + 1
+
+	if m.IsRepeat() {
+		res.Origin = m.Origin - 1
+	}
+
+	return res, nil
+}
+
+// RepeatMTI returns the MTI of the repeat of the message, e.g. 0121 for 0120.
+func (m MTI) RepeatMTI() (MTI, error) {
+	if m.IsRepeat() {
+		return MTI{}, fmt.Errorf("MTI %s is already a repeat", m)
+	}
+
+	if !m.expectsResponse() {
+		return MTI{}, fmt.Errorf("MTI %s is not a request, advice, notification or instruction and cannot be repeated", m)
+	}
+
+	if m.Origin > OriginOther {
+		return MTI{}, fmt.Errorf("MTI %s has no repeat origin", m)
+	}
+
+	res := m
+	res.Origin = m.Origin + 1
+
+	return res, nil
+}
+
+// GetParsedMTI returns the parsed MTI of the message. It returns an error if
+// the MTI is not set or is not valid.
+func (m *Message) GetParsedMTI() (MTI, error) {
+	mti, err := m.GetMTI()
+	if err != nil {
+		return MTI{}, err
+	}
+
+	return ParseMTI(mti)
+}
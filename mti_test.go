@@ -0,0 +1,160 @@
+package iso8583
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMTI(t *testing.T) {
+	t.Run("parses MTI digits", func(t *testing.T) {
+		mti, err := ParseMTI("1421")
+		require.NoError(t, err)
+
+		require.Equal(t, MTIVersion1993, mti.Version)
+		require.Equal(t, ClassReversal, mti.Class)
+		require.Equal(t, FunctionAdvice, mti.Function)
+		require.Equal(t, OriginAcquirerRepeat, mti.Origin)
+		require.Equal(t, "1421", mti.String())
+
+		require.Equal(t, "ISO 8583:1993", mti.Version.String())
+		require.Equal(t, "Reversal or Chargeback", mti.Class.String())
+		require.Equal(t, "Advice", mti.Function.String())
+		require.Equal(t, "Acquirer Repeat", mti.Origin.String())
+
+		require.True(t, mti.IsAdvice())
+		require.True(t, mti.IsRepeat())
+		require.True(t, mti.IsReversal())
+		require.True(t, mti.IsAcquirerOriginated())
+		require.False(t, mti.IsRequest())
+		require.False(t, mti.IsResponse())
+		require.False(t, mti.IsIssuerOriginated())
+	})
+
+	t.Run("parses constants", func(t *testing.T) {
+		for _, c := range []MesssageTypeIndicator{
+			AuthorizationRequest,
+			AuthorizationPositiveAcknowledgement,
+			AuthorizationNegativeAcknowledgement,
+			AcquirerReversalAdvice,
+			NetworkManagementAdvice,
+		} {
+			mti, err := c.Parse()
+			require.NoError(t, err)
+			require.Equal(t, string(c), mti.String())
+		}
+
+		mti, err := AuthorizationNegativeAcknowledgement.Parse()
+		require.NoError(t, err)
+		require.True(t, mti.IsAcknowledgement())
+	})
+
+	t.Run("validates MTI against ISO 8583 tables", func(t *testing.T) {
+		tests := map[string]string{
+			"010":   `invalid MTI "010": expected 4 digits`,
+			"01A0":  `invalid MTI "01A0": expected 4 digits`,
+			"3100":  "invalid MTI 3100: version 3 is reserved by ISO",
+			"0000":  "invalid MTI 0000: message class 0 is reserved by ISO",
+			"1900":  "invalid MTI 1900: message class 9 is reserved by ISO",
+			"0160":  "invalid MTI 0160: message function 6 is not defined in ISO 8583:1987",
+			"0106":  "invalid MTI 0106: message origin 6 is reserved by ISO",
+			"01000": `invalid MTI "01000": expected 4 digits`,
+		}
+
+		for mti, expectedErr := range tests {
+			_, err := ParseMTI(mti)
+			require.EqualError(t, err, expectedErr)
+		}
+
+		// instructions are defined in ISO 8583:2003
+		_, err := ParseMTI("2160")
+		require.NoError(t, err)
+
+		// national and private versions are not validated
+		_, err = ParseMTI("9999")
+		require.NoError(t, err)
+	})
+}
+
+func TestMTIResponseMTI(t *testing.T) {
+	tests := map[string]string{
+		"0100": "0110",
+		"0101": "0110",
+		"0120": "0130",
+		"0121": "0130",
+		"0200": "0210",
+		"0402": "0412",
+		"0420": "0430",
+		"0421": "0430",
+		"0604": "0614",
+		"0605": "0614",
+		"0800": "0810",
+		"0820": "0830",
+		"1100": "1110",
+		"1240": "1250",
+		"1423": "1432",
+		"2160": "2170",
+	}
+
+	for req, expected := range tests {
+		mti, err := ParseMTI(req)
+		require.NoError(t, err)
+
+		res, err := mti.ResponseMTI()
+		require.NoError(t, err)
+		require.Equal(t, expected, res.String(), "response for %s", req)
+	}
+
+	for _, req := range []string{"0110", "0130", "0180", "1250"} {
+		mti, err := ParseMTI(req)
+		require.NoError(t, err)
+
+		_, err = mti.ResponseMTI()
+		require.Error(t, err, "response for %s", req)
+	}
+}
+
+func TestMTIRepeatMTI(t *testing.T) {
+	tests := map[string]string{
+		"0100": "0101",
+		"0120": "0121",
+		"0420": "0421",
+		"0522": "0523",
+		"0604": "0605",
+	}
+
+	for req, expected := range tests {
+		mti, err := ParseMTI(req)
+		require.NoError(t, err)
+
+		repeat, err := mti.RepeatMTI()
+		require.NoError(t, err)
+		require.Equal(t, expected, repeat.String(), "repeat for %s", req)
+		require.True(t, repeat.IsRepeat())
+	}
+
+	mti, err := ParseMTI("0121")
+	require.NoError(t, err)
+
+	_, err = mti.RepeatMTI()
+	require.EqualError(t, err, "MTI 0121 is already a repeat")
+
+	mti, err = ParseMTI("0110")
+	require.NoError(t, err)
+
+	_, err = mti.RepeatMTI()
+	require.EqualError(t, err, "MTI 0110 is not a request, advice, notification or instruction and cannot be repeated")
+}
+
+func TestMessageGetParsedMTI(t *testing.T) {
+	message := NewMessage(Spec87)
+	message.MTI("0200")
+
+	mti, err := message.GetParsedMTI()
+	require.NoError(t, err)
+	require.Equal(t, ClassFinancial, mti.Class)
+	require.True(t, mti.IsRequest())
+
+	_, err = NewMessage(Spec87).GetParsedMTI()
+	require.EqualError(t, err, `invalid MTI "": expected 4 digits`)
+}
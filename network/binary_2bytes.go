@@ -7,6 +7,8 @@ import (
 	"math"
 )
 
+var _ CheckedHeader = (*Binary2Bytes)(nil)
+
 type Binary2Bytes struct {
 	Len uint16
 }
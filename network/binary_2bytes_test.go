@@ -2,6 +2,7 @@ package network
 
 import (
 	"bytes"
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -11,7 +12,7 @@ func TestBinary2BytesHeader(t *testing.T) {
 	t.Run("Pack returns binary encoded length", func(t *testing.T) {
 		header := NewBinary2BytesHeader()
 
-		header.SetLength(319)
+		require.NoError(t, header.SetLength(319))
 		var buf bytes.Buffer
 		n, err := header.WriteTo(&buf)
 
@@ -32,4 +33,12 @@ func TestBinary2BytesHeader(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, 319, header.Length())
 	})
+
+	t.Run("SetLength returns error when length exceeds max length of 2 bytes", func(t *testing.T) {
+		header := NewBinary2BytesHeader()
+
+		err := header.SetLength(math.MaxUint16 + 1)
+
+		require.EqualError(t, err, "length 65536 exceeds max length for 2 bytes header 65535")
+	})
 }
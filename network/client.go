@@ -0,0 +1,324 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+
+	"github.com/moov-io/iso8583"
+	iso8583errors "github.com/moov-io/iso8583/errors"
+)
+
+// ErrClientClosed is returned by the client methods when the client is
+// closed.
+var ErrClientClosed = errors.New("client is closed")
+
+// MatchKeyFunc returns the key used to match responses with requests. It's
+// called for the outgoing request and for the inbound response, so the key
+// of the request must be equal to the key of its response.
+type MatchKeyFunc func(message *iso8583.Message) (string, error)
+
+// InboundHandler handles inbound messages that do not match any pending
+// request, e.g. network management requests sent by the host. The client can
+// be used to write the reply.
+type InboundHandler func(client *Client, message *iso8583.Message)
+
+// DefaultMatchKey returns the key that consists of the MTI of the response,
+// STAN (field 11) and Terminal ID (field 41). For requests, advices,
+// notifications and instructions the MTI of their response is used, so the
+// key of the request is equal to the key of its response.
+func DefaultMatchKey(message *iso8583.Message) (string, error) {
+	mti, err := message.GetParsedMTI()
+	if err != nil {
+		return "", fmt.Errorf("getting MTI: %w", err)
+	}
+
+	if resMTI, err := mti.ResponseMTI(); err == nil {
+		mti = resMTI
+	}
+
+	stan, err := message.GetString(11)
+	if err != nil {
+		return "", fmt.Errorf("getting STAN: %w", err)
+	}
+
+	tid, err := message.GetString(41)
+	if err != nil {
+		return "", fmt.Errorf("getting terminal ID: %w", err)
+	}
+
+	return mti.String() + "|" + stan + "|" + tid, nil
+}
+
+type clientOptions struct {
+	matchKey       MatchKeyFunc
+	inboundHandler InboundHandler
+	errorHandler   func(err error)
+	newFramer      func(rw io.ReadWriter) Framer
+}
+
+// ClientOption configures the client created by NewClient.
+type ClientOption func(*clientOptions)
+
+// WithMatchKey sets the function that returns the key used to match
+// responses with requests instead of DefaultMatchKey.
+func WithMatchKey(matchKey MatchKeyFunc) ClientOption {
+	return func(o *clientOptions) {
+		o.matchKey = matchKey
+	}
+}
+
+// WithInboundHandler sets the handler of inbound messages that do not match
+// any pending request. The handler is called in its own goroutine.
+func WithInboundHandler(handler InboundHandler) ClientOption {
+	return func(o *clientOptions) {
+		o.inboundHandler = handler
+	}
+}
+
+// WithErrorHandler sets the handler of errors that happen while reading
+// messages from the connection and do not close the client, e.g. errors of
+// unpacking or matching of inbound messages.
+func WithErrorHandler(handler func(err error)) ClientOption {
+	return func(o *clientOptions) {
+		o.errorHandler = handler
+	}
+}
+
+// WithFramer sets the factory of the framer that reads and writes messages
+// instead of framing them with the network header, e.g. for STX/ETX links.
+// The header factory passed to NewClient is not used and can be nil.
+func WithFramer(newFramer func(rw io.ReadWriter) Framer) ClientOption {
+	return func(o *clientOptions) {
+		o.newFramer = newFramer
+	}
+}
+
+// Client sends messages over one connection and matches inbound responses
+// with the sent requests. It's safe to send messages from multiple
+// goroutines concurrently. Each message is framed with the network header
+// created by the header factory.
+type Client struct {
+	conn   net.Conn
+	reader *MessageReader
+	writer *MessageWriter
+	opts   clientOptions
+
+	mu      sync.Mutex
+	pending map[string]chan *iso8583.Message
+	err     error
+
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewClient creates a client that uses the connection and starts reading
+// messages from it. Messages are unpacked using the spec. The client owns
+// the connection and closes it when it's closed or when reading from the
+// connection fails.
+func NewClient(conn net.Conn, spec *iso8583.MessageSpec, newHeader func() Header, opts ...ClientOption) *Client {
+	options := clientOptions{
+		matchKey: DefaultMatchKey,
+	}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	c := &Client{
+		conn:    conn,
+		opts:    options,
+		pending: make(map[string]chan *iso8583.Message),
+		done:    make(chan struct{}),
+	}
+
+	if options.newFramer != nil {
+		framer := options.newFramer(conn)
+		c.reader = NewFramedMessageReader(framer, spec)
+		c.writer = NewFramedMessageWriter(framer)
+	} else {
+		c.reader = NewMessageReader(conn, newHeader, spec)
+		c.writer = NewMessageWriter(conn, newHeader)
+	}
+
+	go c.readLoop()
+
+	return c
+}
+
+// Send writes the request and waits for the matching response. It returns
+// the context error when the context is done before the response is
+// received, so context.WithTimeout can be used to set the timeout of the
+// request.
+func (c *Client) Send(ctx context.Context, request *iso8583.Message) (*iso8583.Message, error) {
+	key, err := c.opts.matchKey(request)
+	if err != nil {
+		return nil, fmt.Errorf("getting match key of request: %w", err)
+	}
+
+	// buffered, so the read loop never blocks on delivery
+	responseCh := make(chan *iso8583.Message, 1)
+
+	c.mu.Lock()
+	if c.err != nil {
+		err := c.err
+		c.mu.Unlock()
+		return nil, err
+	}
+	if _, found := c.pending[key]; found {
+		c.mu.Unlock()
+		return nil, fmt.Errorf("request with match key %s is already pending", key)
+	}
+	c.pending[key] = responseCh
+	c.mu.Unlock()
+
+	if err := c.Write(request); err != nil {
+		c.removePending(key)
+		return nil, err
+	}
+
+	select {
+	case response := <-responseCh:
+		return response, nil
+	case <-ctx.Done():
+		c.removePending(key)
+		return nil, ctx.Err()
+	case <-c.done:
+		return nil, c.closeErr()
+	}
+}
+
+// Write writes the message without waiting for a response, e.g. a reply to
+// an inbound request.
+func (c *Client) Write(message *iso8583.Message) error {
+	select {
+	case <-c.done:
+		return c.closeErr()
+	default:
+	}
+
+	return c.writer.WriteMessage(message)
+}
+
+// Close closes the client and its connection. Pending requests return
+// ErrClientClosed.
+func (c *Client) Close() error {
+	return c.close(ErrClientClosed)
+}
+
+// Done returns the channel that is closed when the client is closed.
+func (c *Client) Done() <-chan struct{} {
+	return c.done
+}
+
+// Err returns the error the client was closed with: ErrClientClosed when it
+// was closed by Close or the error of reading from the connection. It
+// returns nil if the client is not closed.
+func (c *Client) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.err
+}
+
+func (c *Client) close(cause error) error {
+	var err error
+
+	c.closeOnce.Do(func() {
+		c.mu.Lock()
+		c.err = cause
+		c.mu.Unlock()
+
+		close(c.done)
+		err = c.conn.Close()
+	})
+
+	return err
+}
+
+func (c *Client) closeErr() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.err
+}
+
+func (c *Client) removePending(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.pending, key)
+}
+
+func (c *Client) readLoop() {
+	for {
+		message, _, err := c.reader.ReadMessage()
+		var unpackErr *iso8583errors.UnpackError
+
+		switch {
+		case errors.Is(err, ErrSessionControl):
+			continue
+		case errors.As(err, &unpackErr), errors.Is(err, ErrInvalidLRC):
+			c.handleError(err)
+			continue
+		case err != nil:
+			c.close(err)
+			return
+		}
+
+		c.dispatch(message)
+	}
+}
+
+// dispatch delivers the inbound message to the pending request it responds
+// to or to the inbound handler.
+func (c *Client) dispatch(message *iso8583.Message) {
+	if isResponse(message) {
+		key, err := c.opts.matchKey(message)
+		if err != nil {
+			c.handleError(fmt.Errorf("getting match key of inbound message: %w", err))
+			return
+		}
+
+		c.mu.Lock()
+		responseCh, found := c.pending[key]
+		delete(c.pending, key)
+		c.mu.Unlock()
+
+		if found {
+			responseCh <- message
+			return
+		}
+	}
+
+	if c.opts.inboundHandler == nil {
+		c.handleError(fmt.Errorf("no pending request or inbound handler for inbound message"))
+		return
+	}
+
+	go c.opts.inboundHandler(c, message)
+}
+
+func (c *Client) handleError(err error) {
+	if c.opts.errorHandler != nil {
+		c.opts.errorHandler(err)
+	}
+}
+
+// isResponse returns false for messages that expect a response themselves
+// (requests, advices, notifications and instructions), so they are never
+// matched with pending requests. Messages with an MTI that can't be parsed
+// are matched using the match key only.
+func isResponse(message *iso8583.Message) bool {
+	mti, err := message.GetParsedMTI()
+	if err != nil {
+		return true
+	}
+
+	_, err = mti.ResponseMTI()
+
+	return err != nil
+}
@@ -0,0 +1,262 @@
+package network
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/moov-io/iso8583"
+	"github.com/stretchr/testify/require"
+)
+
+// testHost is the other side of the connection that reads and writes
+// messages framed with the BCD2BytesHeader.
+type testHost struct {
+	t    *testing.T
+	conn net.Conn
+}
+
+func (h *testHost) read() *iso8583.Message {
+	header := NewBCD2BytesHeader()
+	_, err := header.ReadFrom(h.conn)
+	require.NoError(h.t, err)
+
+	raw := make([]byte, header.Length())
+	_, err = io.ReadFull(h.conn, raw)
+	require.NoError(h.t, err)
+
+	message := iso8583.NewMessage(iso8583.Spec87)
+	require.NoError(h.t, message.Unpack(raw))
+
+	return message
+}
+
+func (h *testHost) write(message *iso8583.Message) {
+	packed, err := message.Pack()
+	require.NoError(h.t, err)
+
+	header := NewBCD2BytesHeader()
+	header.SetLength(len(packed))
+	_, err = header.WriteTo(h.conn)
+	require.NoError(h.t, err)
+
+	_, err = h.conn.Write(packed)
+	require.NoError(h.t, err)
+}
+
+func (h *testHost) respond(request *iso8583.Message, responseCode string) {
+	response, err := iso8583.NewResponse(request)
+	require.NoError(h.t, err)
+	require.NoError(h.t, response.Field(39, responseCode))
+
+	h.write(response)
+}
+
+func newTestClient(t *testing.T, opts ...ClientOption) (*Client, *testHost) {
+	t.Helper()
+
+	clientConn, hostConn := net.Pipe()
+
+	client := NewClient(clientConn, iso8583.Spec87, func() Header {
+		return NewBCD2BytesHeader()
+	}, opts...)
+
+	t.Cleanup(func() {
+		client.Close()
+		hostConn.Close()
+	})
+
+	return client, &testHost{t: t, conn: hostConn}
+}
+
+func newTestRequest(t *testing.T, mti, stan string) *iso8583.Message {
+	t.Helper()
+
+	message := iso8583.NewMessage(iso8583.Spec87)
+	message.MTI(mti)
+	require.NoError(t, message.Field(11, stan))
+	require.NoError(t, message.Field(41, "TERM0001"))
+
+	return message
+}
+
+func TestClient(t *testing.T) {
+	t.Run("Send matches responses received in any order with requests", func(t *testing.T) {
+		client, host := newTestClient(t)
+
+		go func() {
+			first := host.read()
+			second := host.read()
+
+			// respond in reverse order with response code equal to STAN suffix
+			for _, request := range []*iso8583.Message{second, first} {
+				stan, _ := request.GetString(11)
+				host.respond(request, stan[4:])
+			}
+		}()
+
+		type result struct {
+			stan, responseCode string
+		}
+		results := make(chan result, 2)
+
+		for _, stan := range []string{"000001", "000002"} {
+			go func() {
+				response, err := client.Send(context.Background(), newTestRequest(t, "0100", stan))
+				require.NoError(t, err)
+
+				responseCode, err := response.GetString(39)
+				require.NoError(t, err)
+
+				results <- result{stan: stan, responseCode: responseCode}
+			}()
+		}
+
+		for range 2 {
+			res := <-results
+			require.Equal(t, res.stan[4:], res.responseCode)
+		}
+	})
+
+	t.Run("Send returns context error when response is not received in time", func(t *testing.T) {
+		client, host := newTestClient(t)
+
+		go host.read()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		_, err := client.Send(ctx, newTestRequest(t, "0100", "000001"))
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+
+		client.mu.Lock()
+		require.Empty(t, client.pending)
+		client.mu.Unlock()
+	})
+
+	t.Run("Send returns error when request with the same key is pending", func(t *testing.T) {
+		client, host := newTestClient(t)
+
+		received := make(chan *iso8583.Message)
+		go func() {
+			received <- host.read()
+		}()
+
+		responseCh := make(chan *iso8583.Message)
+		go func() {
+			response, err := client.Send(context.Background(), newTestRequest(t, "0100", "000001"))
+			require.NoError(t, err)
+			responseCh <- response
+		}()
+
+		request := <-received
+
+		_, err := client.Send(context.Background(), newTestRequest(t, "0100", "000001"))
+		require.EqualError(t, err, "request with match key 0110|000001|TERM0001 is already pending")
+
+		host.respond(request, "00")
+		require.NotNil(t, <-responseCh)
+	})
+
+	t.Run("unmatched inbound messages are delivered to the inbound handler", func(t *testing.T) {
+		handler := func(client *Client, message *iso8583.Message) {
+			response, err := iso8583.NewResponse(message)
+			require.NoError(t, err)
+			require.NoError(t, response.Field(39, "00"))
+			require.NoError(t, client.Write(response))
+		}
+
+		_, host := newTestClient(t, WithInboundHandler(handler))
+
+		echo := newTestRequest(t, "0800", "000001")
+		require.NoError(t, echo.Field(70, "301"))
+		host.write(echo)
+
+		response := host.read()
+
+		mti, err := response.GetMTI()
+		require.NoError(t, err)
+		require.Equal(t, "0810", mti)
+
+		responseCode, err := response.GetString(39)
+		require.NoError(t, err)
+		require.Equal(t, "00", responseCode)
+	})
+
+	t.Run("inbound requests are not matched with pending requests", func(t *testing.T) {
+		inbound := make(chan *iso8583.Message, 1)
+		client, host := newTestClient(t, WithInboundHandler(func(_ *Client, message *iso8583.Message) {
+			inbound <- message
+		}))
+
+		go func() {
+			request := host.read()
+
+			// request with the key of the pending request
+			host.write(newTestRequest(t, "0100", "000001"))
+			host.respond(request, "00")
+		}()
+
+		response, err := client.Send(context.Background(), newTestRequest(t, "0100", "000001"))
+		require.NoError(t, err)
+
+		mti, err := response.GetMTI()
+		require.NoError(t, err)
+		require.Equal(t, "0110", mti)
+
+		mti, err = (<-inbound).GetMTI()
+		require.NoError(t, err)
+		require.Equal(t, "0100", mti)
+	})
+
+	t.Run("custom match key", func(t *testing.T) {
+		matchKey := func(message *iso8583.Message) (string, error) {
+			return message.GetString(11)
+		}
+
+		client, host := newTestClient(t, WithMatchKey(matchKey))
+
+		go func() {
+			host.read()
+
+			response := newTestRequest(t, "0110", "000001")
+			// terminal ID is not used by the custom key
+			require.NoError(t, response.Field(41, "TERM0002"))
+
+			host.write(response)
+		}()
+
+		response, err := client.Send(context.Background(), newTestRequest(t, "0100", "000001"))
+		require.NoError(t, err)
+
+		tid, err := response.GetString(41)
+		require.NoError(t, err)
+		require.Equal(t, "TERM0002", tid)
+	})
+
+	t.Run("pending requests return error when client is closed", func(t *testing.T) {
+		client, host := newTestClient(t)
+
+		go func() {
+			host.read()
+			client.Close()
+		}()
+
+		_, err := client.Send(context.Background(), newTestRequest(t, "0100", "000001"))
+		require.ErrorIs(t, err, ErrClientClosed)
+
+		_, err = client.Send(context.Background(), newTestRequest(t, "0100", "000002"))
+		require.ErrorIs(t, err, ErrClientClosed)
+	})
+
+	t.Run("client is closed when connection is closed", func(t *testing.T) {
+		client, host := newTestClient(t)
+
+		host.conn.Close()
+
+		<-client.Done()
+		require.ErrorIs(t, client.Err(), io.EOF)
+	})
+}
@@ -10,45 +10,29 @@ Header is the interface for network header
 All messages between client/server have a message length header. In some cases
 it can be a 4 bytes ASCII or 2 bytes BCD encoded length.
 
-Here is how you can use it to read message from net.Conn:
+Use MessageReader to read messages framed with the header from net.Conn. It
+reads the whole frame even if it arrives in several packets:
 
 	func handleRequest(conn net.Conn) {
-		header := network.NewBCD2BytesHeader()
-		_, err := header.ReadFrom(conn)
-		if err != nil {
-			fmt.Printf("Reading header: %w\n", err)
-		}
+		reader := network.NewMessageReader(conn, func() network.Header {
+			return network.NewBCD2BytesHeader()
+		}, iso8583.Spec87)
 
-		// Make a buffer to hold message
-		buf := make([]byte, header.Length())
-		// Read the incoming connection into the buffer.
-		read, err := conn.Read(buf)
+		message, _, err := reader.ReadMessage()
 		if err != nil {
-			fmt.Println("Error reading:", err.Error())
+			// handle error
 		}
-		if reqLen != header.Length() {
-			fmt.Println("Expected to read %d bytes, read %d bytes", header.Length(), read)
-		}
-
-		message := iso8583.NewMessage(iso8583.Spec87)
-		message.Unpack(buf)
 
 		// work with the message
 	}
 
-This is how you can write header into the net.Conn:
+This is how you can write messages framed with the header into the net.Conn:
 
-	header := network.NewBCD2BytesHeader()
-	packed, err := message.Pack()
-	if err != nil {
-		// handle error
-	}
-	header.SetLength(len(packed))
-	_, err = header.WriteTo(conn)
-	if err != nil {
-		// handle error
-	}
-	n, err := conn.Write(packed)
+	writer := network.NewMessageWriter(conn, func() network.Header {
+		return network.NewBCD2BytesHeader()
+	})
+
+	err := writer.WriteMessage(message)
 	if err != nil {
 		// handle error
 	}
@@ -69,3 +53,106 @@ type Header interface {
 	// Length returns the length of the message
 	Length() int
 }
+
+// CheckedHeader is the header whose SetLength returns an error when the
+// length doesn't fit into the header, like VMLH and Binary2Bytes. Use
+// NewCheckedHeader to use it where Header is expected.
+type CheckedHeader interface {
+	// WriteTo encoded length into Writer
+	WriteTo(w io.Writer) (int, error)
+
+	// ReadFrom reads header (N bytes) from the Reader
+	ReadFrom(r io.Reader) (int, error)
+
+	// SetLength sets the length of the message or returns an error if it
+	// doesn't fit into the header
+	SetLength(length int) error
+
+	// Length returns the length of the message
+	Length() int
+}
+
+// NewCheckedHeader adapts the CheckedHeader to Header, so it can be used by
+// MessageReader, MessageWriter, STXFramer, Client and Server. The error of
+// SetLength is returned when the message is written:
+//
+//	writer := network.NewMessageWriter(conn, func() network.Header {
+//		return network.NewCheckedHeader(network.NewVMLHeader())
+//	})
+func NewCheckedHeader(header CheckedHeader) Header {
+	return &checkedHeader{header: header}
+}
+
+// checkedHeader is the Header of the CheckedHeader. It reports the session
+// control messages if the CheckedHeader does.
+type checkedHeader struct {
+	header CheckedHeader
+
+	// err is the error of the last SetLength call
+	err error
+}
+
+var _ SessionControlHeader = (*checkedHeader)(nil)
+
+func (h *checkedHeader) WriteTo(w io.Writer) (int, error) {
+	if h.err != nil {
+		return 0, h.err
+	}
+
+	return h.header.WriteTo(w)
+}
+
+func (h *checkedHeader) ReadFrom(r io.Reader) (int, error) {
+	return h.header.ReadFrom(r)
+}
+
+// SetLength sets the length of the message. The error of SetLength of the
+// CheckedHeader is returned by WriteTo.
+func (h *checkedHeader) SetLength(length int) {
+	h.err = h.header.SetLength(length)
+}
+
+func (h *checkedHeader) Length() int {
+	return h.header.Length()
+}
+
+func (h *checkedHeader) SessionControl() bool {
+	sc, ok := h.header.(interface{ SessionControl() bool })
+
+	return ok && sc.SessionControl()
+}
+
+// setLength sets the length of the message in the header and returns the
+// error of SetLength of the CheckedHeader.
+func setLength(header Header, length int) error {
+	if h, ok := header.(*checkedHeader); ok {
+		h.SetLength(length)
+		return h.err
+	}
+
+	header.SetLength(length)
+
+	return nil
+}
+
+// SessionControlHeader is the header that can mark the frame as a session
+// control message (e.g. heartbeat) instead of an ISO 8583 message, like
+// VMLH adapted by NewCheckedHeader does.
+type SessionControlHeader interface {
+	Header
+
+	// SessionControl returns true if the frame is a session control
+	// message
+	SessionControl() bool
+}
+
+// RequestHeader is the header that defines the header of the response to the
+// message, like TPDUHeader does. Server writes responses with the header
+// returned by ResponseHeader instead of the one created by the header
+// factory.
+type RequestHeader interface {
+	Header
+
+	// ResponseHeader returns the header of the response to the message
+	ResponseHeader() Header
+}
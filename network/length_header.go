@@ -0,0 +1,268 @@
+package network
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+
+	"github.com/moov-io/iso8583/encoding"
+)
+
+var _ Header = (*LengthHeader)(nil)
+
+// LengthEncoding defines how the length is encoded in the LengthHeader.
+type LengthEncoding int
+
+const (
+	// LengthBinaryBigEndian encodes the length as unsigned big-endian
+	// integer, e.g. {0x00, 0x73} for 115 in 2 bytes.
+	LengthBinaryBigEndian LengthEncoding = iota
+	// LengthBinaryLittleEndian encodes the length as unsigned little-endian
+	// integer, e.g. {0x73, 0x00} for 115 in 2 bytes.
+	LengthBinaryLittleEndian
+	// LengthASCII encodes the length as ASCII decimal digits, e.g. "0115"
+	// for 115 in 4 bytes.
+	LengthASCII
+	// LengthBCD encodes the length as BCD digits, e.g. {0x01, 0x15} for 115
+	// in 2 bytes.
+	LengthBCD
+	// LengthEBCDIC encodes the length as EBCDIC decimal digits, e.g.
+	// {0xF0, 0xF1, 0xF1, 0xF5} for 115 in 4 bytes.
+	LengthEBCDIC
+	// LengthASCIIHex encodes the length as ASCII hex digits, e.g. "0073"
+	// for 115 in 4 bytes.
+	LengthASCIIHex
+)
+
+// LengthHeaderSpec defines the layout of the LengthHeader.
+type LengthHeaderSpec struct {
+	// Width is the number of bytes of the encoded length. Binary lengths
+	// can't be wider than 8 bytes.
+	Width int
+	// Encoding defines how the length is encoded.
+	Encoding LengthEncoding
+	// IncludesHeader is true when the encoded length includes the length of
+	// the header itself (Width and Trailer) in addition to the length of the
+	// message.
+	IncludesHeader bool
+	// Trailer defines fixed bytes written after the length, e.g. reserved
+	// bytes. The same number of bytes is skipped when the header is read.
+	Trailer []byte
+}
+
+// LengthHeader is the network header with the layout defined by the spec.
+// It can be used for the headers that have no dedicated type, e.g. 4 bytes
+// big-endian binary or 6 bytes EBCDIC length:
+//
+//	newHeader := func() network.Header {
+//		return network.NewLengthHeader(network.LengthHeaderSpec{
+//			Width:    4,
+//			Encoding: network.LengthBinaryBigEndian,
+//		})
+//	}
+type LengthHeader struct {
+	Len int
+
+	spec LengthHeaderSpec
+}
+
+// NewLengthHeader creates the header with the layout defined by the spec.
+func NewLengthHeader(spec LengthHeaderSpec) *LengthHeader {
+	return &LengthHeader{
+		spec: spec,
+	}
+}
+
+func (h *LengthHeader) SetLength(length int) {
+	h.Len = length
+}
+
+func (h *LengthHeader) Length() int {
+	return h.Len
+}
+
+func (h *LengthHeader) WriteTo(w io.Writer) (int, error) {
+	length := h.Len
+	if h.spec.IncludesHeader {
+		length += h.size()
+	}
+
+	encoded, err := h.encodeLength(length)
+	if err != nil {
+		return 0, fmt.Errorf("encoding length: %w", err)
+	}
+
+	n, err := w.Write(append(encoded, h.spec.Trailer...))
+	if err != nil {
+		return 0, fmt.Errorf("writing header: %w", err)
+	}
+
+	return n, nil
+}
+
+func (h *LengthHeader) ReadFrom(r io.Reader) (int, error) {
+	if h.spec.Width <= 0 {
+		return 0, fmt.Errorf("invalid header width %d", h.spec.Width)
+	}
+
+	buf := make([]byte, h.size())
+	read, err := io.ReadFull(r, buf)
+	if err != nil {
+		return 0, fmt.Errorf("reading header: %w", err)
+	}
+
+	length, err := h.decodeLength(buf[:h.spec.Width])
+	if err != nil {
+		return 0, fmt.Errorf("decoding length: %w", err)
+	}
+
+	if h.spec.IncludesHeader {
+		length -= h.size()
+
+		if length < 0 {
+			return 0, fmt.Errorf("length %d is less than header length %d", length+h.size(), h.size())
+		}
+	}
+
+	h.Len = length
+
+	return read, nil
+}
+
+// size returns the number of bytes of the header.
+func (h *LengthHeader) size() int {
+	return h.spec.Width + len(h.spec.Trailer)
+}
+
+func (h *LengthHeader) encodeLength(length int) ([]byte, error) {
+	width := h.spec.Width
+
+	if width <= 0 {
+		return nil, fmt.Errorf("invalid header width %d", width)
+	}
+
+	if length < 0 {
+		return nil, fmt.Errorf("invalid length %d", length)
+	}
+
+	switch h.spec.Encoding {
+	case LengthBinaryBigEndian, LengthBinaryLittleEndian:
+		if width > 8 {
+			return nil, fmt.Errorf("binary length can't be wider than 8 bytes, got %d", width)
+		}
+
+		if width < 8 && uint64(length) >= 1<<(8*width) {
+			return nil, fmt.Errorf("length %d exceeds max length for %d bytes header", length, width)
+		}
+
+		buf := make([]byte, 8)
+		if h.spec.Encoding == LengthBinaryLittleEndian {
+			binary.LittleEndian.PutUint64(buf, uint64(length))
+			return buf[:width], nil
+		}
+
+		binary.BigEndian.PutUint64(buf, uint64(length))
+		return buf[8-width:], nil
+
+	case LengthASCII, LengthEBCDIC, LengthBCD:
+		digits := width
+		if h.spec.Encoding == LengthBCD {
+			digits = width * 2
+		}
+
+		encoded := fmt.Sprintf("%0*d", digits, length)
+		if len(encoded) > digits {
+			return nil, fmt.Errorf("length %d exceeds max length for %d digits header", length, digits)
+		}
+
+		switch h.spec.Encoding { //nolint:exhaustive
+		case LengthEBCDIC:
+			return encoding.EBCDIC.Encode([]byte(encoded))
+		case LengthBCD:
+			return encoding.BCD.Encode([]byte(encoded))
+		}
+
+		return []byte(encoded), nil
+
+	case LengthASCIIHex:
+		encoded := fmt.Sprintf("%0*X", width, length)
+		if len(encoded) > width {
+			return nil, fmt.Errorf("length %d exceeds max length for %d hex digits header", length, width)
+		}
+
+		return []byte(encoded), nil
+	}
+
+	return nil, fmt.Errorf("unknown length encoding %d", h.spec.Encoding)
+}
+
+func (h *LengthHeader) decodeLength(data []byte) (int, error) {
+	var digits []byte
+
+	switch h.spec.Encoding {
+	case LengthBinaryBigEndian, LengthBinaryLittleEndian:
+		if len(data) > 8 {
+			return 0, fmt.Errorf("binary length can't be wider than 8 bytes, got %d", len(data))
+		}
+
+		buf := make([]byte, 8)
+
+		var length uint64
+		if h.spec.Encoding == LengthBinaryLittleEndian {
+			copy(buf, data)
+			length = binary.LittleEndian.Uint64(buf)
+		} else {
+			copy(buf[8-len(data):], data)
+			length = binary.BigEndian.Uint64(buf)
+		}
+
+		if length > math.MaxInt32 {
+			return 0, fmt.Errorf("length %d is too large", length)
+		}
+
+		return int(length), nil
+
+	case LengthASCIIHex:
+		length, err := strconv.ParseUint(string(data), 16, 31)
+		if err != nil {
+			return 0, fmt.Errorf("parsing hex digits: %w", err)
+		}
+
+		return int(length), nil
+
+	case LengthASCII:
+		digits = data
+
+	case LengthEBCDIC:
+		decoded, _, err := encoding.EBCDIC.Decode(data, len(data))
+		if err != nil {
+			return 0, err
+		}
+		digits = decoded
+
+	case LengthBCD:
+		decoded, _, err := encoding.BCD.Decode(data, len(data)*2)
+		if err != nil {
+			return 0, err
+		}
+		digits = decoded
+
+	default:
+		return 0, fmt.Errorf("unknown length encoding %d", h.spec.Encoding)
+	}
+
+	for _, d := range digits {
+		if d < '0' || d > '9' {
+			return 0, fmt.Errorf("length contains non-digit characters")
+		}
+	}
+
+	length, err := strconv.Atoi(string(digits))
+	if err != nil {
+		return 0, fmt.Errorf("converting length to int: %w", err)
+	}
+
+	return length, nil
+}
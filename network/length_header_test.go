@@ -0,0 +1,115 @@
+package network
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLengthHeader(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    LengthHeaderSpec
+		encoded []byte
+	}{
+		{
+			name:    "4 bytes big-endian binary",
+			spec:    LengthHeaderSpec{Width: 4, Encoding: LengthBinaryBigEndian},
+			encoded: []byte{0x00, 0x00, 0x00, 0x73},
+		},
+		{
+			name:    "2 bytes little-endian binary",
+			spec:    LengthHeaderSpec{Width: 2, Encoding: LengthBinaryLittleEndian},
+			encoded: []byte{0x73, 0x00},
+		},
+		{
+			name:    "4 bytes ASCII including header",
+			spec:    LengthHeaderSpec{Width: 4, Encoding: LengthASCII, IncludesHeader: true},
+			encoded: []byte("0119"),
+		},
+		{
+			name:    "2 bytes BCD",
+			spec:    LengthHeaderSpec{Width: 2, Encoding: LengthBCD},
+			encoded: []byte{0x01, 0x15},
+		},
+		{
+			name:    "6 bytes EBCDIC",
+			spec:    LengthHeaderSpec{Width: 6, Encoding: LengthEBCDIC},
+			encoded: []byte{0xF0, 0xF0, 0xF0, 0xF1, 0xF1, 0xF5},
+		},
+		{
+			name:    "4 bytes ASCII hex",
+			spec:    LengthHeaderSpec{Width: 4, Encoding: LengthASCIIHex},
+			encoded: []byte("0073"),
+		},
+		{
+			name:    "2 bytes binary with reserved bytes including header",
+			spec:    LengthHeaderSpec{Width: 2, Encoding: LengthBinaryBigEndian, IncludesHeader: true, Trailer: []byte{0x00, 0x00}},
+			encoded: []byte{0x00, 0x77, 0x00, 0x00},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := NewLengthHeader(tt.spec)
+			header.SetLength(115)
+
+			var buf bytes.Buffer
+			n, err := header.WriteTo(&buf)
+			require.NoError(t, err)
+			require.Equal(t, len(tt.encoded), n)
+			require.Equal(t, tt.encoded, buf.Bytes())
+
+			header = NewLengthHeader(tt.spec)
+			read, err := header.ReadFrom(bytes.NewReader(tt.encoded))
+			require.NoError(t, err)
+			require.Equal(t, len(tt.encoded), read)
+			require.Equal(t, 115, header.Length())
+		})
+	}
+
+	t.Run("WriteTo returns error when length exceeds max length of the header", func(t *testing.T) {
+		for _, spec := range []LengthHeaderSpec{
+			{Width: 1, Encoding: LengthBinaryBigEndian},
+			{Width: 2, Encoding: LengthASCII},
+			{Width: 1, Encoding: LengthBCD},
+			{Width: 1, Encoding: LengthASCIIHex},
+		} {
+			header := NewLengthHeader(spec)
+			header.SetLength(256)
+
+			_, err := header.WriteTo(&bytes.Buffer{})
+			require.Error(t, err)
+		}
+	})
+
+	t.Run("WriteTo returns error when binary length is wider than 8 bytes", func(t *testing.T) {
+		header := NewLengthHeader(LengthHeaderSpec{Width: 9, Encoding: LengthBinaryBigEndian})
+		header.SetLength(115)
+
+		_, err := header.WriteTo(&bytes.Buffer{})
+		require.EqualError(t, err, "encoding length: binary length can't be wider than 8 bytes, got 9")
+	})
+
+	t.Run("ReadFrom returns error when length is not a number", func(t *testing.T) {
+		header := NewLengthHeader(LengthHeaderSpec{Width: 4, Encoding: LengthASCII})
+
+		_, err := header.ReadFrom(bytes.NewReader([]byte("-115")))
+		require.EqualError(t, err, "decoding length: length contains non-digit characters")
+	})
+
+	t.Run("ReadFrom returns error when length does not include the header", func(t *testing.T) {
+		header := NewLengthHeader(LengthHeaderSpec{Width: 4, Encoding: LengthASCII, IncludesHeader: true})
+
+		_, err := header.ReadFrom(bytes.NewReader([]byte("0003")))
+		require.EqualError(t, err, "length 3 is less than header length 4")
+	})
+
+	t.Run("ReadFrom returns error when header is truncated", func(t *testing.T) {
+		header := NewLengthHeader(LengthHeaderSpec{Width: 4, Encoding: LengthBinaryBigEndian})
+
+		_, err := header.ReadFrom(bytes.NewReader([]byte{0x00, 0x00}))
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,137 @@
+package network
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/moov-io/iso8583"
+)
+
+// DefaultMaxFrameSize is the default max length of the frame read by
+// MessageReader.
+const DefaultMaxFrameSize = 64 * 1024
+
+var (
+	// ErrSessionControl is returned by MessageReader.ReadMessage when the
+	// frame is a session control message (e.g. VMLH heartbeat) and not an
+	// ISO 8583 message. The reader can be used to read the next message.
+	ErrSessionControl = errors.New("session control message")
+
+	// ErrFrameTooLarge is returned by MessageReader.ReadMessage when the
+	// length of the frame exceeds the max frame size.
+	ErrFrameTooLarge = errors.New("frame is too large")
+)
+
+// MessageReaderOption configures the reader created by NewMessageReader.
+type MessageReaderOption func(*MessageReader)
+
+// WithMaxFrameSize sets the max length of the frame instead of
+// DefaultMaxFrameSize.
+func WithMaxFrameSize(size int) MessageReaderOption {
+	return func(r *MessageReader) {
+		r.maxFrameSize = size
+	}
+}
+
+// MessageReader reads messages framed with the network header (or by the
+// framer) from the reader.
+type MessageReader struct {
+	r            io.Reader
+	newHeader    func() Header
+	frames       FrameReader
+	spec         *iso8583.MessageSpec
+	maxFrameSize int
+
+	lastHeader Header
+}
+
+// NewMessageReader creates a reader of messages framed with the headers
+// created by the header factory. Messages are unpacked using the spec.
+func NewMessageReader(r io.Reader, newHeader func() Header, spec *iso8583.MessageSpec, opts ...MessageReaderOption) *MessageReader {
+	reader := &MessageReader{
+		r:            r,
+		newHeader:    newHeader,
+		spec:         spec,
+		maxFrameSize: DefaultMaxFrameSize,
+	}
+
+	for _, opt := range opts {
+		opt(reader)
+	}
+
+	return reader
+}
+
+// NewFramedMessageReader creates a reader of messages read by the frame
+// reader, e.g. by STXFramer. Messages are unpacked using the spec. Frames
+// have no network header, so LastHeader returns nil.
+func NewFramedMessageReader(frames FrameReader, spec *iso8583.MessageSpec) *MessageReader {
+	return &MessageReader{
+		frames: frames,
+		spec:   spec,
+	}
+}
+
+// ReadMessage reads the next frame and returns the unpacked message and its
+// raw bytes (without the header). The whole frame is read even if it's
+// split by the underlying reader.
+//
+// When the frame is read, but the message can't be unpacked, the raw bytes
+// are returned with the error that wraps *errors.UnpackError. For session
+// control frames, ErrSessionControl is returned with the raw bytes of the
+// frame. In both cases the next message can still be read. Other errors
+// mean that the stream can't be read further.
+func (r *MessageReader) ReadMessage() (*iso8583.Message, []byte, error) {
+	raw, err := r.ReadFrame()
+	if err != nil {
+		return nil, raw, err
+	}
+
+	message := iso8583.NewMessage(r.spec)
+	if err := message.Unpack(raw); err != nil {
+		return nil, raw, fmt.Errorf("unpacking message: %w", err)
+	}
+
+	return message, raw, nil
+}
+
+// ReadFrame reads the next frame and returns the raw message without
+// unpacking it. For session control frames, ErrSessionControl is returned
+// with the raw bytes of the frame.
+func (r *MessageReader) ReadFrame() ([]byte, error) {
+	if r.frames != nil {
+		return r.frames.ReadFrame()
+	}
+
+	header := r.newHeader()
+
+	if _, err := header.ReadFrom(r.r); err != nil {
+		return nil, fmt.Errorf("reading header: %w", err)
+	}
+
+	length := header.Length()
+	if length < 0 || length > r.maxFrameSize {
+		return nil, fmt.Errorf("%w: length %d exceeds max frame size %d", ErrFrameTooLarge, length, r.maxFrameSize)
+	}
+
+	raw := make([]byte, length)
+	if _, err := io.ReadFull(r.r, raw); err != nil {
+		return nil, fmt.Errorf("reading %d bytes of message: %w", length, err)
+	}
+
+	r.lastHeader = header
+
+	if sc, ok := header.(SessionControlHeader); ok && sc.SessionControl() {
+		return raw, ErrSessionControl
+	}
+
+	return raw, nil
+}
+
+// LastHeader returns the header of the last frame read by ReadMessage, e.g.
+// to get the TPDU of the message. Headers of the frames that failed to read
+// are not returned. It returns nil if nothing was read.
+func (r *MessageReader) LastHeader() Header {
+	return r.lastHeader
+}
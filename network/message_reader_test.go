@@ -0,0 +1,148 @@
+package network
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"testing/iotest"
+
+	"github.com/moov-io/iso8583"
+	iso8583errors "github.com/moov-io/iso8583/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func packTestMessage(t *testing.T, mti string) []byte {
+	t.Helper()
+
+	packed, err := newTestRequest(t, mti, "000001").Pack()
+	require.NoError(t, err)
+
+	return packed
+}
+
+func TestMessageReader(t *testing.T) {
+	t.Run("ReadMessage reads messages split into several reads", func(t *testing.T) {
+		var buf bytes.Buffer
+		writer := NewMessageWriter(&buf, newBCD2BytesHeader)
+
+		require.NoError(t, writer.WriteMessage(newTestRequest(t, "0100", "000001")))
+		require.NoError(t, writer.WriteMessage(newTestRequest(t, "0800", "000002")))
+
+		// reader returns one byte per Read call
+		reader := NewMessageReader(iotest.OneByteReader(&buf), newBCD2BytesHeader, iso8583.Spec87)
+
+		message, raw, err := reader.ReadMessage()
+		require.NoError(t, err)
+		require.Equal(t, packTestMessage(t, "0100"), raw)
+
+		mti, err := message.GetMTI()
+		require.NoError(t, err)
+		require.Equal(t, "0100", mti)
+
+		message, _, err = reader.ReadMessage()
+		require.NoError(t, err)
+
+		stan, err := message.GetString(11)
+		require.NoError(t, err)
+		require.Equal(t, "000002", stan)
+
+		_, _, err = reader.ReadMessage()
+		require.ErrorIs(t, err, io.EOF)
+	})
+
+	t.Run("ReadMessage returns error when frame is truncated", func(t *testing.T) {
+		packed := packTestMessage(t, "0100")
+
+		// length of the whole message, but only part of it is sent
+		frame := append([]byte(fmt.Sprintf("%04d", len(packed))), packed[:10]...)
+
+		reader := NewMessageReader(bytes.NewReader(frame), func() Header {
+			return NewASCII4BytesHeader()
+		}, iso8583.Spec87)
+
+		_, _, err := reader.ReadMessage()
+		require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	})
+
+	t.Run("LastHeader returns header of the last frame that was read", func(t *testing.T) {
+		packed := packTestMessage(t, "0100")
+
+		var buf bytes.Buffer
+		buf.WriteString(fmt.Sprintf("%04d", len(packed)))
+		buf.Write(packed)
+		// the next frame is truncated
+		buf.WriteString(fmt.Sprintf("%04d", len(packed)))
+		buf.Write(packed[:10])
+
+		reader := NewMessageReader(&buf, func() Header {
+			return NewASCII4BytesHeader()
+		}, iso8583.Spec87)
+		require.Nil(t, reader.LastHeader())
+
+		_, _, err := reader.ReadMessage()
+		require.NoError(t, err)
+
+		header := reader.LastHeader()
+		require.NotNil(t, header)
+
+		_, _, err = reader.ReadMessage()
+		require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+		require.Same(t, header, reader.LastHeader())
+	})
+
+	t.Run("ReadMessage returns error when frame exceeds max frame size", func(t *testing.T) {
+		reader := NewMessageReader(bytes.NewReader([]byte("9999")), func() Header {
+			return NewASCII4BytesHeader()
+		}, iso8583.Spec87, WithMaxFrameSize(1024))
+
+		_, _, err := reader.ReadMessage()
+		require.ErrorIs(t, err, ErrFrameTooLarge)
+		require.EqualError(t, err, "frame is too large: length 9999 exceeds max frame size 1024")
+	})
+
+	t.Run("ReadMessage returns raw message and unpack error", func(t *testing.T) {
+		var buf bytes.Buffer
+		writer := NewMessageWriter(&buf, newBCD2BytesHeader)
+
+		require.NoError(t, writer.WriteFrame([]byte("01X0")))
+		require.NoError(t, writer.WriteMessage(newTestRequest(t, "0100", "000001")))
+
+		reader := NewMessageReader(&buf, newBCD2BytesHeader, iso8583.Spec87)
+
+		_, raw, err := reader.ReadMessage()
+		require.Equal(t, []byte("01X0"), raw)
+
+		var unpackErr *iso8583errors.UnpackError
+		require.True(t, errors.As(err, &unpackErr))
+
+		// next message can be read
+		message, _, err := reader.ReadMessage()
+		require.NoError(t, err)
+		require.NotNil(t, message)
+	})
+
+	t.Run("ReadMessage returns ErrSessionControl for VMLH session control messages", func(t *testing.T) {
+		packed := packTestMessage(t, "0100")
+
+		var buf bytes.Buffer
+		// heartbeat without data followed by the message
+		buf.Write([]byte{0x00, 0x00, 0x00, 0x20})
+		buf.Write([]byte{0x00, byte(len(packed)), 0x00, 0x00})
+		buf.Write(packed)
+
+		reader := NewMessageReader(&buf, func() Header {
+			return NewCheckedHeader(NewVMLHeader())
+		}, iso8583.Spec87)
+
+		message, raw, err := reader.ReadMessage()
+		require.ErrorIs(t, err, ErrSessionControl)
+		require.Nil(t, message)
+		require.Empty(t, raw)
+
+		message, _, err = reader.ReadMessage()
+		require.NoError(t, err)
+		require.NotNil(t, message)
+	})
+}
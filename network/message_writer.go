@@ -0,0 +1,109 @@
+package network
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/moov-io/iso8583"
+)
+
+// ErrHeaderNotSupported is returned by MessageWriter.WriteMessageWithHeader
+// when the writer frames messages with the framer instead of the network
+// header.
+var ErrHeaderNotSupported = errors.New("header is not supported by framed writer")
+
+// MessageWriter writes messages framed with the network header (or by the
+// framer) into the writer. It's safe to write messages from multiple
+// goroutines concurrently.
+type MessageWriter struct {
+	w         io.Writer
+	newHeader func() Header
+	frames    FrameWriter
+
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+// NewMessageWriter creates a writer of messages framed with the headers
+// created by the header factory.
+func NewMessageWriter(w io.Writer, newHeader func() Header) *MessageWriter {
+	return &MessageWriter{
+		w:         w,
+		newHeader: newHeader,
+	}
+}
+
+// NewFramedMessageWriter creates a writer of messages framed by the frame
+// writer, e.g. by STXFramer.
+func NewFramedMessageWriter(frames FrameWriter) *MessageWriter {
+	return &MessageWriter{
+		frames: frames,
+	}
+}
+
+// WriteMessage packs the message and writes it with the header.
+func (w *MessageWriter) WriteMessage(message *iso8583.Message) error {
+	packed, err := message.Pack()
+	if err != nil {
+		return fmt.Errorf("packing message: %w", err)
+	}
+
+	return w.WriteFrame(packed)
+}
+
+// WriteMessageWithHeader packs the message and writes it with the given
+// header instead of the one created by the header factory, e.g. with the
+// header of the response to the request. The length of the header is set
+// by the writer.
+func (w *MessageWriter) WriteMessageWithHeader(header Header, message *iso8583.Message) error {
+	if w.frames != nil {
+		return ErrHeaderNotSupported
+	}
+
+	packed, err := message.Pack()
+	if err != nil {
+		return fmt.Errorf("packing message: %w", err)
+	}
+
+	return w.writeFrame(header, packed)
+}
+
+// WriteFrame writes the packed message with the header. The header and the
+// message are written with a single Write call, so frames written
+// concurrently do not interleave.
+func (w *MessageWriter) WriteFrame(packed []byte) error {
+	if w.frames != nil {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+
+		return w.frames.WriteFrame(packed)
+	}
+
+	return w.writeFrame(w.newHeader(), packed)
+}
+
+func (w *MessageWriter) writeFrame(header Header, packed []byte) error {
+	if err := setLength(header, len(packed)); err != nil {
+		return fmt.Errorf("setting length: %w", err)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf.Reset()
+
+	if _, err := header.WriteTo(&w.buf); err != nil {
+		return fmt.Errorf("writing header: %w", err)
+	}
+
+	w.buf.Write(packed)
+
+	if _, err := w.w.Write(w.buf.Bytes()); err != nil {
+		return fmt.Errorf("writing message: %w", err)
+	}
+
+	return nil
+}
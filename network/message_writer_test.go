@@ -0,0 +1,88 @@
+package network
+
+import (
+	"bytes"
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/moov-io/iso8583"
+	"github.com/stretchr/testify/require"
+)
+
+// writeRecorder records the data of each Write call
+type writeRecorder struct {
+	mu     sync.Mutex
+	writes [][]byte
+}
+
+func (r *writeRecorder) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.writes = append(r.writes, bytes.Clone(p))
+
+	return len(p), nil
+}
+
+func TestMessageWriter(t *testing.T) {
+	t.Run("WriteMessage writes header and message with single Write call", func(t *testing.T) {
+		recorder := &writeRecorder{}
+		writer := NewMessageWriter(recorder, func() Header {
+			return NewASCII4BytesHeader()
+		})
+
+		require.NoError(t, writer.WriteMessage(newTestRequest(t, "0100", "000001")))
+
+		packed := packTestMessage(t, "0100")
+
+		require.Len(t, recorder.writes, 1)
+		require.Equal(t, append([]byte("0034"), packed...), recorder.writes[0])
+	})
+
+	t.Run("frames written concurrently do not interleave", func(t *testing.T) {
+		var buf bytes.Buffer
+		writer := NewMessageWriter(&buf, newBCD2BytesHeader)
+
+		var wg sync.WaitGroup
+		for range 10 {
+			wg.Go(func() {
+				require.NoError(t, writer.WriteMessage(newTestRequest(t, "0100", "000001")))
+			})
+		}
+		wg.Wait()
+
+		reader := NewMessageReader(&buf, newBCD2BytesHeader, iso8583.Spec87)
+		for range 10 {
+			_, raw, err := reader.ReadMessage()
+			require.NoError(t, err)
+			require.Equal(t, packTestMessage(t, "0100"), raw)
+		}
+	})
+
+	t.Run("WriteFrame returns header error", func(t *testing.T) {
+		writer := NewMessageWriter(&bytes.Buffer{}, func() Header {
+			return NewCheckedHeader(NewVMLHeader())
+		})
+
+		err := writer.WriteFrame(make([]byte, MaxMessageLength+1))
+		require.EqualError(t, err, "writing header: length 2049 exceeds max length 2048")
+	})
+
+	t.Run("WriteFrame returns error of SetLength of checked header", func(t *testing.T) {
+		writer := NewMessageWriter(&bytes.Buffer{}, func() Header {
+			return NewCheckedHeader(NewBinary2BytesHeader())
+		})
+
+		err := writer.WriteFrame(make([]byte, math.MaxUint16+1))
+		require.EqualError(t, err, "setting length: length 65536 exceeds max length for 2 bytes header 65535")
+
+		var buf bytes.Buffer
+		writer = NewMessageWriter(&buf, func() Header {
+			return NewCheckedHeader(NewBinary2BytesHeader())
+		})
+
+		require.NoError(t, writer.WriteFrame([]byte("abc")))
+		require.Equal(t, []byte{0x00, 0x03, 'a', 'b', 'c'}, buf.Bytes())
+	})
+}
@@ -0,0 +1,91 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/moov-io/iso8583"
+)
+
+// LoggingMiddleware logs the handled messages and their responses using the
+// logger. Messages are described with iso8583.Describe, so sensitive fields
+// are redacted by the filters. If no filters are given,
+// iso8583.DefaultFilters are used.
+func LoggingMiddleware(logger *slog.Logger, filters ...iso8583.FieldFilter) Middleware {
+	if len(filters) == 0 {
+		filters = iso8583.DefaultFilters()
+	}
+
+	return func(next Handler) Handler {
+		return func(ctx context.Context, message *iso8583.Message) (*iso8583.Message, error) {
+			start := time.Now()
+
+			response, err := next(ctx, message)
+
+			attrs := []any{
+				slog.Duration("duration", time.Since(start)),
+				slog.String("message", describe(message, filters)),
+			}
+
+			if response != nil {
+				attrs = append(attrs, slog.String("response", describe(response, filters)))
+			}
+
+			if err != nil {
+				logger.ErrorContext(ctx, "handling message failed", append(attrs, slog.Any("error", err))...)
+			} else {
+				logger.InfoContext(ctx, "message handled", attrs...)
+			}
+
+			return response, err
+		}
+	}
+}
+
+func describe(message *iso8583.Message, filters []iso8583.FieldFilter) string {
+	var sb strings.Builder
+
+	if err := iso8583.Describe(message, &sb, filters...); err != nil {
+		return fmt.Sprintf("describing message: %v", err)
+	}
+
+	return sb.String()
+}
+
+// RecoveryMiddleware recovers from panics of the handler and returns them as
+// errors, so a failing handler does not crash the server.
+func RecoveryMiddleware() Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, message *iso8583.Message) (response *iso8583.Message, err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					response = nil
+					err = fmt.Errorf("handler panicked: %v", r)
+				}
+			}()
+
+			return next(ctx, message)
+		}
+	}
+}
+
+// MetricsMiddleware calls observe with the MTI of the handled message, the
+// duration of the handler and its error. It can be used to export metrics
+// to the monitoring system of choice.
+func MetricsMiddleware(observe func(mti string, duration time.Duration, err error)) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, message *iso8583.Message) (*iso8583.Message, error) {
+			start := time.Now()
+
+			response, err := next(ctx, message)
+
+			mti, _ := message.GetMTI()
+			observe(mti, time.Since(start), err)
+
+			return response, err
+		}
+	}
+}
@@ -0,0 +1,452 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/moov-io/iso8583"
+	iso8583errors "github.com/moov-io/iso8583/errors"
+)
+
+// ErrServerClosed is returned by Serve and ListenAndServe after Shutdown or
+// Close is called.
+var ErrServerClosed = errors.New("server is closed")
+
+// Handler handles the inbound message and returns the response that is
+// written back to the connection. If the response is nil, nothing is
+// written.
+type Handler func(ctx context.Context, message *iso8583.Message) (*iso8583.Message, error)
+
+// Middleware wraps the handler to add behavior before or after it, e.g.
+// logging or panic recovery.
+type Middleware func(next Handler) Handler
+
+// MatchFunc returns true if the handler should handle the message.
+type MatchFunc func(message *iso8583.Message) bool
+
+type headerContextKey struct{}
+
+// HeaderFromContext returns the network header of the message handled by
+// the server handler, e.g. to get the TPDU of the message. It returns nil if
+// the context is not the context of the handler.
+func HeaderFromContext(ctx context.Context) Header {
+	header, _ := ctx.Value(headerContextKey{}).(Header)
+
+	return header
+}
+
+type route struct {
+	match   MatchFunc
+	handler Handler
+}
+
+type serverOptions struct {
+	maxConnections int
+	defaultHandler Handler
+	errorHandler   func(err error)
+	newFramer      func(rw io.ReadWriter) Framer
+}
+
+// ServerOption configures the server created by NewServer.
+type ServerOption func(*serverOptions)
+
+// WithMaxConnections limits the number of connections served at the same
+// time. When the limit is reached, the server stops accepting new
+// connections until one of the served connections is closed.
+func WithMaxConnections(n int) ServerOption {
+	return func(o *serverOptions) {
+		o.maxConnections = n
+	}
+}
+
+// WithDefaultHandler sets the handler of messages that do not match any
+// registered handler. Without it such messages are reported to the error
+// handler and are not answered.
+func WithDefaultHandler(handler Handler) ServerOption {
+	return func(o *serverOptions) {
+		o.defaultHandler = handler
+	}
+}
+
+// WithServerFramer sets the factory of the framer that reads and writes
+// messages of the accepted connections instead of framing them with the
+// network header, e.g. for STX/ETX links. The header factory passed to
+// NewServer is not used and can be nil.
+func WithServerFramer(newFramer func(rw io.ReadWriter) Framer) ServerOption {
+	return func(o *serverOptions) {
+		o.newFramer = newFramer
+	}
+}
+
+// WithServerErrorHandler sets the handler of errors that happen while
+// serving connections, e.g. errors of reading, unpacking or handling of
+// messages.
+func WithServerErrorHandler(handler func(err error)) ServerOption {
+	return func(o *serverOptions) {
+		o.errorHandler = handler
+	}
+}
+
+// Server reads messages framed with the network header from the accepted
+// connections, dispatches them to the handlers registered by MTI or by
+// match function and writes the responses back using the same header type.
+// Messages of the same connection are handled concurrently.
+type Server struct {
+	spec      *iso8583.MessageSpec
+	newHeader func() Header
+	opts      serverOptions
+
+	mu          sync.Mutex
+	handlers    map[string]Handler
+	routes      []route
+	middlewares []Middleware
+	listeners   map[net.Listener]struct{}
+	conns       map[*serverConn]struct{}
+	closed      bool
+
+	// inFlight tracks connections and messages being handled
+	inFlight sync.WaitGroup
+}
+
+// NewServer creates a server that unpacks messages using the spec and frames
+// them with the headers created by the header factory.
+func NewServer(spec *iso8583.MessageSpec, newHeader func() Header, opts ...ServerOption) *Server {
+	options := serverOptions{}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return &Server{
+		spec:      spec,
+		newHeader: newHeader,
+		opts:      options,
+		handlers:  make(map[string]Handler),
+		listeners: make(map[net.Listener]struct{}),
+		conns:     make(map[*serverConn]struct{}),
+	}
+}
+
+// Handle registers the handler for messages with the MTI. Handlers
+// registered by MTI take precedence over handlers registered by match
+// function.
+func (s *Server) Handle(mti string, handler Handler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.handlers[mti] = handler
+}
+
+// HandleMatch registers the handler for messages the match function returns
+// true for. Match functions are checked in the order of registration.
+func (s *Server) HandleMatch(match MatchFunc, handler Handler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.routes = append(s.routes, route{match: match, handler: handler})
+}
+
+// Use adds middlewares that wrap all handlers. The first middleware is the
+// outermost one.
+func (s *Server) Use(middlewares ...Middleware) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.middlewares = append(s.middlewares, middlewares...)
+}
+
+// ListenAndServe listens on the TCP network address and serves the accepted
+// connections.
+func (s *Server) ListenAndServe(addr string) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("listening on %s: %w", addr, err)
+	}
+
+	return s.Serve(ln)
+}
+
+// Serve accepts connections on the listener and serves them. It always
+// returns a non-nil error and closes the listener. After Shutdown or Close
+// the returned error is ErrServerClosed.
+func (s *Server) Serve(ln net.Listener) error {
+	if !s.trackListener(ln) {
+		ln.Close()
+		return ErrServerClosed
+	}
+	defer s.untrackListener(ln)
+
+	var slots chan struct{}
+	if s.opts.maxConnections > 0 {
+		slots = make(chan struct{}, s.opts.maxConnections)
+	}
+
+	for {
+		if slots != nil {
+			slots <- struct{}{}
+		}
+
+		conn, err := ln.Accept()
+		if err != nil {
+			if s.isClosed() {
+				return ErrServerClosed
+			}
+
+			return fmt.Errorf("accepting connection: %w", err)
+		}
+
+		sc := &serverConn{
+			server: s,
+			conn:   conn,
+		}
+
+		if s.opts.newFramer != nil {
+			framer := s.opts.newFramer(conn)
+			sc.reader = NewFramedMessageReader(framer, s.spec)
+			sc.writer = NewFramedMessageWriter(framer)
+		} else {
+			sc.reader = NewMessageReader(conn, s.newHeader, s.spec)
+			sc.writer = NewMessageWriter(conn, s.newHeader)
+		}
+		if !s.trackConn(sc) {
+			conn.Close()
+			return ErrServerClosed
+		}
+
+		go func() {
+			defer func() {
+				if slots != nil {
+					<-slots
+				}
+			}()
+
+			sc.serve()
+		}()
+	}
+}
+
+// Shutdown gracefully shuts down the server: it closes the listeners, stops
+// reading new messages and waits for the messages being handled to be
+// answered before closing the connections. If the context is done before
+// that, the connections are closed and the context error is returned.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	s.closed = true
+	for ln := range s.listeners {
+		ln.Close()
+	}
+	// interrupt reading, so no new messages are handled
+	for sc := range s.conns {
+		_ = sc.conn.SetReadDeadline(time.Now())
+	}
+	s.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.inFlight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		s.Close()
+		return ctx.Err()
+	}
+}
+
+// Close immediately closes the listeners and all connections.
+func (s *Server) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+
+	var errs []error
+	for ln := range s.listeners {
+		errs = append(errs, ln.Close())
+	}
+	for sc := range s.conns {
+		errs = append(errs, sc.conn.Close())
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *Server) isClosed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.closed
+}
+
+func (s *Server) trackListener(ln net.Listener) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return false
+	}
+
+	s.listeners[ln] = struct{}{}
+
+	return true
+}
+
+func (s *Server) untrackListener(ln net.Listener) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.listeners, ln)
+}
+
+func (s *Server) trackConn(sc *serverConn) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return false
+	}
+
+	s.conns[sc] = struct{}{}
+	s.inFlight.Add(1)
+
+	return true
+}
+
+func (s *Server) untrackConn(sc *serverConn) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.conns, sc)
+}
+
+// handler returns the handler for the message wrapped with middlewares.
+func (s *Server) handler(message *iso8583.Message) (Handler, error) {
+	mti, err := message.GetMTI()
+	if err != nil {
+		return nil, fmt.Errorf("getting MTI: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	handler, found := s.handlers[mti]
+	if !found {
+		for _, r := range s.routes {
+			if r.match(message) {
+				handler = r.handler
+				break
+			}
+		}
+	}
+
+	if handler == nil {
+		handler = s.opts.defaultHandler
+	}
+
+	if handler == nil {
+		return nil, fmt.Errorf("no handler for MTI %s", mti)
+	}
+
+	for i := len(s.middlewares) - 1; i >= 0; i-- {
+		handler = s.middlewares[i](handler)
+	}
+
+	return handler, nil
+}
+
+func (s *Server) handleError(err error) {
+	if s.opts.errorHandler != nil {
+		s.opts.errorHandler(err)
+	}
+}
+
+// serverConn is the connection accepted by the server.
+type serverConn struct {
+	server *Server
+	conn   net.Conn
+	reader *MessageReader
+	writer *MessageWriter
+}
+
+func (sc *serverConn) serve() {
+	s := sc.server
+
+	var handling sync.WaitGroup
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	defer func() {
+		// answer messages being handled before closing the connection,
+		// the context of handlers is canceled only when they are done
+		handling.Wait()
+		cancel()
+		sc.conn.Close()
+		s.untrackConn(sc)
+		s.inFlight.Done()
+	}()
+
+	for {
+		message, _, err := sc.reader.ReadMessage()
+		var unpackErr *iso8583errors.UnpackError
+
+		switch {
+		case errors.Is(err, ErrSessionControl):
+			continue
+		case errors.As(err, &unpackErr), errors.Is(err, ErrInvalidLRC):
+			s.handleError(fmt.Errorf("reading message from %s: %w", sc.conn.RemoteAddr(), err))
+			continue
+		case err != nil:
+			if !errors.Is(err, io.EOF) && !s.isClosed() {
+				s.handleError(fmt.Errorf("reading message from %s: %w", sc.conn.RemoteAddr(), err))
+			}
+			return
+		}
+
+		header := sc.reader.LastHeader()
+
+		handling.Add(1)
+		go func() {
+			defer handling.Done()
+
+			if err := sc.handle(ctx, header, message); err != nil {
+				s.handleError(err)
+			}
+		}()
+	}
+}
+
+func (sc *serverConn) handle(ctx context.Context, header Header, message *iso8583.Message) error {
+	handler, err := sc.server.handler(message)
+	if err != nil {
+		return err
+	}
+
+	response, err := handler(context.WithValue(ctx, headerContextKey{}, header), message)
+	if err != nil {
+		return fmt.Errorf("handling message: %w", err)
+	}
+
+	if response == nil {
+		return nil
+	}
+
+	if requestHeader, ok := header.(RequestHeader); ok {
+		err = sc.writer.WriteMessageWithHeader(requestHeader.ResponseHeader(), response)
+	} else {
+		err = sc.writer.WriteMessage(response)
+	}
+
+	if err != nil {
+		return fmt.Errorf("writing response: %w", err)
+	}
+
+	return nil
+}
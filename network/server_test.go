@@ -0,0 +1,363 @@
+package network
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/moov-io/iso8583"
+	"github.com/stretchr/testify/require"
+)
+
+func newBCD2BytesHeader() Header {
+	return NewBCD2BytesHeader()
+}
+
+// startTestServer starts serving on the local port and returns its address
+// and the channel with the error returned by Serve.
+func startTestServer(t *testing.T, server *Server) (string, <-chan error) {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	served := make(chan error, 1)
+	go func() {
+		served <- server.Serve(ln)
+	}()
+
+	t.Cleanup(func() {
+		server.Close()
+	})
+
+	return ln.Addr().String(), served
+}
+
+func dialTestClient(t *testing.T, addr string) *Client {
+	t.Helper()
+
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+
+	client := NewClient(conn, iso8583.Spec87, newBCD2BytesHeader)
+	t.Cleanup(func() {
+		client.Close()
+	})
+
+	return client
+}
+
+func respondWith(responseCode string) Handler {
+	return func(_ context.Context, message *iso8583.Message) (*iso8583.Message, error) {
+		response, err := iso8583.NewResponse(message)
+		if err != nil {
+			return nil, err
+		}
+
+		return response, response.Field(39, responseCode)
+	}
+}
+
+func sendTestRequest(t *testing.T, client *Client, mti string) (*iso8583.Message, error) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	return client.Send(ctx, newTestRequest(t, mti, "000001"))
+}
+
+func requireResponseCode(t *testing.T, expected string, response *iso8583.Message) {
+	t.Helper()
+
+	responseCode, err := response.GetString(39)
+	require.NoError(t, err)
+	require.Equal(t, expected, responseCode)
+}
+
+func TestServer(t *testing.T) {
+	t.Run("routes messages by MTI and match function", func(t *testing.T) {
+		server := NewServer(iso8583.Spec87, newBCD2BytesHeader)
+
+		server.Handle("0100", respondWith("00"))
+		server.HandleMatch(func(message *iso8583.Message) bool {
+			mti, _ := message.GetParsedMTI()
+			return mti.IsReversal()
+		}, respondWith("01"))
+		// handlers registered by MTI take precedence over match functions
+		server.Handle("0400", respondWith("02"))
+
+		addr, _ := startTestServer(t, server)
+		client := dialTestClient(t, addr)
+
+		response, err := sendTestRequest(t, client, "0100")
+		require.NoError(t, err)
+		requireResponseCode(t, "00", response)
+
+		response, err = sendTestRequest(t, client, "0420")
+		require.NoError(t, err)
+		requireResponseCode(t, "01", response)
+
+		response, err = sendTestRequest(t, client, "0400")
+		require.NoError(t, err)
+		requireResponseCode(t, "02", response)
+	})
+
+	t.Run("reports messages without handler", func(t *testing.T) {
+		errs := make(chan error, 1)
+		server := NewServer(iso8583.Spec87, newBCD2BytesHeader, WithServerErrorHandler(func(err error) {
+			errs <- err
+		}))
+
+		addr, _ := startTestServer(t, server)
+		client := dialTestClient(t, addr)
+
+		require.NoError(t, client.Write(newTestRequest(t, "0800", "000001")))
+		require.EqualError(t, <-errs, "no handler for MTI 0800")
+	})
+
+	t.Run("default handler handles messages without handler", func(t *testing.T) {
+		server := NewServer(iso8583.Spec87, newBCD2BytesHeader, WithDefaultHandler(respondWith("12")))
+
+		addr, _ := startTestServer(t, server)
+		client := dialTestClient(t, addr)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		// field 41 is not echoed in the network management response
+		echo := iso8583.NewMessage(iso8583.Spec87)
+		echo.MTI("0800")
+		require.NoError(t, echo.Field(11, "000001"))
+		require.NoError(t, echo.Field(70, "301"))
+
+		response, err := client.Send(ctx, echo)
+		require.NoError(t, err)
+		requireResponseCode(t, "12", response)
+	})
+
+	t.Run("middlewares wrap handlers in order", func(t *testing.T) {
+		var mu sync.Mutex
+		var calls []string
+
+		middleware := func(name string) Middleware {
+			return func(next Handler) Handler {
+				return func(ctx context.Context, message *iso8583.Message) (*iso8583.Message, error) {
+					mu.Lock()
+					calls = append(calls, name)
+					mu.Unlock()
+
+					return next(ctx, message)
+				}
+			}
+		}
+
+		server := NewServer(iso8583.Spec87, newBCD2BytesHeader)
+		server.Use(middleware("first"), middleware("second"))
+		server.Handle("0100", respondWith("00"))
+
+		addr, _ := startTestServer(t, server)
+		client := dialTestClient(t, addr)
+
+		_, err := sendTestRequest(t, client, "0100")
+		require.NoError(t, err)
+
+		mu.Lock()
+		defer mu.Unlock()
+		require.Equal(t, []string{"first", "second"}, calls)
+	})
+
+	t.Run("graceful shutdown waits for messages being handled", func(t *testing.T) {
+		handling := make(chan struct{})
+		release := make(chan struct{})
+
+		server := NewServer(iso8583.Spec87, newBCD2BytesHeader)
+		server.Handle("0100", func(ctx context.Context, message *iso8583.Message) (*iso8583.Message, error) {
+			close(handling)
+			<-release
+
+			return respondWith("00")(ctx, message)
+		})
+
+		addr, served := startTestServer(t, server)
+		client := dialTestClient(t, addr)
+
+		responseCh := make(chan *iso8583.Message, 1)
+		go func() {
+			response, err := sendTestRequest(t, client, "0100")
+			require.NoError(t, err)
+			responseCh <- response
+		}()
+
+		<-handling
+
+		shutdown := make(chan error, 1)
+		go func() {
+			shutdown <- server.Shutdown(context.Background())
+		}()
+
+		require.ErrorIs(t, <-served, ErrServerClosed)
+
+		select {
+		case <-shutdown:
+			t.Fatal("shutdown returned before the message was handled")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		close(release)
+
+		require.NoError(t, <-shutdown)
+		requireResponseCode(t, "00", <-responseCh)
+
+		// connection is closed after shutdown
+		<-client.Done()
+	})
+
+	t.Run("context of messages being handled is not canceled by graceful shutdown", func(t *testing.T) {
+		handling := make(chan struct{})
+		release := make(chan struct{})
+		ctxErr := make(chan error, 1)
+
+		server := NewServer(iso8583.Spec87, newBCD2BytesHeader)
+		server.Handle("0100", func(ctx context.Context, message *iso8583.Message) (*iso8583.Message, error) {
+			close(handling)
+
+			select {
+			case <-release:
+			case <-ctx.Done():
+			}
+			ctxErr <- ctx.Err()
+
+			return respondWith("00")(ctx, message)
+		})
+
+		addr, _ := startTestServer(t, server)
+		client := dialTestClient(t, addr)
+
+		responseCh := make(chan *iso8583.Message, 1)
+		go func() {
+			response, err := sendTestRequest(t, client, "0100")
+			require.NoError(t, err)
+			responseCh <- response
+		}()
+
+		<-handling
+
+		shutdown := make(chan error, 1)
+		go func() {
+			shutdown <- server.Shutdown(context.Background())
+		}()
+
+		// give shutdown time to stop reading from the connection
+		time.Sleep(50 * time.Millisecond)
+		close(release)
+
+		require.NoError(t, <-ctxErr)
+		require.NoError(t, <-shutdown)
+		requireResponseCode(t, "00", <-responseCh)
+	})
+
+	t.Run("shutdown returns context error when messages are not handled in time", func(t *testing.T) {
+		handling := make(chan struct{})
+		release := make(chan struct{})
+		defer close(release)
+
+		server := NewServer(iso8583.Spec87, newBCD2BytesHeader)
+		server.Handle("0100", func(ctx context.Context, message *iso8583.Message) (*iso8583.Message, error) {
+			close(handling)
+			<-release
+
+			return nil, nil
+		})
+
+		addr, _ := startTestServer(t, server)
+		client := dialTestClient(t, addr)
+
+		require.NoError(t, client.Write(newTestRequest(t, "0100", "000001")))
+		<-handling
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		require.ErrorIs(t, server.Shutdown(ctx), context.DeadlineExceeded)
+		<-client.Done()
+	})
+
+	t.Run("limits number of connections", func(t *testing.T) {
+		server := NewServer(iso8583.Spec87, newBCD2BytesHeader, WithMaxConnections(1))
+		server.Handle("0100", respondWith("00"))
+
+		addr, _ := startTestServer(t, server)
+
+		first := dialTestClient(t, addr)
+		_, err := sendTestRequest(t, first, "0100")
+		require.NoError(t, err)
+
+		second := dialTestClient(t, addr)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		_, err = second.Send(ctx, newTestRequest(t, "0100", "000001"))
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+
+		// second connection is served when the first one is closed
+		first.Close()
+
+		response, err := sendTestRequest(t, second, "0100")
+		require.NoError(t, err)
+		requireResponseCode(t, "00", response)
+	})
+}
+
+func TestMiddlewares(t *testing.T) {
+	t.Run("LoggingMiddleware logs filtered messages", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+		handler := LoggingMiddleware(logger)(respondWith("00"))
+
+		request := newTestRequest(t, "0100", "000001")
+		require.NoError(t, request.Field(2, "4242424242424242"))
+
+		_, err := handler(context.Background(), request)
+		require.NoError(t, err)
+
+		require.Contains(t, buf.String(), "message handled")
+		require.Contains(t, buf.String(), "4242****4242")
+		require.NotContains(t, buf.String(), "4242424242424242")
+	})
+
+	t.Run("RecoveryMiddleware returns panic as error", func(t *testing.T) {
+		handler := RecoveryMiddleware()(func(context.Context, *iso8583.Message) (*iso8583.Message, error) {
+			panic("boom")
+		})
+
+		response, err := handler(context.Background(), newTestRequest(t, "0100", "000001"))
+		require.Nil(t, response)
+		require.EqualError(t, err, "handler panicked: boom")
+	})
+
+	t.Run("MetricsMiddleware observes handled messages", func(t *testing.T) {
+		var observedMTI string
+		var observedErr error
+
+		handlerErr := errors.New("handler error")
+		handler := MetricsMiddleware(func(mti string, duration time.Duration, err error) {
+			observedMTI = mti
+			observedErr = err
+		})(func(context.Context, *iso8583.Message) (*iso8583.Message, error) {
+			return nil, handlerErr
+		})
+
+		_, err := handler(context.Background(), newTestRequest(t, "0100", "000001"))
+		require.ErrorIs(t, err, handlerErr)
+		require.Equal(t, "0100", observedMTI)
+		require.ErrorIs(t, observedErr, handlerErr)
+	})
+}
@@ -0,0 +1,228 @@
+package network
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Control characters of the STX/ETX framing.
+const (
+	STX = 0x02
+	ETX = 0x03
+	ACK = 0x06
+	NAK = 0x15
+)
+
+// ErrInvalidLRC is returned by STXFramer.ReadFrame when the LRC of the frame
+// doesn't match its content.
+var ErrInvalidLRC = errors.New("invalid LRC")
+
+var _ Framer = (*STXFramer)(nil)
+
+// FrameReader reads the payloads of the frames, e.g. packed messages.
+type FrameReader interface {
+	// ReadFrame reads the next frame and returns its payload
+	ReadFrame() ([]byte, error)
+}
+
+// FrameWriter writes the payloads framed into the underlying writer.
+type FrameWriter interface {
+	// WriteFrame writes the payload as one frame
+	WriteFrame(payload []byte) error
+}
+
+// Framer reads and writes frames that are not framed with the length
+// header only, like STXFramer does. Use NewFramedMessageReader,
+// NewFramedMessageWriter, WithFramer and WithServerFramer to read and write
+// messages with the framer.
+type Framer interface {
+	FrameReader
+	FrameWriter
+}
+
+// lrc calculates the Longitudinal Redundancy Check (XOR of all bytes) of the
+// data written into it.
+type lrc byte
+
+func (l *lrc) Write(p []byte) (int, error) {
+	for _, b := range p {
+		*l ^= lrc(b)
+	}
+
+	return len(p), nil
+}
+
+type stxFramerOptions struct {
+	ackNak       bool
+	maxRetries   int
+	maxFrameSize int
+}
+
+// STXFramerOption configures the framer created by NewSTXFramer.
+type STXFramerOption func(*stxFramerOptions)
+
+// WithAckNak enables the acknowledgement of frames: the reader of the frame
+// replies with ACK when the frame is valid and with NAK when its LRC is
+// invalid. The writer of the frame waits for the reply and writes the frame
+// again on NAK up to maxRetries times. As the writer reads the reply from the
+// connection, frames must be read and written in turns, so acknowledgement
+// can't be used with Client and Server that read and write concurrently.
+func WithAckNak(maxRetries int) STXFramerOption {
+	return func(o *stxFramerOptions) {
+		o.ackNak = true
+		o.maxRetries = maxRetries
+	}
+}
+
+// WithSTXMaxFrameSize sets the max length of the frame payload instead of
+// DefaultMaxFrameSize.
+func WithSTXMaxFrameSize(size int) STXFramerOption {
+	return func(o *stxFramerOptions) {
+		o.maxFrameSize = size
+	}
+}
+
+// STXFramer reads and writes packed messages framed as STX, length, payload,
+// ETX and LRC, which is used by legacy terminals and ECR integrations. The
+// length is encoded by the length header created by the header factory. LRC
+// is the XOR of all bytes after STX up to and including ETX.
+type STXFramer struct {
+	rw              io.ReadWriter
+	newLengthHeader func() Header
+	opts            stxFramerOptions
+}
+
+// NewSTXFramer creates the framer that reads and writes frames using rw.
+func NewSTXFramer(rw io.ReadWriter, newLengthHeader func() Header, opts ...STXFramerOption) *STXFramer {
+	options := stxFramerOptions{
+		maxFrameSize: DefaultMaxFrameSize,
+	}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return &STXFramer{
+		rw:              rw,
+		newLengthHeader: newLengthHeader,
+		opts:            options,
+	}
+}
+
+// ReadFrame reads the next frame and returns its payload. When the LRC of the
+// frame is invalid, ErrInvalidLRC is returned (after NAK is written, if
+// enabled) and the next frame can still be read.
+func (f *STXFramer) ReadFrame() ([]byte, error) {
+	payload, err := f.readFrame()
+
+	if !f.opts.ackNak || (err != nil && !errors.Is(err, ErrInvalidLRC)) {
+		return payload, err
+	}
+
+	reply := []byte{ACK}
+	if err != nil {
+		reply[0] = NAK
+	}
+
+	if _, werr := f.rw.Write(reply); werr != nil {
+		return nil, fmt.Errorf("writing reply: %w", werr)
+	}
+
+	return payload, err
+}
+
+func (f *STXFramer) readFrame() ([]byte, error) {
+	control := make([]byte, 1)
+	if _, err := io.ReadFull(f.rw, control); err != nil {
+		return nil, fmt.Errorf("reading STX: %w", err)
+	}
+
+	if control[0] != STX {
+		return nil, fmt.Errorf("expected STX, got 0x%02X", control[0])
+	}
+
+	var sum lrc
+	r := io.TeeReader(f.rw, &sum)
+
+	header := f.newLengthHeader()
+	if _, err := header.ReadFrom(r); err != nil {
+		return nil, fmt.Errorf("reading length: %w", err)
+	}
+
+	length := header.Length()
+	if length < 0 || length > f.opts.maxFrameSize {
+		return nil, fmt.Errorf("%w: length %d exceeds max frame size %d", ErrFrameTooLarge, length, f.opts.maxFrameSize)
+	}
+
+	// payload followed by ETX and LRC
+	buf := make([]byte, length+2)
+	if _, err := io.ReadFull(f.rw, buf); err != nil {
+		return nil, fmt.Errorf("reading %d bytes of frame: %w", length, err)
+	}
+
+	payload, trailer := buf[:length], buf[length:]
+
+	if trailer[0] != ETX {
+		return nil, fmt.Errorf("expected ETX, got 0x%02X", trailer[0])
+	}
+
+	sum.Write(buf[:length+1])
+
+	if byte(sum) != trailer[1] {
+		return nil, fmt.Errorf("%w: expected 0x%02X, got 0x%02X", ErrInvalidLRC, byte(sum), trailer[1])
+	}
+
+	return payload, nil
+}
+
+// WriteFrame writes the payload framed with STX, length, ETX and LRC with a
+// single Write call. If acknowledgement is enabled, it waits for ACK and
+// writes the frame again on NAK.
+func (f *STXFramer) WriteFrame(payload []byte) error {
+	header := f.newLengthHeader()
+	if err := setLength(header, len(payload)); err != nil {
+		return fmt.Errorf("setting length: %w", err)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte(STX)
+
+	if _, err := header.WriteTo(&buf); err != nil {
+		return fmt.Errorf("writing length: %w", err)
+	}
+
+	buf.Write(payload)
+	buf.WriteByte(ETX)
+
+	var sum lrc
+	sum.Write(buf.Bytes()[1:])
+	buf.WriteByte(byte(sum))
+
+	for attempt := 0; ; attempt++ {
+		if _, err := f.rw.Write(buf.Bytes()); err != nil {
+			return fmt.Errorf("writing frame: %w", err)
+		}
+
+		if !f.opts.ackNak {
+			return nil
+		}
+
+		reply := make([]byte, 1)
+		if _, err := io.ReadFull(f.rw, reply); err != nil {
+			return fmt.Errorf("reading reply: %w", err)
+		}
+
+		switch reply[0] {
+		case ACK:
+			return nil
+		case NAK:
+			if attempt >= f.opts.maxRetries {
+				return fmt.Errorf("frame was rejected with NAK %d times", attempt+1)
+			}
+		default:
+			return fmt.Errorf("expected ACK or NAK, got 0x%02X", reply[0])
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package network
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"net"
+	"testing"
+
+	"github.com/moov-io/iso8583"
+	"github.com/stretchr/testify/require"
+)
+
+// duplex reads from in and writes into out
+type duplex struct {
+	io.Reader
+	io.Writer
+}
+
+func TestSTXFramer(t *testing.T) {
+	newLengthHeader := func() Header {
+		return NewBCD2BytesHeader()
+	}
+
+	// STX, length 0x0004, payload, ETX and LRC
+	// LRC = 0x00 ^ 0x04 ^ '0' ^ '8' ^ '0' ^ '0' ^ 0x03 = 0x0F
+	frame := []byte{STX, 0x00, 0x04, '0', '8', '0', '0', ETX, 0x0F}
+
+	t.Run("WriteFrame writes framed payload with LRC", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		require.NoError(t, NewSTXFramer(&buf, newLengthHeader).WriteFrame([]byte("0800")))
+		require.Equal(t, frame, buf.Bytes())
+	})
+
+	t.Run("WriteFrame returns error of SetLength of checked header", func(t *testing.T) {
+		newBinaryHeader := func() Header {
+			return NewCheckedHeader(NewBinary2BytesHeader())
+		}
+
+		var buf bytes.Buffer
+		require.NoError(t, NewSTXFramer(&buf, newBinaryHeader).WriteFrame([]byte("0800")))
+		require.Equal(t, frame, buf.Bytes())
+
+		err := NewSTXFramer(duplex{&bytes.Buffer{}, io.Discard}, newBinaryHeader).WriteFrame(make([]byte, math.MaxUint16+1))
+		require.EqualError(t, err, "setting length: length 65536 exceeds max length for 2 bytes header 65535")
+	})
+
+	t.Run("ReadFrame reads payload and validates frame", func(t *testing.T) {
+		payload, err := NewSTXFramer(bytes.NewBuffer(frame), newLengthHeader).ReadFrame()
+		require.NoError(t, err)
+		require.Equal(t, []byte("0800"), payload)
+	})
+
+	t.Run("ReadFrame returns error for invalid frames", func(t *testing.T) {
+		tests := []struct {
+			name        string
+			frame       []byte
+			expectedErr string
+		}{
+			{"no STX", []byte{0x00, 0x00, 0x04}, "expected STX, got 0x00"},
+			{"no ETX", []byte{STX, 0x00, 0x01, '0', 0x00, 0x00}, "expected ETX, got 0x00"},
+			{"invalid LRC", []byte{STX, 0x00, 0x04, '0', '8', '0', '0', ETX, 0x00}, "invalid LRC: expected 0x0F, got 0x00"},
+			{"truncated frame", frame[:5], "reading 4 bytes of frame: unexpected EOF"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := NewSTXFramer(bytes.NewBuffer(tt.frame), newLengthHeader).ReadFrame()
+				require.EqualError(t, err, tt.expectedErr)
+			})
+		}
+	})
+
+	t.Run("ReadFrame replies with ACK and NAK", func(t *testing.T) {
+		in := bytes.NewBuffer(nil)
+		in.Write([]byte{STX, 0x00, 0x04, '0', '8', '0', '0', ETX, 0x00})
+		in.Write(frame)
+
+		var out bytes.Buffer
+		framer := NewSTXFramer(duplex{in, &out}, newLengthHeader, WithAckNak(3))
+
+		_, err := framer.ReadFrame()
+		require.ErrorIs(t, err, ErrInvalidLRC)
+
+		payload, err := framer.ReadFrame()
+		require.NoError(t, err)
+		require.Equal(t, []byte("0800"), payload)
+
+		require.Equal(t, []byte{NAK, ACK}, out.Bytes())
+	})
+
+	t.Run("WriteFrame writes frame again on NAK", func(t *testing.T) {
+		var out bytes.Buffer
+		framer := NewSTXFramer(duplex{bytes.NewBuffer([]byte{NAK, ACK}), &out}, newLengthHeader, WithAckNak(3))
+
+		require.NoError(t, framer.WriteFrame([]byte("0800")))
+		require.Equal(t, append(bytes.Clone(frame), frame...), out.Bytes())
+	})
+
+	t.Run("WriteFrame returns error when frame is rejected too many times", func(t *testing.T) {
+		framer := NewSTXFramer(duplex{bytes.NewBuffer([]byte{NAK, NAK}), io.Discard}, newLengthHeader, WithAckNak(1))
+
+		err := framer.WriteFrame([]byte("0800"))
+		require.EqualError(t, err, "frame was rejected with NAK 2 times")
+	})
+
+	t.Run("MessageReader and MessageWriter read and write messages with framer", func(t *testing.T) {
+		var buf bytes.Buffer
+		framer := NewSTXFramer(&buf, newLengthHeader)
+
+		writer := NewFramedMessageWriter(framer)
+		require.NoError(t, writer.WriteMessage(newTestRequest(t, "0100", "000001")))
+
+		err := writer.WriteMessageWithHeader(NewBCD2BytesHeader(), newTestRequest(t, "0100", "000001"))
+		require.ErrorIs(t, err, ErrHeaderNotSupported)
+
+		reader := NewFramedMessageReader(framer, iso8583.Spec87)
+
+		message, raw, err := reader.ReadMessage()
+		require.NoError(t, err)
+		require.Equal(t, packTestMessage(t, "0100"), raw)
+		require.Nil(t, reader.LastHeader())
+
+		stan, err := message.GetString(11)
+		require.NoError(t, err)
+		require.Equal(t, "000001", stan)
+	})
+
+	t.Run("client and server exchange messages with framer", func(t *testing.T) {
+		newFramer := func(rw io.ReadWriter) Framer {
+			return NewSTXFramer(rw, newLengthHeader)
+		}
+
+		server := NewServer(iso8583.Spec87, nil, WithServerFramer(newFramer))
+		server.Handle("0100", respondWith("00"))
+
+		addr, _ := startTestServer(t, server)
+
+		conn, err := net.Dial("tcp", addr)
+		require.NoError(t, err)
+
+		client := NewClient(conn, iso8583.Spec87, nil, WithFramer(newFramer))
+		t.Cleanup(func() {
+			client.Close()
+		})
+
+		response, err := sendTestRequest(t, client, "0100")
+		require.NoError(t, err)
+		requireResponseCode(t, "00", response)
+	})
+}
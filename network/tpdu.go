@@ -0,0 +1,148 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+const (
+	// TPDULength is the length of the TPDU in bytes.
+	TPDULength = 5
+
+	// TPDUTransactionID is the ID of the TPDU of transaction messages.
+	TPDUTransactionID = 0x60
+)
+
+// NII is the Network International Identifier: the 2 bytes address of the
+// destination or the source of the message in the TPDU.
+type NII [2]byte
+
+// String returns the NII as 4 hex digits, e.g. "0001".
+func (n NII) String() string {
+	return fmt.Sprintf("%02X%02X", n[0], n[1])
+}
+
+// TPDU is the Transport Protocol Data Unit that POS terminals send between
+// the length header and the MTI to route messages by NII.
+type TPDU struct {
+	ID          byte
+	Destination NII
+	Source      NII
+}
+
+// ParseTPDU parses the TPDU from the first TPDULength bytes of data.
+func ParseTPDU(data []byte) (TPDU, error) {
+	if len(data) < TPDULength {
+		return TPDU{}, fmt.Errorf("not enough data to parse TPDU: expected %d bytes, got %d", TPDULength, len(data))
+	}
+
+	return TPDU{
+		ID:          data[0],
+		Destination: NII{data[1], data[2]},
+		Source:      NII{data[3], data[4]},
+	}, nil
+}
+
+// Bytes returns the TPDU encoded in TPDULength bytes.
+func (t TPDU) Bytes() []byte {
+	return []byte{t.ID, t.Destination[0], t.Destination[1], t.Source[0], t.Source[1]}
+}
+
+// Response returns the TPDU of the response to the message: the source and
+// the destination are swapped.
+func (t TPDU) Response() TPDU {
+	return TPDU{
+		ID:          t.ID,
+		Destination: t.Source,
+		Source:      t.Destination,
+	}
+}
+
+// String returns the TPDU as 10 hex digits, e.g. "6000010000".
+func (t TPDU) String() string {
+	return fmt.Sprintf("%X", t.Bytes())
+}
+
+var _ RequestHeader = (*TPDUHeader)(nil)
+
+// TPDUHeader is the length header followed by the TPDU. The length of the
+// message set and returned by the header does not include the TPDU, while
+// the length written by the length header does.
+type TPDUHeader struct {
+	LengthHeader Header
+	TPDU         TPDU
+}
+
+// NewTPDUHeader creates the header that writes the TPDU after the length
+// header.
+func NewTPDUHeader(lengthHeader Header, tpdu TPDU) *TPDUHeader {
+	return &TPDUHeader{
+		LengthHeader: lengthHeader,
+		TPDU:         tpdu,
+	}
+}
+
+func (h *TPDUHeader) SetLength(length int) {
+	h.LengthHeader.SetLength(length + TPDULength)
+}
+
+func (h *TPDUHeader) Length() int {
+	return h.LengthHeader.Length() - TPDULength
+}
+
+func (h *TPDUHeader) WriteTo(w io.Writer) (int, error) {
+	n, err := h.LengthHeader.WriteTo(w)
+	if err != nil {
+		return 0, err
+	}
+
+	m, err := w.Write(h.TPDU.Bytes())
+	if err != nil {
+		return 0, fmt.Errorf("writing TPDU: %w", err)
+	}
+
+	return n + m, nil
+}
+
+func (h *TPDUHeader) ReadFrom(r io.Reader) (int, error) {
+	n, err := h.LengthHeader.ReadFrom(r)
+	if err != nil {
+		return 0, err
+	}
+
+	if h.LengthHeader.Length() < TPDULength {
+		return 0, fmt.Errorf("length %d is less than TPDU length %d", h.LengthHeader.Length(), TPDULength)
+	}
+
+	buf := make([]byte, TPDULength)
+	m, err := io.ReadFull(r, buf)
+	if err != nil {
+		return 0, fmt.Errorf("reading TPDU: %w", err)
+	}
+
+	h.TPDU, err = ParseTPDU(buf)
+	if err != nil {
+		return 0, err
+	}
+
+	return n + m, nil
+}
+
+// ResponseHeader returns the header of the response to the message with the
+// source and the destination of the TPDU swapped. The length header is
+// reused, as it's not needed after the message is read.
+func (h *TPDUHeader) ResponseHeader() Header {
+	return NewTPDUHeader(h.LengthHeader, h.TPDU.Response())
+}
+
+// TPDUFromContext returns the TPDU of the message handled by the server
+// handler, if the message was read with the TPDUHeader.
+func TPDUFromContext(ctx context.Context) (TPDU, bool) {
+	header, ok := HeaderFromContext(ctx).(*TPDUHeader)
+	if !ok {
+		return TPDU{}, false
+	}
+
+	return header.TPDU, true
+}
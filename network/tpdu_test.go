@@ -0,0 +1,108 @@
+package network
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"testing"
+
+	"github.com/moov-io/iso8583"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTPDU(t *testing.T) {
+	t.Run("ParseTPDU parses TPDU and Bytes encodes it", func(t *testing.T) {
+		data := []byte{0x60, 0x00, 0x01, 0x00, 0x02}
+
+		tpdu, err := ParseTPDU(data)
+		require.NoError(t, err)
+		require.Equal(t, byte(TPDUTransactionID), tpdu.ID)
+		require.Equal(t, "0001", tpdu.Destination.String())
+		require.Equal(t, "0002", tpdu.Source.String())
+		require.Equal(t, "6000010002", tpdu.String())
+		require.Equal(t, data, tpdu.Bytes())
+
+		_, err = ParseTPDU(data[:4])
+		require.EqualError(t, err, "not enough data to parse TPDU: expected 5 bytes, got 4")
+	})
+
+	t.Run("Response swaps source and destination", func(t *testing.T) {
+		tpdu := TPDU{ID: TPDUTransactionID, Destination: NII{0x00, 0x01}, Source: NII{0x00, 0x02}}
+
+		require.Equal(t, "6000020001", tpdu.Response().String())
+	})
+}
+
+func TestTPDUHeader(t *testing.T) {
+	tpdu := TPDU{ID: TPDUTransactionID, Destination: NII{0x00, 0x01}, Source: NII{0x00, 0x00}}
+
+	t.Run("WriteTo writes length including TPDU and TPDU", func(t *testing.T) {
+		header := NewTPDUHeader(NewBCD2BytesHeader(), tpdu)
+		header.SetLength(15)
+
+		var buf bytes.Buffer
+		n, err := header.WriteTo(&buf)
+		require.NoError(t, err)
+		require.Equal(t, 7, n)
+		require.Equal(t, []byte{0x00, 0x20, 0x60, 0x00, 0x01, 0x00, 0x00}, buf.Bytes())
+	})
+
+	t.Run("ReadFrom reads length and TPDU", func(t *testing.T) {
+		header := NewTPDUHeader(NewBCD2BytesHeader(), TPDU{})
+
+		read, err := header.ReadFrom(bytes.NewReader([]byte{0x00, 0x20, 0x60, 0x00, 0x01, 0x00, 0x00}))
+		require.NoError(t, err)
+		require.Equal(t, 7, read)
+		require.Equal(t, 15, header.Length())
+		require.Equal(t, tpdu, header.TPDU)
+	})
+
+	t.Run("ReadFrom returns error when length does not include TPDU", func(t *testing.T) {
+		header := NewTPDUHeader(NewBCD2BytesHeader(), TPDU{})
+
+		_, err := header.ReadFrom(bytes.NewReader([]byte{0x00, 0x04, 0x60, 0x00, 0x01, 0x00}))
+		require.EqualError(t, err, "length 4 is less than TPDU length 5")
+	})
+
+	t.Run("server exposes TPDU to handlers and swaps it in responses", func(t *testing.T) {
+		newHeader := func() Header {
+			return NewTPDUHeader(NewBCD2BytesHeader(), TPDU{})
+		}
+
+		received := make(chan TPDU, 1)
+
+		server := NewServer(iso8583.Spec87, newHeader)
+		server.Handle("0100", func(ctx context.Context, message *iso8583.Message) (*iso8583.Message, error) {
+			tpdu, ok := TPDUFromContext(ctx)
+			require.True(t, ok)
+			received <- tpdu
+
+			return respondWith("00")(ctx, message)
+		})
+
+		addr, _ := startTestServer(t, server)
+
+		conn, err := net.Dial("tcp", addr)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		writer := NewMessageWriter(conn, func() Header {
+			return NewTPDUHeader(NewBCD2BytesHeader(), tpdu)
+		})
+		require.NoError(t, writer.WriteMessage(newTestRequest(t, "0100", "000001")))
+
+		require.Equal(t, tpdu, <-received)
+
+		reader := NewMessageReader(conn, newHeader, iso8583.Spec87)
+		response, _, err := reader.ReadMessage()
+		require.NoError(t, err)
+		require.Equal(t, tpdu.Response(), reader.LastHeader().(*TPDUHeader).TPDU)
+
+		requireResponseCode(t, "00", response)
+	})
+
+	t.Run("TPDUFromContext returns false when message is read without TPDU", func(t *testing.T) {
+		_, ok := TPDUFromContext(context.Background())
+		require.False(t, ok)
+	})
+}
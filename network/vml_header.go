@@ -15,6 +15,8 @@ const (
 	MaxMessageLength        = 2048
 )
 
+var _ CheckedHeader = (*VMLH)(nil)
+
 type VMLH struct {
 	Len uint16
 
@@ -39,6 +41,12 @@ func (h *VMLH) SetLength(length int) error {
 	return nil
 }
 
+// SessionControl returns true if the header belongs to the session control
+// message (e.g. heartbeat) rather than to the ISO 8583 message.
+func (h *VMLH) SessionControl() bool {
+	return h.IsSessionControl
+}
+
 func (h *VMLH) Length() int {
 	return int(h.Len)
 }
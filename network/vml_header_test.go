@@ -2,6 +2,7 @@ package network
 
 import (
 	"bytes"
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -11,7 +12,7 @@ func TestVMLHeader(t *testing.T) {
 	t.Run("WriteTo writes binary encoded length into writer", func(t *testing.T) {
 		header := NewVMLHeader()
 
-		header.SetLength(15)
+		require.NoError(t, header.SetLength(15))
 		var buf bytes.Buffer
 		n, err := header.WriteTo(&buf)
 
@@ -24,7 +25,7 @@ func TestVMLHeader(t *testing.T) {
 
 	t.Run("WriteTo returns error when message length exceeds max message length", func(t *testing.T) {
 		header := NewVMLHeader()
-		header.SetLength(MaxMessageLength + 1)
+		require.NoError(t, header.SetLength(MaxMessageLength+1))
 
 		_, err := header.WriteTo(&bytes.Buffer{})
 
@@ -59,4 +60,12 @@ func TestVMLHeader(t *testing.T) {
 
 		require.Error(t, err)
 	})
+
+	t.Run("SetLength returns error when length exceeds max length of 2 bytes", func(t *testing.T) {
+		header := NewVMLHeader()
+
+		err := header.SetLength(math.MaxUint16 + 1)
+
+		require.EqualError(t, err, "length 65536 exceeds max length for 2 bytes header 65535")
+	})
 }
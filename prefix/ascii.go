@@ -34,6 +34,23 @@ func (p *asciiVarPrefixer) EncodeLength(maxLen, dataLen int) ([]byte, error) {
 	return []byte(res), nil
 }
 
+func (p *asciiVarPrefixer) AppendLength(dst []byte, maxLen, dataLen int) ([]byte, error) {
+	if dataLen > maxLen {
+		return nil, fmt.Errorf(fieldLengthIsLargerThanMax, dataLen, maxLen)
+	}
+
+	dst, ok := appendDecimal(dst, dataLen, p.Digits)
+	if !ok {
+		return nil, fmt.Errorf(numberOfDigitsInLengthExceeds, dataLen, p.Digits)
+	}
+
+	return dst, nil
+}
+
+func (p *asciiVarPrefixer) EncodedLen() int {
+	return p.Digits
+}
+
 func (p *asciiVarPrefixer) DecodeLength(maxLen int, data []byte) (int, int, error) {
 	if len(data) < p.Digits {
 		return 0, 0, fmt.Errorf(notEnoughDataToRead, len(data), p.Digits)
@@ -71,6 +88,18 @@ func (p *asciiFixedPrefixer) EncodeLength(fixLen, dataLen int) ([]byte, error) {
 	return []byte{}, nil
 }
 
+func (p *asciiFixedPrefixer) AppendLength(dst []byte, fixLen, dataLen int) ([]byte, error) {
+	if dataLen != fixLen {
+		return nil, fmt.Errorf(fieldLengthShouldBeFixed, dataLen, fixLen)
+	}
+
+	return dst, nil
+}
+
+func (p *asciiFixedPrefixer) EncodedLen() int {
+	return 0
+}
+
 func (p *asciiFixedPrefixer) DecodeLength(fixLen int, data []byte) (int, int, error) {
 	return fixLen, 0, nil
 }
@@ -41,6 +41,24 @@ func (p *bcdVarPrefixer) EncodeLength(maxLen, dataLen int) ([]byte, error) {
 	return res, nil
 }
 
+func (p *bcdVarPrefixer) AppendLength(dst []byte, maxLen, dataLen int) ([]byte, error) {
+	if dataLen > maxLen {
+		return nil, fmt.Errorf(fieldLengthIsLargerThanMax, dataLen, maxLen)
+	}
+
+	var buf [8]byte
+	digits, ok := appendDecimal(buf[:0], dataLen, p.Digits)
+	if !ok {
+		return nil, fmt.Errorf(numberOfDigitsInLengthExceeds, dataLen, p.Digits)
+	}
+
+	return encoding.BCD.AppendEncode(dst, digits)
+}
+
+func (p *bcdVarPrefixer) EncodedLen() int {
+	return bcd.EncodedLen(p.Digits)
+}
+
 func (p *bcdVarPrefixer) DecodeLength(maxLen int, data []byte) (int, int, error) {
 	length := bcd.EncodedLen(p.Digits)
 	if len(data) < length {
@@ -79,6 +97,18 @@ func (p *bcdFixedPrefixer) EncodeLength(fixLen, dataLen int) ([]byte, error) {
 	return []byte{}, nil
 }
 
+func (p *bcdFixedPrefixer) AppendLength(dst []byte, fixLen, dataLen int) ([]byte, error) {
+	if dataLen != fixLen {
+		return nil, fmt.Errorf(fieldLengthShouldBeFixed, dataLen, fixLen)
+	}
+
+	return dst, nil
+}
+
+func (p *bcdFixedPrefixer) EncodedLen() int {
+	return 0
+}
+
 // Returns number of characters that should be decoded
 func (p *bcdFixedPrefixer) DecodeLength(fixLen int, data []byte) (int, int, error) {
 	return fixLen, 0, nil
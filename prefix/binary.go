@@ -29,6 +29,18 @@ func (p *binaryFixedPrefixer) EncodeLength(fixLen, dataLen int) ([]byte, error)
 	return []byte{}, nil
 }
 
+func (p *binaryFixedPrefixer) AppendLength(dst []byte, fixLen, dataLen int) ([]byte, error) {
+	if dataLen != fixLen {
+		return nil, fmt.Errorf(fieldLengthShouldBeFixed, dataLen, fixLen)
+	}
+
+	return dst, nil
+}
+
+func (p *binaryFixedPrefixer) EncodedLen() int {
+	return 0
+}
+
 func (p *binaryFixedPrefixer) DecodeLength(fixLen int, data []byte) (int, int, error) {
 	return fixLen, 0, nil
 }
@@ -94,6 +106,38 @@ func (p *binaryVarPrefixer) EncodeLength(maxLen, dataLen int) ([]byte, error) {
 	return res, nil
 }
 
+func (p *binaryVarPrefixer) AppendLength(dst []byte, maxLen, dataLen int) ([]byte, error) {
+	if dataLen > maxLen {
+		return nil, fmt.Errorf(fieldLengthIsLargerThanMax, dataLen, maxLen)
+	}
+
+	if dataLen < 0 {
+		return nil, fmt.Errorf("encode length: negative number: %d", dataLen)
+	}
+
+	if dataLen > math.MaxUint32 {
+		return nil, fmt.Errorf("encode length: number %d exceeds maximum uint32 value", dataLen)
+	}
+
+	if p.Digits < 4 && dataLen >= 1<<(8*p.Digits) {
+		return nil, fmt.Errorf(numberOfDigitsInLengthExceeds, dataLen, p.Digits)
+	}
+
+	for i := p.Digits - 1; i >= 0; i-- {
+		if i >= 4 {
+			dst = append(dst, 0x00)
+			continue
+		}
+		dst = append(dst, byte(dataLen>>(8*i)))
+	}
+
+	return dst, nil
+}
+
+func (p *binaryVarPrefixer) EncodedLen() int {
+	return p.Digits
+}
+
 // DecodeLength decodes the length of the field from the data. It reads up to 4
 // bytes from data, converts it into int32 and returns the length of the field
 // and the number of bytes read.
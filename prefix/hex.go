@@ -30,6 +30,19 @@ func (p *hexFixedPrefixer) EncodeLength(fixLen, dataLen int) ([]byte, error) {
 	return []byte{}, nil
 }
 
+func (p *hexFixedPrefixer) AppendLength(dst []byte, fixLen, dataLen int) ([]byte, error) {
+	// for ascii hex the length is x2 (ascii hex digit takes one byte)
+	if dataLen != fixLen*2 {
+		return nil, fmt.Errorf(fieldLengthShouldBeFixed, dataLen, fixLen*2)
+	}
+
+	return dst, nil
+}
+
+func (p *hexFixedPrefixer) EncodedLen() int {
+	return 0
+}
+
 func (p *hexFixedPrefixer) DecodeLength(fixLen int, _ []byte) (int, int, error) {
 	return fixLen, 0, nil
 }
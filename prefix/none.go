@@ -11,6 +11,14 @@ func (p *nonePrefixer) EncodeLength(int, int) ([]byte, error) {
 	return []byte{}, nil
 }
 
+func (p *nonePrefixer) AppendLength(dst []byte, _, _ int) ([]byte, error) {
+	return dst, nil
+}
+
+func (p *nonePrefixer) EncodedLen() int {
+	return 0
+}
+
 func (p *nonePrefixer) DecodeLength(fixLen int, data []byte) (int, int, error) {
 	return len(data), 0, nil
 }
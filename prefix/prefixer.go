@@ -18,6 +18,39 @@ type Prefixer interface {
 	Inspect() string
 }
 
+// Appender is an optional interface implemented by prefixers that can append
+// the encoded length to the destination slice instead of allocating a new
+// one. It is used by fields to pack data into a buffer provided by the
+// caller.
+type Appender interface {
+	// Appends field length encoded into []byte to dst and returns the
+	// extended slice
+	AppendLength(dst []byte, maxLen, length int) ([]byte, error)
+
+	// Returns the number of bytes the encoded length takes
+	EncodedLen() int
+}
+
+// appendDecimal appends n as decimal number left padded with zeros to the
+// given number of digits. It returns false if n does not fit into digits.
+func appendDecimal(dst []byte, n, digits int) ([]byte, bool) {
+	start := len(dst)
+	for range digits {
+		dst = append(dst, '0')
+	}
+
+	for i := len(dst) - 1; i >= start && n > 0; i-- {
+		dst[i] = byte('0' + n%10)
+		n /= 10
+	}
+
+	if n > 0 {
+		return dst[:start], false
+	}
+
+	return dst, true
+}
+
 type Prefixers struct {
 	Fixed  Prefixer
 	L      Prefixer
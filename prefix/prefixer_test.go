@@ -0,0 +1,71 @@
+package prefix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppendLength(t *testing.T) {
+	tests := []struct {
+		prefixer Prefixer
+		maxLen   int
+		length   int
+	}{
+		{ASCII.Fixed, 10, 10},
+		{ASCII.LL, 20, 9},
+		{ASCII.LLL, 999, 123},
+		{ASCII.LLLLLL, 999999, 12},
+		{BCD.Fixed, 10, 10},
+		{BCD.L, 9, 5},
+		{BCD.LL, 20, 19},
+		{BCD.LLL, 999, 123},
+		{Binary.Fixed, 8, 8},
+		{Binary.L, 255, 200},
+		{Binary.LL, 999, 300},
+		{Binary.LLLLLL, 999999, 70000},
+		{Hex.Fixed, 8, 16},
+		{None.Fixed, 0, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.prefixer.Inspect(), func(t *testing.T) {
+			appender, ok := tt.prefixer.(Appender)
+			require.True(t, ok)
+
+			encoded, err := tt.prefixer.EncodeLength(tt.maxLen, tt.length)
+			require.NoError(t, err)
+
+			appended, err := appender.AppendLength([]byte{0xFF}, tt.maxLen, tt.length)
+			require.NoError(t, err)
+
+			require.Equal(t, append([]byte{0xFF}, encoded...), appended)
+			require.Len(t, encoded, appender.EncodedLen())
+		})
+	}
+
+	t.Run("returns the same errors as EncodeLength", func(t *testing.T) {
+		tests := []struct {
+			prefixer Prefixer
+			maxLen   int
+			length   int
+		}{
+			{ASCII.Fixed, 10, 9},
+			{ASCII.LL, 20, 21},
+			{ASCII.L, 20, 12},
+			{BCD.Fixed, 10, 9},
+			{BCD.LL, 200, 123},
+			{Binary.Fixed, 8, 7},
+			{Binary.L, 999, 256},
+			{Hex.Fixed, 8, 8},
+		}
+
+		for _, tt := range tests {
+			_, expectedErr := tt.prefixer.EncodeLength(tt.maxLen, tt.length)
+			require.Error(t, expectedErr)
+
+			_, err := tt.prefixer.(Appender).AppendLength(nil, tt.maxLen, tt.length)
+			require.EqualError(t, err, expectedErr.Error(), tt.prefixer.Inspect())
+		}
+	})
+}
@@ -0,0 +1,135 @@
+package iso8583
+
+import (
+	"fmt"
+
+	"github.com/moov-io/iso8583/field"
+)
+
+// DefaultEchoFields defines which fields of the request are copied into the
+// response by NewResponse. It is keyed by the message class and follows the
+// ISO 8583:1987 rules for fields that must be returned unchanged in the
+// response.
+var DefaultEchoFields = map[MessageClass][]int{
+	ClassAuthorization:     {2, 3, 4, 7, 11, 12, 13, 32, 37, 41, 42, 49},
+	ClassFinancial:         {2, 3, 4, 7, 11, 12, 13, 32, 37, 41, 42, 49},
+	ClassFileAction:        {7, 11, 32, 37, 41, 42},
+	ClassReversal:          {2, 3, 4, 7, 11, 12, 13, 32, 37, 41, 42, 49, 90},
+	ClassReconciliation:    {7, 11, 15, 32, 50},
+	ClassAdministrative:    {7, 11, 32, 37, 41, 42},
+	ClassFeeCollection:     {2, 3, 4, 7, 11, 32, 37, 49},
+	ClassNetworkManagement: {7, 11, 70},
+}
+
+type responseOptions struct {
+	mti        string
+	echoFields []int
+	echoTable  map[MessageClass][]int
+}
+
+// ResponseOption configures the response created by NewResponse.
+type ResponseOption func(*responseOptions)
+
+// WithResponseMTI sets the MTI of the response instead of computing it from
+// the request MTI.
+func WithResponseMTI(mti string) ResponseOption {
+	return func(o *responseOptions) {
+		o.mti = mti
+	}
+}
+
+// WithEchoFields sets the list of fields that are copied from the request
+// into the response regardless of the message class.
+func WithEchoFields(ids ...int) ResponseOption {
+	return func(o *responseOptions) {
+		o.echoFields = ids
+	}
+}
+
+// WithEchoTable sets the table of the echo fields keyed by the message class
+// that is used instead of DefaultEchoFields.
+func WithEchoTable(table map[MessageClass][]int) ResponseOption {
+	return func(o *responseOptions) {
+		o.echoTable = table
+	}
+}
+
+// NewResponse creates a response message for the request. The response has
+// the same spec as the request, its MTI is computed from the request MTI
+// (e.g. 0100 -> 0110, 0421 -> 0430, 1100 -> 1110) and it holds copies of the
+// echo fields of the request. All other fields are left unset.
+func NewResponse(req *Message, opts ...ResponseOption) (*Message, error) {
+	options := &responseOptions{
+		echoTable: DefaultEchoFields,
+	}
+
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	reqMTI, err := req.GetParsedMTI()
+	if err != nil {
+		return nil, fmt.Errorf("getting request MTI: %w", err)
+	}
+
+	mti := options.mti
+	if mti == "" {
+		resMTI, err := reqMTI.ResponseMTI()
+		if err != nil {
+			return nil, fmt.Errorf("computing response MTI: %w", err)
+		}
+		mti = resMTI.String()
+	}
+
+	echoFields := options.echoFields
+	if echoFields == nil {
+		echoFields = options.echoTable[reqMTI.Class]
+	}
+
+	res := NewMessage(req.GetSpec())
+	res.MTI(mti)
+
+	req.mu.Lock()
+	defer req.mu.Unlock()
+
+	for _, id := range echoFields {
+		// MTI and bitmap are never copied
+		if id == mtiIdx || id == bitmapIdx {
+			continue
+		}
+
+		reqField, err := req.fieldByID(id)
+		if err != nil {
+			return nil, fmt.Errorf("getting request field %d: %w", id, err)
+		}
+
+		if reqField == nil {
+			continue
+		}
+
+		resField, err := copyField(reqField)
+		if err != nil {
+			return nil, fmt.Errorf("copying field %d: %w", id, err)
+		}
+
+		res.fields[id] = resField
+	}
+
+	return res, nil
+}
+
+// copyField creates a new field with the same spec and value as f by packing
+// and unpacking it.
+func copyField(f field.Field) (field.Field, error) {
+	packed, err := f.Pack()
+	if err != nil {
+		return nil, fmt.Errorf("packing field: %w", err)
+	}
+
+	copied := field.NewInstanceOf(f)
+	if _, err := copied.Unpack(packed); err != nil {
+		return nil, fmt.Errorf("unpacking field: %w", err)
+	}
+
+	return copied, nil
+}
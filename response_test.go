@@ -0,0 +1,110 @@
+package iso8583
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewResponse(t *testing.T) {
+	newRequest := func(t *testing.T, mti string) *Message {
+		t.Helper()
+
+		req := NewMessage(Spec87)
+		req.MTI(mti)
+		require.NoError(t, req.Field(2, "4242424242424242"))
+		require.NoError(t, req.Field(3, "123456"))
+		require.NoError(t, req.Field(4, "100"))
+		require.NoError(t, req.Field(7, "0701111844"))
+		require.NoError(t, req.Field(11, "000123"))
+		require.NoError(t, req.Field(14, "2512"))
+		require.NoError(t, req.Field(41, "TERM0001"))
+		require.NoError(t, req.Field(70, "301"))
+
+		return req
+	}
+
+	t.Run("copies echo fields of the message class", func(t *testing.T) {
+		req := newRequest(t, "0100")
+
+		res, err := NewResponse(req)
+		require.NoError(t, err)
+
+		require.Same(t, req.GetSpec(), res.GetSpec())
+
+		mti, err := res.GetMTI()
+		require.NoError(t, err)
+		require.Equal(t, "0110", mti)
+
+		for _, id := range []int{2, 3, 4, 7, 11, 41} {
+			reqValue, err := req.GetString(id)
+			require.NoError(t, err)
+
+			resValue, err := res.GetString(id)
+			require.NoError(t, err)
+
+			require.Equal(t, reqValue, resValue, "field %d", id)
+		}
+
+		// fields that are not echoed are not set
+		require.Nil(t, res.GetField(14))
+		require.Nil(t, res.GetField(70))
+
+		// response fields are independent from the request fields
+		require.NoError(t, res.Field(4, "200"))
+		reqAmount, err := req.GetString(4)
+		require.NoError(t, err)
+		require.Equal(t, "100", reqAmount)
+
+		require.NoError(t, res.Field(39, "00"))
+		_, err = res.Pack()
+		require.NoError(t, err)
+	})
+
+	t.Run("uses network management echo fields", func(t *testing.T) {
+		res, err := NewResponse(newRequest(t, "0800"))
+		require.NoError(t, err)
+
+		mti, err := res.GetMTI()
+		require.NoError(t, err)
+		require.Equal(t, "0810", mti)
+
+		fields := res.GetFields()
+		require.Len(t, fields, 4) // MTI, 7, 11, 70
+		require.Contains(t, fields, 7)
+		require.Contains(t, fields, 11)
+		require.Contains(t, fields, 70)
+	})
+
+	t.Run("options override MTI and echo fields", func(t *testing.T) {
+		res, err := NewResponse(newRequest(t, "0100"),
+			WithResponseMTI("0190"),
+			WithEchoFields(11, 14),
+		)
+		require.NoError(t, err)
+
+		mti, err := res.GetMTI()
+		require.NoError(t, err)
+		require.Equal(t, "0190", mti)
+
+		fields := res.GetFields()
+		require.Len(t, fields, 3)
+		require.Contains(t, fields, 11)
+		require.Contains(t, fields, 14)
+
+		res, err = NewResponse(newRequest(t, "0200"), WithEchoTable(map[MessageClass][]int{
+			ClassFinancial: {41},
+		}))
+		require.NoError(t, err)
+		require.Len(t, res.GetFields(), 2)
+		require.Contains(t, res.GetFields(), 41)
+	})
+
+	t.Run("returns error for messages without response", func(t *testing.T) {
+		_, err := NewResponse(newRequest(t, "0110"))
+		require.EqualError(t, err, "computing response MTI: MTI 0110 is not a request, advice, notification or instruction and has no response")
+
+		_, err = NewResponse(newRequest(t, "01X0"))
+		require.EqualError(t, err, `getting request MTI: invalid MTI "01X0": expected 4 digits`)
+	})
+}
@@ -13,8 +13,9 @@ import (
 // inspected (e.g., MTI and STAN in a proxy) without unpacking the entire
 // message.
 //
-// MTI and bitmap are parsed automatically as needed. Fields can only be
-// scanned in ascending order; attempting to scan a field at or before the
+// MTI and bitmap are parsed automatically as needed. Fields of the
+// bitmapless message are scanned in the order of the spec. Fields can only
+// be scanned in ascending order; attempting to scan a field at or before the
 // current cursor position returns an error.
 type MessageScanner struct {
 	spec   *MessageSpec
@@ -23,6 +24,7 @@ type MessageScanner struct {
 
 	bitmap        *field.Bitmap
 	lastID        int // last scanned field ID, -1 initially
+	scannedHeader bool
 	scannedBitmap bool
 }
 
@@ -64,6 +66,16 @@ func (s *MessageScanner) scanField(id int) (field.Field, string, error) {
 		return nil, strconv.Itoa(id), fmt.Errorf("field %d is at or before current position %d: scanner is forward-only", id, s.lastID)
 	}
 
+	if !s.scannedHeader {
+		if err := s.skipHeader(); err != nil {
+			return nil, headerID, err
+		}
+	}
+
+	if s.spec.Bitmapless {
+		return s.scanPositionalField(id)
+	}
+
 	// Ensure MTI is scanned; return it if that's what was requested.
 	if s.lastID == -1 {
 		f, err := s.scanMTI()
@@ -96,27 +108,63 @@ func (s *MessageScanner) scanField(id int) (field.Field, string, error) {
 			continue
 		}
 
-		specField, ok := s.spec.Fields[i]
-		if !ok {
-			return nil, strconv.Itoa(i), fmt.Errorf("field %d is not defined in the spec", i)
+		f, err := s.scanNextField(i)
+		if err != nil {
+			return nil, strconv.Itoa(i), err
 		}
 
-		f := field.NewInstanceOf(specField)
+		if i == id {
+			return f, "", nil
+		}
+	}
 
-		read, err := f.Unpack(s.src[s.offset:])
-		if err != nil {
-			return nil, strconv.Itoa(i), fmt.Errorf("failed to unpack field %d (%s): %w", i, f.Spec().Description, err)
+	return nil, strconv.Itoa(id), fmt.Errorf("field %d is not set in the bitmap", id)
+}
+
+// scanPositionalField scans the fields of the bitmapless message up to and
+// including the field with the given id.
+func (s *MessageScanner) scanPositionalField(id int) (field.Field, string, error) {
+	for _, i := range s.spec.fieldIDs() {
+		if i <= s.lastID {
+			continue
 		}
 
-		s.offset += read
-		s.lastID = i
+		if i > id {
+			break
+		}
+
+		f, err := s.scanNextField(i)
+		if err != nil {
+			return nil, strconv.Itoa(i), err
+		}
 
 		if i == id {
 			return f, "", nil
 		}
 	}
 
-	return nil, strconv.Itoa(id), fmt.Errorf("field %d is not set in the bitmap", id)
+	return nil, strconv.Itoa(id), fmt.Errorf("field %d is not defined in the spec", id)
+}
+
+// scanNextField unpacks the field i at the current cursor position and moves
+// the cursor past it.
+func (s *MessageScanner) scanNextField(i int) (field.Field, error) {
+	specField, ok := s.spec.Fields[i]
+	if !ok {
+		return nil, fmt.Errorf("field %d is not defined in the spec", i)
+	}
+
+	f := field.NewInstanceOf(specField)
+
+	read, err := f.Unpack(s.src[s.offset:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack field %d (%s): %w", i, f.Spec().Description, err)
+	}
+
+	s.offset += read
+	s.lastID = i
+
+	return f, nil
 }
 
 func (s *MessageScanner) scanMTI() (field.Field, error) {
@@ -138,6 +186,23 @@ func (s *MessageScanner) scanMTI() (field.Field, error) {
 	return f, nil
 }
 
+// skipHeader moves the cursor past the protocol header packed before the
+// MTI, if the spec defines it.
+func (s *MessageScanner) skipHeader() error {
+	if s.spec.Header != nil {
+		read, err := field.NewInstanceOf(s.spec.Header).Unpack(s.src[s.offset:])
+		if err != nil {
+			return fmt.Errorf("failed to unpack header: %w", err)
+		}
+
+		s.offset += read
+	}
+
+	s.scannedHeader = true
+
+	return nil
+}
+
 func (s *MessageScanner) parseBitmap() error {
 	specField, ok := s.spec.Fields[bitmapIdx]
 	if !ok {
@@ -237,3 +237,19 @@ func TestMessageScanner(t *testing.T) {
 		assert.Equal(t, "123", stan)
 	})
 }
+
+func TestMessageScannerBitmapless(t *testing.T) {
+	packed := []byte("01164242424242424242000000000100     ")
+
+	scanner := NewMessageScanner(bitmaplessSpec, packed)
+
+	f, err := scanner.ScanField(3)
+	require.NoError(t, err)
+
+	amount, err := f.String()
+	require.NoError(t, err)
+	require.Equal(t, "100", amount)
+
+	_, err = scanner.ScanField(5)
+	require.EqualError(t, err, "field 5 is not defined in the spec")
+}
@@ -6,9 +6,11 @@ import (
 	"fmt"
 	"path"
 	"reflect"
+	"regexp"
 	"runtime"
 	"sort"
 	"strconv"
+	"time"
 
 	"github.com/moov-io/iso8583"
 	"github.com/moov-io/iso8583/encoding"
@@ -27,42 +29,56 @@ var (
 		"String":    func(spec *field.Spec) field.Field { return field.NewString(spec) },
 		"Track2":    func(spec *field.Spec) field.Field { return field.NewTrack2(spec) },
 		"Numeric":   func(spec *field.Spec) field.Field { return field.NewNumeric(spec) },
+		"Digits":    func(spec *field.Spec) field.Field { return field.NewDigits(spec) },
 		"Binary":    func(spec *field.Spec) field.Field { return field.NewBinary(spec) },
 		"Bitmap":    func(spec *field.Spec) field.Field { return field.NewBitmap(spec) },
 		"Composite": func(spec *field.Spec) field.Field { return field.NewComposite(spec) },
+		"Repeating": func(spec *field.Spec) field.Field { return field.NewRepeating(spec) },
+		"Amount":    func(spec *field.Spec) field.Field { return field.NewAmount(spec) },
+		"DateTime":  func(spec *field.Spec) field.Field { return field.NewDateTime(spec) },
 		"Hex":       func(spec *field.Spec) field.Field { return field.NewHex(spec) },
 		"Track1":    func(spec *field.Spec) field.Field { return field.NewTrack1(spec) },
 		"Track3":    func(spec *field.Spec) field.Field { return field.NewTrack3(spec) },
 	}
 
 	PrefixesExtToInt = map[string]prefix.Prefixer{
-		"None.Fixed":   prefix.None.Fixed,
-		"ASCII.Fixed":  prefix.ASCII.Fixed,
-		"ASCII.L":      prefix.ASCII.L,
-		"ASCII.LL":     prefix.ASCII.LL,
-		"ASCII.LLL":    prefix.ASCII.LLL,
-		"ASCII.LLLL":   prefix.ASCII.LLLL,
-		"BCD.Fixed":    prefix.BCD.Fixed,
-		"BCD.L":        prefix.BCD.L,
-		"BCD.LL":       prefix.BCD.LL,
-		"BCD.LLL":      prefix.BCD.LLL,
-		"BCD.LLLL":     prefix.BCD.LLLL,
-		"Hex.Fixed":    prefix.Hex.Fixed,
-		"Hex.L":        prefix.Hex.L,
-		"Hex.LL":       prefix.Hex.LL,
-		"Hex.LLL":      prefix.Hex.LLL,
-		"Hex.LLLL":     prefix.Hex.LLLL,
-		"EBCDIC.Fixed": prefix.EBCDIC.Fixed,
-		"EBCDIC.L":     prefix.EBCDIC.L,
-		"EBCDIC.LL":    prefix.EBCDIC.LL,
-		"EBCDIC.LLL":   prefix.EBCDIC.LLL,
-		"EBCDIC.LLLL":  prefix.EBCDIC.LLLL,
-		"Binary.Fixed": prefix.Binary.Fixed,
-		"Binary.L":     prefix.Binary.L,
-		"Binary.LL":    prefix.Binary.LL,
-		"Binary.LLL":   prefix.Binary.LLL,
-		"Binary.LLLL":  prefix.Binary.LLLL,
-		"BerTLV":       prefix.BerTLV,
+		"None.Fixed":    prefix.None.Fixed,
+		"ASCII.Fixed":   prefix.ASCII.Fixed,
+		"ASCII.L":       prefix.ASCII.L,
+		"ASCII.LL":      prefix.ASCII.LL,
+		"ASCII.LLL":     prefix.ASCII.LLL,
+		"ASCII.LLLL":    prefix.ASCII.LLLL,
+		"ASCII.LLLLL":   prefix.ASCII.LLLLL,
+		"ASCII.LLLLLL":  prefix.ASCII.LLLLLL,
+		"BCD.Fixed":     prefix.BCD.Fixed,
+		"BCD.L":         prefix.BCD.L,
+		"BCD.LL":        prefix.BCD.LL,
+		"BCD.LLL":       prefix.BCD.LLL,
+		"BCD.LLLL":      prefix.BCD.LLLL,
+		"BCD.LLLLL":     prefix.BCD.LLLLL,
+		"BCD.LLLLLL":    prefix.BCD.LLLLLL,
+		"Hex.Fixed":     prefix.Hex.Fixed,
+		"Hex.L":         prefix.Hex.L,
+		"Hex.LL":        prefix.Hex.LL,
+		"Hex.LLL":       prefix.Hex.LLL,
+		"Hex.LLLL":      prefix.Hex.LLLL,
+		"Hex.LLLLL":     prefix.Hex.LLLLL,
+		"Hex.LLLLLL":    prefix.Hex.LLLLLL,
+		"EBCDIC.Fixed":  prefix.EBCDIC.Fixed,
+		"EBCDIC.L":      prefix.EBCDIC.L,
+		"EBCDIC.LL":     prefix.EBCDIC.LL,
+		"EBCDIC.LLL":    prefix.EBCDIC.LLL,
+		"EBCDIC.LLLL":   prefix.EBCDIC.LLLL,
+		"EBCDIC.LLLLL":  prefix.EBCDIC.LLLLL,
+		"EBCDIC.LLLLLL": prefix.EBCDIC.LLLLLL,
+		"Binary.Fixed":  prefix.Binary.Fixed,
+		"Binary.L":      prefix.Binary.L,
+		"Binary.LL":     prefix.Binary.LL,
+		"Binary.LLL":    prefix.Binary.LLL,
+		"Binary.LLLL":   prefix.Binary.LLLL,
+		"Binary.LLLLL":  prefix.Binary.LLLLL,
+		"Binary.LLLLLL": prefix.Binary.LLLLLL,
+		"BerTLV":        prefix.BerTLV,
 	}
 
 	EncodingsExtToInt = map[string]encoding.Encoder{
@@ -115,6 +131,12 @@ var (
 		"StringsByInt": moovsort.StringsByInt,
 		"StringsByHex": moovsort.StringsByHex,
 	}
+
+	YearInferencesExtToInt = map[string]field.YearInference{
+		"NearestYear": field.NearestYear,
+		"PastYear":    field.PastYear,
+		"CurrentYear": field.CurrentYear,
+	}
 )
 
 // Deprecated: Use ImportJSON, ExportJSON package-level functions instead.
@@ -129,8 +151,22 @@ type MessageSpecBuilder interface {
 type messageSpecBuilder struct{}
 
 type specDummy struct {
-	Name   string          `json:"name,omitempty"   xml:"name,omitempty"   yaml:"name,omitempty"`
-	Fields orderedFieldMap `json:"fields,omitempty" xml:"fields,omitempty" yaml:"fields,omitempty"`
+	Name       string                `json:"name,omitempty"       xml:"name,omitempty"       yaml:"name,omitempty"`
+	Bitmapless bool                  `json:"bitmapless,omitempty" xml:"bitmapless,omitempty" yaml:"bitmapless,omitempty"`
+	Fields     orderedFieldMap       `json:"fields,omitempty"     xml:"fields,omitempty"     yaml:"fields,omitempty"`
+	Rules      map[string]*ruleDummy `json:"rules,omitempty"      xml:"rules,omitempty"      yaml:"rules,omitempty"`
+}
+
+type ruleDummy struct {
+	Fields     map[string]string `json:"fields,omitempty"     xml:"fields,omitempty"     yaml:"fields,omitempty"`
+	Conditions []*conditionDummy `json:"conditions,omitempty" xml:"conditions,omitempty" yaml:"conditions,omitempty"`
+}
+
+type conditionDummy struct {
+	Field       string   `json:"field"                 xml:"field"                 yaml:"field"`
+	Presence    string   `json:"presence"              xml:"presence"              yaml:"presence"`
+	WhenPresent []string `json:"whenPresent,omitempty" xml:"whenPresent,omitempty" yaml:"whenPresent,omitempty"`
+	WhenAbsent  []string `json:"whenAbsent,omitempty"  xml:"whenAbsent,omitempty"  yaml:"whenAbsent,omitempty"`
 }
 
 type fieldDummy struct {
@@ -144,6 +180,38 @@ type fieldDummy struct {
 	Subfields         map[string]*fieldDummy `json:"subfields,omitempty"         xml:"subfields:omitempty"         yaml:"subfields,omitempty"`
 	Bitmap            *fieldDummy            `json:"bitmap,omitempty"            xml:"bitmap,omitempty"            yaml:"bitmap,omitempty"`
 	DisableAutoExpand bool                   `json:"disableAutoExpand,omitempty" xml:"disableAutoExpand,omitempty" yaml:"disableAutoExpand,omitempty"`
+	Constraints       *constraintsDummy      `json:"constraints,omitempty"       xml:"constraints,omitempty"       yaml:"constraints,omitempty"`
+	Repeat            *repeatDummy           `json:"repeat,omitempty"            xml:"repeat,omitempty"            yaml:"repeat,omitempty"`
+	Amount            *amountDummy           `json:"amount,omitempty"            xml:"amount,omitempty"            yaml:"amount,omitempty"`
+	DateTime          *dateTimeDummy         `json:"dateTime,omitempty"          xml:"dateTime,omitempty"          yaml:"dateTime,omitempty"`
+}
+
+type dateTimeDummy struct {
+	Layout        string `json:"layout"                  xml:"layout"                  yaml:"layout"`
+	Location      string `json:"location,omitempty"      xml:"location,omitempty"      yaml:"location,omitempty"`
+	YearInference string `json:"yearInference,omitempty" xml:"yearInference,omitempty" yaml:"yearInference,omitempty"`
+}
+
+type amountDummy struct {
+	Sign     string `json:"sign,omitempty"     xml:"sign,omitempty"     yaml:"sign,omitempty"`
+	Exponent int    `json:"exponent,omitempty" xml:"exponent,omitempty" yaml:"exponent,omitempty"`
+}
+
+type repeatDummy struct {
+	Element     *fieldDummy `json:"element"               xml:"element"               yaml:"element"`
+	Mode        string      `json:"mode"                  xml:"mode"                  yaml:"mode"`
+	Count       int         `json:"count,omitempty"       xml:"count,omitempty"       yaml:"count,omitempty"`
+	CountPrefix string      `json:"countPrefix,omitempty" xml:"countPrefix,omitempty" yaml:"countPrefix,omitempty"`
+}
+
+type constraintsDummy struct {
+	Class      string   `json:"class,omitempty"      xml:"class,omitempty"      yaml:"class,omitempty"`
+	MinLength  int      `json:"minLength,omitempty"  xml:"minLength,omitempty"  yaml:"minLength,omitempty"`
+	Pattern    string   `json:"pattern,omitempty"    xml:"pattern,omitempty"    yaml:"pattern,omitempty"`
+	Enum       []string `json:"enum,omitempty"       xml:"enum,omitempty"       yaml:"enum,omitempty"`
+	Min        *int64   `json:"min,omitempty"        xml:"min,omitempty"        yaml:"min,omitempty"`
+	Max        *int64   `json:"max,omitempty"        xml:"max,omitempty"        yaml:"max,omitempty"`
+	Strictness string   `json:"strictness,omitempty" xml:"strictness,omitempty" yaml:"strictness,omitempty"`
 }
 
 type paddingDummy struct {
@@ -179,7 +247,13 @@ func importField(dummyField *fieldDummy, index string) (*field.Spec, error) {
 		}
 	}
 
-	if len(dummyField.Subfields) == 0 {
+	if dummyField.Repeat != nil {
+		repeat, err := importRepeat(dummyField.Repeat, index)
+		if err != nil {
+			return nil, err
+		}
+		fieldSpec.Repeat = repeat
+	} else if len(dummyField.Subfields) == 0 {
 		enc, ok := EncodingsExtToInt[dummyField.Enc]
 		if !ok {
 			return nil, fmt.Errorf("unknown encoding: %s for field: %s", dummyField.Enc, index)
@@ -245,17 +319,153 @@ func importField(dummyField *fieldDummy, index string) (*field.Spec, error) {
 
 	}
 	fieldSpec.DisableAutoExpand = dummyField.DisableAutoExpand
+
+	if dummyField.Amount != nil {
+		sign := field.SignIndicator(dummyField.Amount.Sign)
+		switch sign {
+		case field.SignNone, field.SignCreditDebit, field.SignPlusMinus:
+		default:
+			return nil, fmt.Errorf("unknown sign indicator: %s in amount of field: %s", dummyField.Amount.Sign, index)
+		}
+
+		fieldSpec.Amount = &field.AmountSpec{
+			Sign:     sign,
+			Exponent: dummyField.Amount.Exponent,
+		}
+	}
+
+	if dummyField.DateTime != nil {
+		dateTime, err := importDateTime(dummyField.DateTime, index)
+		if err != nil {
+			return nil, err
+		}
+		fieldSpec.DateTime = dateTime
+	}
+
+	if dummyField.Constraints != nil {
+		constraints, err := importConstraints(dummyField.Constraints, index)
+		if err != nil {
+			return nil, err
+		}
+		fieldSpec.Constraints = constraints
+	}
+
 	return fieldSpec, nil
 }
 
+func importRepeat(dummy *repeatDummy, index string) (*field.RepeatSpec, error) {
+	if dummy.Element == nil {
+		return nil, fmt.Errorf("missing repeat element for field: %s", index)
+	}
+
+	elementSpec, err := importField(dummy.Element, index)
+	if err != nil {
+		return nil, err
+	}
+
+	constructor, ok := FieldConstructor[dummy.Element.Type]
+	if !ok {
+		return nil, fmt.Errorf("no constructor for filed type: %s for repeat element of field: %s", dummy.Element.Type, index)
+	}
+
+	repeat := &field.RepeatSpec{
+		Element: constructor(elementSpec),
+		Mode:    field.RepeatMode(dummy.Mode),
+		Count:   dummy.Count,
+	}
+
+	switch repeat.Mode {
+	case field.RepeatFixed, field.RepeatCounted, field.RepeatFill:
+	default:
+		return nil, fmt.Errorf("unknown mode: %s in repeat of field: %s", dummy.Mode, index)
+	}
+
+	if dummy.CountPrefix != "" {
+		pref, ok := PrefixesExtToInt[dummy.CountPrefix]
+		if !ok {
+			return nil, fmt.Errorf("unknown prefix: %s for repeat count of field: %s", dummy.CountPrefix, index)
+		}
+		repeat.CountPref = pref
+	}
+
+	return repeat, nil
+}
+
+func importDateTime(dummy *dateTimeDummy, index string) (*field.DateTimeSpec, error) {
+	if dummy.Layout == "" {
+		return nil, fmt.Errorf("missing layout in date/time of field: %s", index)
+	}
+
+	dateTime := &field.DateTimeSpec{
+		Layout: dummy.Layout,
+	}
+
+	if err := dateTime.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid layout in date/time of field: %s: %w", index, err)
+	}
+
+	if dummy.Location != "" {
+		loc, err := time.LoadLocation(dummy.Location)
+		if err != nil {
+			return nil, fmt.Errorf("unknown location: %s in date/time of field: %s", dummy.Location, index)
+		}
+		dateTime.Location = loc
+	}
+
+	if dummy.YearInference != "" {
+		inferYear, ok := YearInferencesExtToInt[dummy.YearInference]
+		if !ok {
+			return nil, fmt.Errorf("unknown year inference: %s in date/time of field: %s", dummy.YearInference, index)
+		}
+		dateTime.YearInference = inferYear
+	}
+
+	return dateTime, nil
+}
+
+func importConstraints(dummy *constraintsDummy, index string) (*field.Constraints, error) {
+	constraints := &field.Constraints{
+		Class:      field.Class(dummy.Class),
+		MinLength:  dummy.MinLength,
+		Enum:       dummy.Enum,
+		Min:        dummy.Min,
+		Max:        dummy.Max,
+		Strictness: field.Strictness(dummy.Strictness),
+	}
+
+	switch constraints.Class {
+	case "", field.ClassNumeric, field.ClassAlpha, field.ClassAlphaNumeric,
+		field.ClassAlphaNumericSpecial, field.ClassBinary, field.ClassTrack:
+	default:
+		return nil, fmt.Errorf("unknown class: %s in constraints of field: %s", dummy.Class, index)
+	}
+
+	switch constraints.Strictness {
+	case field.StrictnessStrict, field.StrictnessLenient, field.StrictnessOff:
+	default:
+		return nil, fmt.Errorf("unknown strictness: %s in constraints of field: %s", dummy.Strictness, index)
+	}
+
+	if dummy.Pattern != "" {
+		pattern, err := regexp.Compile(dummy.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern in constraints of field: %s: %w", index, err)
+		}
+		constraints.Pattern = pattern
+	}
+
+	return constraints, nil
+}
+
 func importSpec(dummySpec *specDummy) (*iso8583.MessageSpec, error) {
 	if len(dummySpec.Fields) == 0 {
 		return nil, fmt.Errorf("no fields defined in spec")
 	}
 
 	spec := iso8583.MessageSpec{
-		Name:   dummySpec.Name,
-		Fields: make(map[int]field.Field),
+		Name:       dummySpec.Name,
+		Bitmapless: dummySpec.Bitmapless,
+		Fields:     make(map[int]field.Field),
 	}
 
 	for key, dummyField := range dummySpec.Fields {
@@ -278,16 +488,74 @@ func importSpec(dummySpec *specDummy) (*iso8583.MessageSpec, error) {
 		spec.Fields[index] = constructor(fieldSpec)
 	}
 
+	if len(dummySpec.Rules) > 0 {
+		spec.Rules = make(map[string]*iso8583.MessageRule, len(dummySpec.Rules))
+		for mti, dummyRule := range dummySpec.Rules {
+			spec.Rules[mti] = importRule(dummyRule)
+		}
+	}
+
 	return &spec, nil
 }
 
+func importRule(dummyRule *ruleDummy) *iso8583.MessageRule {
+	rule := &iso8583.MessageRule{}
+	if dummyRule == nil {
+		return rule
+	}
+
+	if len(dummyRule.Fields) > 0 {
+		rule.Fields = make(map[string]iso8583.Presence, len(dummyRule.Fields))
+		for path, presence := range dummyRule.Fields {
+			rule.Fields[path] = iso8583.Presence(presence)
+		}
+	}
+
+	for _, dummyCond := range dummyRule.Conditions {
+		rule.Conditions = append(rule.Conditions, iso8583.FieldCondition{
+			Field:       dummyCond.Field,
+			Presence:    iso8583.Presence(dummyCond.Presence),
+			WhenPresent: dummyCond.WhenPresent,
+			WhenAbsent:  dummyCond.WhenAbsent,
+		})
+	}
+
+	return rule
+}
+
+func exportRule(rule *iso8583.MessageRule) *ruleDummy {
+	dummy := &ruleDummy{}
+	if rule == nil {
+		return dummy
+	}
+
+	if len(rule.Fields) > 0 {
+		dummy.Fields = make(map[string]string, len(rule.Fields))
+		for path, presence := range rule.Fields {
+			dummy.Fields[path] = string(presence)
+		}
+	}
+
+	for _, cond := range rule.Conditions {
+		dummy.Conditions = append(dummy.Conditions, &conditionDummy{
+			Field:       cond.Field,
+			Presence:    string(cond.Presence),
+			WhenPresent: cond.WhenPresent,
+			WhenAbsent:  cond.WhenAbsent,
+		})
+	}
+
+	return dummy
+}
+
 func exportSpec(origSpec *iso8583.MessageSpec) (*specDummy, error) {
 	if origSpec == nil {
 		return nil, fmt.Errorf("invalid message spec")
 	}
 	dummy := &specDummy{
-		Name:   origSpec.Name,
-		Fields: map[string]*fieldDummy{},
+		Name:       origSpec.Name,
+		Bitmapless: origSpec.Bitmapless,
+		Fields:     map[string]*fieldDummy{},
 	}
 
 	for index, origField := range origSpec.Fields {
@@ -298,6 +566,13 @@ func exportSpec(origSpec *iso8583.MessageSpec) (*specDummy, error) {
 		dummy.Fields[strconv.Itoa(index)] = f
 	}
 
+	if len(origSpec.Rules) > 0 {
+		dummy.Rules = make(map[string]*ruleDummy, len(origSpec.Rules))
+		for mti, rule := range origSpec.Rules {
+			dummy.Rules[mti] = exportRule(rule)
+		}
+	}
+
 	return dummy, nil
 }
 
@@ -340,7 +615,13 @@ func exportField(internalField field.Field) (*fieldDummy, error) {
 		dummyField.Padding = dummyPad
 	}
 
-	if len(spec.Subfields) == 0 {
+	if spec.Repeat != nil {
+		repeat, err := exportRepeat(spec.Repeat)
+		if err != nil {
+			return nil, err
+		}
+		dummyField.Repeat = repeat
+	} else if len(spec.Subfields) == 0 {
 		// Encoding only applies to primitive field types
 		if spec.Enc == nil {
 			return nil, fmt.Errorf("missing required spec.Enc")
@@ -379,9 +660,88 @@ func exportField(internalField field.Field) (*fieldDummy, error) {
 	}
 	dummyField.DisableAutoExpand = spec.DisableAutoExpand
 
+	if spec.Amount != nil {
+		dummyField.Amount = &amountDummy{
+			Sign:     string(spec.Amount.Sign),
+			Exponent: spec.Amount.Exponent,
+		}
+	}
+
+	if spec.DateTime != nil {
+		dateTime, err := exportDateTime(spec.DateTime)
+		if err != nil {
+			return nil, err
+		}
+		dummyField.DateTime = dateTime
+	}
+
+	if spec.Constraints != nil {
+		dummyField.Constraints = exportConstraints(spec.Constraints)
+	}
+
 	return dummyField, nil
 }
 
+func exportRepeat(repeat *field.RepeatSpec) (*repeatDummy, error) {
+	element, err := exportField(repeat.Element)
+	if err != nil {
+		return nil, err
+	}
+
+	dummy := &repeatDummy{
+		Element: element,
+		Mode:    string(repeat.Mode),
+		Count:   repeat.Count,
+	}
+
+	if repeat.CountPref != nil {
+		dummy.CountPrefix = repeat.CountPref.Inspect()
+	}
+
+	return dummy, nil
+}
+
+func exportDateTime(dateTime *field.DateTimeSpec) (*dateTimeDummy, error) {
+	dummy := &dateTimeDummy{
+		Layout: dateTime.Layout,
+	}
+
+	if dateTime.Location != nil {
+		dummy.Location = dateTime.Location.String()
+	}
+
+	if dateTime.YearInference != nil {
+		name := getFunctionName(dateTime.YearInference)
+
+		// only the year inferences known by the importer can be exported
+		inferYear, ok := YearInferencesExtToInt[name]
+		if !ok || reflect.ValueOf(inferYear).Pointer() != reflect.ValueOf(dateTime.YearInference).Pointer() {
+			return nil, fmt.Errorf("unknown year inference: %s", name)
+		}
+
+		dummy.YearInference = name
+	}
+
+	return dummy, nil
+}
+
+func exportConstraints(constraints *field.Constraints) *constraintsDummy {
+	dummy := &constraintsDummy{
+		Class:      string(constraints.Class),
+		MinLength:  constraints.MinLength,
+		Enum:       constraints.Enum,
+		Min:        constraints.Min,
+		Max:        constraints.Max,
+		Strictness: string(constraints.Strictness),
+	}
+
+	if constraints.Pattern != nil {
+		dummy.Pattern = constraints.Pattern.String()
+	}
+
+	return dummy
+}
+
 func exportTag(tag *field.TagSpec) (*tagDummy, error) {
 	dummy := &tagDummy{
 		Length:              tag.Length,
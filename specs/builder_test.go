@@ -2,7 +2,9 @@ package specs
 
 import (
 	"os"
+	"regexp"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -50,6 +52,36 @@ func TestExampleJSONSpec(t *testing.T) {
 	require.Exactly(t, Spec87ASCII, asciiSpec)
 }
 
+func TestExampleJSONSpecs93And03(t *testing.T) {
+	tests := map[string]*iso8583.MessageSpec{
+		"spec93ascii":  Spec93ASCII,
+		"spec93binary": Spec93Binary,
+		"spec03ascii":  Spec03ASCII,
+	}
+
+	for name, spec := range tests {
+		t.Run(name, func(t *testing.T) {
+			specJSON, err := os.ReadFile("../examples/specs/" + name + ".json")
+			require.NoError(t, err)
+
+			importedSpec, err := ImportJSON(specJSON)
+			require.NoError(t, err)
+			require.Exactly(t, spec, importedSpec)
+
+			exportedJSON, err := ExportJSON(spec)
+			require.NoError(t, err)
+			require.JSONEq(t, string(specJSON), string(exportedJSON))
+
+			specYAML, err := os.ReadFile("../examples/specs/" + name + ".yaml")
+			require.NoError(t, err)
+
+			importedSpec, err = ImportYAML(specYAML)
+			require.NoError(t, err)
+			require.Exactly(t, spec, importedSpec)
+		})
+	}
+}
+
 func TestSpecWithCompositeFields(t *testing.T) {
 	testSpec := &iso8583.MessageSpec{
 		Name: "ISO 8583 v1987 ASCII",
@@ -526,6 +558,12 @@ func TestExportImportAllFieldTypes(t *testing.T) {
 				Enc:         encoding.ASCII,
 				Pref:        prefix.ASCII.LL,
 			}),
+			74: field.NewDigits(&field.Spec{
+				Length:      20,
+				Description: "Credits, Number",
+				Enc:         encoding.ASCII,
+				Pref:        prefix.ASCII.Fixed,
+			}),
 			3: field.NewBinary(&field.Spec{
 				Length:      8,
 				Description: "Binary field",
@@ -662,3 +700,327 @@ func TestExportImportEBCDIC1047Encoding(t *testing.T) {
 	require.NoError(t, err)
 	require.Exactly(t, spec, fromJSON)
 }
+
+func TestExportImportMessageRules(t *testing.T) {
+	spec := &iso8583.MessageSpec{
+		Name: "Spec with rules",
+		Fields: map[int]field.Field{
+			0:  Spec87ASCII.Fields[0],
+			1:  Spec87ASCII.Fields[1],
+			2:  Spec87ASCII.Fields[2],
+			11: Spec87ASCII.Fields[11],
+			14: Spec87ASCII.Fields[14],
+			35: Spec87ASCII.Fields[35],
+			39: Spec87ASCII.Fields[39],
+		},
+		Rules: map[string]*iso8583.MessageRule{
+			"0100": {
+				Fields: map[string]iso8583.Presence{
+					"2":  iso8583.Mandatory,
+					"11": iso8583.Mandatory,
+					"14": iso8583.Conditional,
+					"39": iso8583.Forbidden,
+				},
+				Conditions: []iso8583.FieldCondition{
+					{Field: "14", Presence: iso8583.Mandatory, WhenAbsent: []string{"35"}},
+				},
+			},
+		},
+	}
+
+	jsonData, err := ExportJSON(spec)
+	require.NoError(t, err)
+	require.Contains(t, string(jsonData), `"whenAbsent": [`)
+
+	specFromJSON, err := ImportJSON(jsonData)
+	require.NoError(t, err)
+	require.Equal(t, spec.Rules, specFromJSON.Rules)
+
+	yamlData, err := ExportYAML(spec)
+	require.NoError(t, err)
+
+	specFromYAML, err := ImportYAML(yamlData)
+	require.NoError(t, err)
+	require.Equal(t, spec.Rules, specFromYAML.Rules)
+
+	// rules loaded from JSON are applied to the messages
+	message := iso8583.NewMessage(specFromJSON)
+	message.MTI("0100")
+	require.NoError(t, message.Field(2, "4242424242424242"))
+
+	err = message.Validate()
+	require.EqualError(t, err, "message 0100 is invalid: field 11: mandatory field is missing; field 14: field is required when 35 absent")
+}
+
+func TestExportImportFieldConstraints(t *testing.T) {
+	minAmount := int64(1)
+	maxAmount := int64(999999)
+
+	spec := &iso8583.MessageSpec{
+		Name: "Spec with constraints",
+		Fields: map[int]field.Field{
+			0: Spec87ASCII.Fields[0],
+			1: Spec87ASCII.Fields[1],
+			4: field.NewString(&field.Spec{
+				Length:      12,
+				Description: "Transaction Amount",
+				Enc:         encoding.ASCII,
+				Pref:        prefix.ASCII.Fixed,
+				Pad:         padding.Left('0'),
+				Constraints: &field.Constraints{
+					Class: field.ClassNumeric,
+					Min:   &minAmount,
+					Max:   &maxAmount,
+				},
+			}),
+			25: field.NewString(&field.Spec{
+				Length:      2,
+				Description: "Point of Service Condition Code",
+				Enc:         encoding.ASCII,
+				Pref:        prefix.ASCII.Fixed,
+				Constraints: &field.Constraints{
+					Enum:       []string{"00", "01", "59"},
+					Strictness: field.StrictnessLenient,
+				},
+			}),
+			41: field.NewString(&field.Spec{
+				Length:      8,
+				Description: "Card Acceptor Terminal Identification",
+				Enc:         encoding.ASCII,
+				Pref:        prefix.ASCII.Fixed,
+				Constraints: &field.Constraints{
+					Class:     field.ClassAlphaNumericSpecial,
+					MinLength: 8,
+					Pattern:   regexp.MustCompile(`^[A-Z0-9 ]+$`),
+				},
+			}),
+		},
+	}
+
+	jsonData, err := ExportJSON(spec)
+	require.NoError(t, err)
+	require.Contains(t, string(jsonData), `"pattern": "^[A-Z0-9 ]+$"`)
+
+	specFromJSON, err := ImportJSON(jsonData)
+	require.NoError(t, err)
+
+	yamlData, err := ExportYAML(spec)
+	require.NoError(t, err)
+
+	specFromYAML, err := ImportYAML(yamlData)
+	require.NoError(t, err)
+
+	for _, imported := range []*iso8583.MessageSpec{specFromJSON, specFromYAML} {
+		for _, id := range []int{4, 25, 41} {
+			require.Equal(t, spec.Fields[id].Spec().Constraints, imported.Fields[id].Spec().Constraints)
+		}
+	}
+
+	// constraints loaded from JSON are enforced
+	message := iso8583.NewMessage(specFromJSON)
+	require.EqualError(t, message.Field(4, "-100"), "setting bytes for field 4: checking constraints: value contains characters not allowed in class n")
+	require.EqualError(t, message.Field(4, "1000000"), "setting bytes for field 4: checking constraints: value is greater than maximum 999999")
+	require.EqualError(t, message.Field(25, "02"), "setting bytes for field 25: checking constraints: value is not one of allowed values [00 01 59]")
+	require.EqualError(t, message.Field(41, "term01  "), "setting bytes for field 41: checking constraints: value does not match pattern ^[A-Z0-9 ]+$")
+	require.NoError(t, message.Field(41, "TERM01  "))
+
+	t.Run("returns error for invalid constraints", func(t *testing.T) {
+		_, err := ImportJSON([]byte(`{"fields": {"2": {"type": "String", "length": 19, "enc": "ASCII", "prefix": "ASCII.LL", "constraints": {"class": "x"}}}}`))
+		require.EqualError(t, err, "error importing field: 2. unknown class: x in constraints of field: 2")
+
+		_, err = ImportJSON([]byte(`{"fields": {"2": {"type": "String", "length": 19, "enc": "ASCII", "prefix": "ASCII.LL", "constraints": {"strictness": "loose"}}}}`))
+		require.EqualError(t, err, "error importing field: 2. unknown strictness: loose in constraints of field: 2")
+
+		_, err = ImportJSON([]byte(`{"fields": {"2": {"type": "String", "length": 19, "enc": "ASCII", "prefix": "ASCII.LL", "constraints": {"pattern": "["}}}}`))
+		require.ErrorContains(t, err, "invalid pattern in constraints of field: 2")
+	})
+}
+
+func TestExportImportBitmaplessSpec(t *testing.T) {
+	spec := &iso8583.MessageSpec{
+		Name:       "Bitmapless spec",
+		Bitmapless: true,
+		Fields: map[int]field.Field{
+			1: Spec87ASCII.Fields[2],
+			2: Spec87ASCII.Fields[4],
+		},
+	}
+
+	jsonData, err := ExportJSON(spec)
+	require.NoError(t, err)
+	require.Contains(t, string(jsonData), `"bitmapless": true`)
+
+	specFromJSON, err := ImportJSON(jsonData)
+	require.NoError(t, err)
+	require.True(t, specFromJSON.Bitmapless)
+
+	yamlData, err := ExportYAML(spec)
+	require.NoError(t, err)
+
+	specFromYAML, err := ImportYAML(yamlData)
+	require.NoError(t, err)
+	require.True(t, specFromYAML.Bitmapless)
+}
+
+func TestExportImportRepeatingField(t *testing.T) {
+	spec := &iso8583.MessageSpec{
+		Name: "Spec with repeating field",
+		Fields: map[int]field.Field{
+			0: Spec87ASCII.Fields[0],
+			1: Spec87ASCII.Fields[1],
+			54: field.NewRepeating(&field.Spec{
+				Length:      120,
+				Description: "Additional Amounts",
+				Pref:        prefix.ASCII.LLL,
+				Repeat: &field.RepeatSpec{
+					Mode:      field.RepeatCounted,
+					Count:     6,
+					CountPref: prefix.ASCII.L,
+					Element: field.NewString(&field.Spec{
+						Length:      20,
+						Description: "Additional Amount",
+						Enc:         encoding.ASCII,
+						Pref:        prefix.ASCII.Fixed,
+					}),
+				},
+			}),
+		},
+	}
+
+	jsonData, err := ExportJSON(spec)
+	require.NoError(t, err)
+	require.Contains(t, string(jsonData), `"countPrefix": "ASCII.L"`)
+
+	specFromJSON, err := ImportJSON(jsonData)
+	require.NoError(t, err)
+	require.Equal(t, spec.Fields[54].Spec(), specFromJSON.Fields[54].Spec())
+
+	yamlData, err := ExportYAML(spec)
+	require.NoError(t, err)
+
+	specFromYAML, err := ImportYAML(yamlData)
+	require.NoError(t, err)
+	require.Equal(t, spec.Fields[54].Spec(), specFromYAML.Fields[54].Spec())
+
+	t.Run("unknown mode", func(t *testing.T) {
+		_, err := ImportJSON([]byte(`{"name": "spec", "fields": {"54": {"type": "Repeating", "length": 120, "prefix": "ASCII.LLL", "repeat": {"mode": "variable", "element": {"type": "String", "length": 20, "enc": "ASCII", "prefix": "ASCII.Fixed"}}}}}`))
+		require.EqualError(t, err, "error importing field: 54. unknown mode: variable in repeat of field: 54")
+	})
+}
+
+func TestExportImportAmountField(t *testing.T) {
+	spec := &iso8583.MessageSpec{
+		Name: "Spec with amount field",
+		Fields: map[int]field.Field{
+			0: Spec87ASCII.Fields[0],
+			1: Spec87ASCII.Fields[1],
+			28: field.NewAmount(&field.Spec{
+				Length:      9,
+				Description: "Transaction Fee Amount",
+				Enc:         encoding.ASCII,
+				Pref:        prefix.ASCII.Fixed,
+				Pad:         padding.Left('0'),
+				Amount: &field.AmountSpec{
+					Sign:     field.SignCreditDebit,
+					Exponent: 2,
+				},
+			}),
+		},
+	}
+
+	jsonData, err := ExportJSON(spec)
+	require.NoError(t, err)
+	require.Contains(t, string(jsonData), `"sign": "C/D"`)
+
+	specFromJSON, err := ImportJSON(jsonData)
+	require.NoError(t, err)
+	require.Equal(t, spec.Fields[28].Spec(), specFromJSON.Fields[28].Spec())
+
+	yamlData, err := ExportYAML(spec)
+	require.NoError(t, err)
+
+	specFromYAML, err := ImportYAML(yamlData)
+	require.NoError(t, err)
+	require.Equal(t, spec.Fields[28].Spec(), specFromYAML.Fields[28].Spec())
+}
+
+func TestExportImportDateTimeField(t *testing.T) {
+	spec := &iso8583.MessageSpec{
+		Name: "Spec with date/time fields",
+		Fields: map[int]field.Field{
+			0: Spec87ASCII.Fields[0],
+			1: Spec87ASCII.Fields[1],
+			7: field.NewDateTime(&field.Spec{
+				Length:      10,
+				Description: "Transmission Date & Time",
+				Enc:         encoding.ASCII,
+				Pref:        prefix.ASCII.Fixed,
+				DateTime: &field.DateTimeSpec{
+					Layout:        "MMDDhhmmss",
+					Location:      time.UTC,
+					YearInference: field.PastYear,
+				},
+			}),
+			14: field.NewDateTime(&field.Spec{
+				Length:      4,
+				Description: "Expiration Date",
+				Enc:         encoding.ASCII,
+				Pref:        prefix.ASCII.Fixed,
+				DateTime: &field.DateTimeSpec{
+					Layout: "YYMM",
+				},
+			}),
+		},
+	}
+
+	jsonData, err := ExportJSON(spec)
+	require.NoError(t, err)
+	require.Contains(t, string(jsonData), `"yearInference": "PastYear"`)
+
+	specFromJSON, err := ImportJSON(jsonData)
+	require.NoError(t, err)
+
+	for _, id := range []int{7, 14} {
+		expected, actual := spec.Fields[id].Spec(), specFromJSON.Fields[id].Spec()
+
+		require.Equal(t, expected.DateTime.Layout, actual.DateTime.Layout)
+		require.Equal(t, expected.DateTime.Location, actual.DateTime.Location)
+		require.IsType(t, &field.DateTime{}, specFromJSON.Fields[id])
+	}
+
+	require.Equal(t, "PastYear", getFunctionName(specFromJSON.Fields[7].Spec().DateTime.YearInference))
+	require.Nil(t, specFromJSON.Fields[14].Spec().DateTime.YearInference)
+
+	t.Run("unknown year inference", func(t *testing.T) {
+		_, err := ImportJSON([]byte(`{"name": "spec", "fields": {"7": {"type": "DateTime", "length": 10, "enc": "ASCII", "prefix": "ASCII.Fixed", "dateTime": {"layout": "MMDDhhmmss", "yearInference": "NextYear"}}}}`))
+		require.EqualError(t, err, "error importing field: 7. unknown year inference: NextYear in date/time of field: 7")
+	})
+
+	t.Run("unsupported layout", func(t *testing.T) {
+		_, err := ImportJSON([]byte(`{"name": "spec", "fields": {"7": {"type": "DateTime", "length": 10, "enc": "ASCII", "prefix": "ASCII.Fixed", "dateTime": {"layout": "MMDDHHmmss"}}}}`))
+		require.EqualError(t, err, `error importing field: 7. invalid layout in date/time of field: 7: unsupported element of DateTime.Layout "MMDDHHmmss" at position 4`)
+	})
+
+	t.Run("export returns error for unknown year inference", func(t *testing.T) {
+		spec := &iso8583.MessageSpec{
+			Name: "Spec with custom year inference",
+			Fields: map[int]field.Field{
+				13: field.NewDateTime(&field.Spec{
+					Length:      4,
+					Description: "Local Transaction Date",
+					Enc:         encoding.ASCII,
+					Pref:        prefix.ASCII.Fixed,
+					DateTime: &field.DateTimeSpec{
+						Layout: "MMDD",
+						YearInference: func(t, now time.Time) time.Time {
+							return t.AddDate(now.Year(), 0, 0)
+						},
+					},
+				}),
+			},
+		}
+
+		_, err := ExportJSON(spec)
+		require.ErrorContains(t, err, "failed to export field: 13. unknown year inference: ")
+	})
+}
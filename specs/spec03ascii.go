@@ -0,0 +1,13 @@
+package specs
+
+import (
+	"github.com/moov-io/iso8583"
+)
+
+// Spec03ASCII is the ISO 8583:2003 message spec with ASCII encoded fields
+// and the hex encoded bitmap. The formats of the fields 2-128 are the same as
+// in ISO 8583:1993, while the messages use MTIs of version 2 (e.g. 2100).
+var Spec03ASCII *iso8583.MessageSpec = &iso8583.MessageSpec{
+	Name:   "ISO 8583 v2003 ASCII",
+	Fields: spec93ASCIIFields(),
+}
@@ -0,0 +1,73 @@
+package specs
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/moov-io/iso8583"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpecs93And03PackUnpack(t *testing.T) {
+	tests := []struct {
+		spec *iso8583.MessageSpec
+		mti  string
+	}{
+		{Spec93ASCII, "1100"},
+		{Spec93Binary, "1100"},
+		{Spec03ASCII, "2100"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec.Name, func(t *testing.T) {
+			fields := map[int]string{
+				2:   "4242424242424242",
+				3:   "000000",
+				4:   "100",
+				7:   "0701111844",
+				11:  "000123",
+				12:  "210701111844",
+				22:  "A10101211144",
+				24:  "100",
+				35:  "4242424242424242=2512",
+				41:  "TERM0001",
+				49:  "978",
+				52:  "0102030405060708",
+				93:  "12345",
+				127: "private data",
+			}
+
+			message := iso8583.NewMessage(tt.spec)
+			message.MTI(tt.mti)
+			for id, value := range fields {
+				require.NoError(t, message.MarshalPath(strconv.Itoa(id), value))
+			}
+
+			packed, err := message.Pack()
+			require.NoError(t, err)
+
+			unpacked := iso8583.NewMessage(tt.spec)
+			require.NoError(t, unpacked.Unpack(packed))
+
+			mti, err := unpacked.GetMTI()
+			require.NoError(t, err)
+			require.Equal(t, tt.mti, mti)
+
+			for id, value := range fields {
+				got, err := unpacked.GetString(id)
+				require.NoError(t, err)
+				require.Equal(t, value, got, "field %d", id)
+			}
+		})
+	}
+}
+
+func TestSpec03ASCIIDoesNotShareFieldsWithSpec93ASCII(t *testing.T) {
+	require.Equal(t, len(Spec93ASCII.Fields), len(Spec03ASCII.Fields))
+
+	for id, f := range Spec93ASCII.Fields {
+		require.NotSame(t, f, Spec03ASCII.Fields[id], "field %d", id)
+		require.NotSame(t, f.Spec(), Spec03ASCII.Fields[id].Spec(), "spec of field %d", id)
+		require.Equal(t, f.Spec().Description, Spec03ASCII.Fields[id].Spec().Description, "field %d", id)
+	}
+}
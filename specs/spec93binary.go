@@ -0,0 +1,797 @@
+package specs
+
+import (
+	"github.com/moov-io/iso8583"
+	"github.com/moov-io/iso8583/encoding"
+	"github.com/moov-io/iso8583/field"
+	"github.com/moov-io/iso8583/padding"
+	"github.com/moov-io/iso8583/prefix"
+)
+
+// Spec93Binary is the ISO 8583:1993 message spec with BCD encoded numeric
+// fields and lengths, ASCII encoded alphanumeric fields and the binary
+// bitmap.
+var Spec93Binary *iso8583.MessageSpec = &iso8583.MessageSpec{
+	Name: "ISO 8583 v1993 Binary",
+	Fields: map[int]field.Field{
+		0: field.NewString(&field.Spec{
+			Length:      4,
+			Description: "Message Type Indicator",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+		}),
+		1: field.NewBitmap(&field.Spec{
+			Length:      8,
+			Description: "Bitmap",
+			Enc:         encoding.Binary,
+			Pref:        prefix.Binary.Fixed,
+		}),
+		2: field.NewString(&field.Spec{
+			Length:      19,
+			Description: "Primary Account Number",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.LL,
+		}),
+		3: field.NewString(&field.Spec{
+			Length:      6,
+			Description: "Processing Code",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+		}),
+		4: field.NewString(&field.Spec{
+			Length:      12,
+			Description: "Transaction Amount",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+			Pad:         padding.Left('0'),
+		}),
+		5: field.NewString(&field.Spec{
+			Length:      12,
+			Description: "Reconciliation Amount",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+			Pad:         padding.Left('0'),
+		}),
+		6: field.NewString(&field.Spec{
+			Length:      12,
+			Description: "Cardholder Billing Amount",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+			Pad:         padding.Left('0'),
+		}),
+		7: field.NewString(&field.Spec{
+			Length:      10,
+			Description: "Transmission Date & Time",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+		}),
+		8: field.NewString(&field.Spec{
+			Length:      8,
+			Description: "Cardholder Billing Fee Amount",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+			Pad:         padding.Left('0'),
+		}),
+		9: field.NewString(&field.Spec{
+			Length:      8,
+			Description: "Reconciliation Conversion Rate",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+		}),
+		10: field.NewString(&field.Spec{
+			Length:      8,
+			Description: "Cardholder Billing Conversion Rate",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+		}),
+		11: field.NewString(&field.Spec{
+			Length:      6,
+			Description: "Systems Trace Audit Number (STAN)",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+		}),
+		12: field.NewString(&field.Spec{
+			Length:      12,
+			Description: "Local Transaction Date & Time",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+		}),
+		13: field.NewString(&field.Spec{
+			Length:      4,
+			Description: "Effective Date",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+		}),
+		14: field.NewString(&field.Spec{
+			Length:      4,
+			Description: "Expiration Date",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+		}),
+		15: field.NewString(&field.Spec{
+			Length:      6,
+			Description: "Settlement Date",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+		}),
+		16: field.NewString(&field.Spec{
+			Length:      4,
+			Description: "Conversion Date",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+		}),
+		17: field.NewString(&field.Spec{
+			Length:      4,
+			Description: "Capture Date",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+		}),
+		18: field.NewString(&field.Spec{
+			Length:      4,
+			Description: "Merchant Type",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+		}),
+		19: field.NewString(&field.Spec{
+			Length:      3,
+			Description: "Acquiring Institution Country Code",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+		}),
+		20: field.NewString(&field.Spec{
+			Length:      3,
+			Description: "PAN Extended Country Code",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+		}),
+		21: field.NewString(&field.Spec{
+			Length:      3,
+			Description: "Forwarding Institution Country Code",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+		}),
+		22: field.NewString(&field.Spec{
+			Length:      12,
+			Description: "Point of Service Data Code",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.ASCII.Fixed,
+		}),
+		23: field.NewString(&field.Spec{
+			Length:      3,
+			Description: "Card Sequence Number",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+		}),
+		24: field.NewString(&field.Spec{
+			Length:      3,
+			Description: "Function Code",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+		}),
+		25: field.NewString(&field.Spec{
+			Length:      4,
+			Description: "Message Reason Code",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+		}),
+		26: field.NewString(&field.Spec{
+			Length:      4,
+			Description: "Card Acceptor Business Code",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+		}),
+		27: field.NewString(&field.Spec{
+			Length:      1,
+			Description: "Approval Code Length",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+		}),
+		28: field.NewString(&field.Spec{
+			Length:      6,
+			Description: "Reconciliation Date",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+		}),
+		29: field.NewString(&field.Spec{
+			Length:      3,
+			Description: "Reconciliation Indicator",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+		}),
+		30: field.NewString(&field.Spec{
+			Length:      24,
+			Description: "Original Amounts",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+			Pad:         padding.Left('0'),
+		}),
+		31: field.NewString(&field.Spec{
+			Length:      99,
+			Description: "Acquirer Reference Data",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.BCD.LL,
+		}),
+		32: field.NewString(&field.Spec{
+			Length:      11,
+			Description: "Acquiring Institution Identification Code",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.LL,
+		}),
+		33: field.NewString(&field.Spec{
+			Length:      11,
+			Description: "Forwarding Institution Identification Code",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.LL,
+		}),
+		34: field.NewString(&field.Spec{
+			Length:      28,
+			Description: "Extended Primary Account Number",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.BCD.LL,
+		}),
+		35: field.NewString(&field.Spec{
+			Length:      37,
+			Description: "Track 2 Data",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.BCD.LL,
+		}),
+		36: field.NewString(&field.Spec{
+			Length:      104,
+			Description: "Track 3 Data",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.BCD.LLL,
+		}),
+		37: field.NewString(&field.Spec{
+			Length:      12,
+			Description: "Retrieval Reference Number",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.ASCII.Fixed,
+		}),
+		38: field.NewString(&field.Spec{
+			Length:      6,
+			Description: "Approval Code",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.ASCII.Fixed,
+		}),
+		39: field.NewString(&field.Spec{
+			Length:      3,
+			Description: "Action Code",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+		}),
+		40: field.NewString(&field.Spec{
+			Length:      3,
+			Description: "Service Code",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+		}),
+		41: field.NewString(&field.Spec{
+			Length:      8,
+			Description: "Card Acceptor Terminal Identification",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.ASCII.Fixed,
+		}),
+		42: field.NewString(&field.Spec{
+			Length:      15,
+			Description: "Card Acceptor Identification Code",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.ASCII.Fixed,
+		}),
+		43: field.NewString(&field.Spec{
+			Length:      99,
+			Description: "Card Acceptor Name/Location",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.BCD.LL,
+		}),
+		44: field.NewString(&field.Spec{
+			Length:      99,
+			Description: "Additional Response Data",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.BCD.LL,
+		}),
+		45: field.NewString(&field.Spec{
+			Length:      76,
+			Description: "Track 1 Data",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.BCD.LL,
+		}),
+		46: field.NewString(&field.Spec{
+			Length:      204,
+			Description: "Fees Amounts",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.BCD.LLL,
+		}),
+		47: field.NewString(&field.Spec{
+			Length:      999,
+			Description: "Additional Data - National",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.BCD.LLL,
+		}),
+		48: field.NewString(&field.Spec{
+			Length:      999,
+			Description: "Additional Data - Private",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.BCD.LLL,
+		}),
+		49: field.NewString(&field.Spec{
+			Length:      3,
+			Description: "Transaction Currency Code",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.ASCII.Fixed,
+		}),
+		50: field.NewString(&field.Spec{
+			Length:      3,
+			Description: "Reconciliation Currency Code",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.ASCII.Fixed,
+		}),
+		51: field.NewString(&field.Spec{
+			Length:      3,
+			Description: "Cardholder Billing Currency Code",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.ASCII.Fixed,
+		}),
+		52: field.NewBinary(&field.Spec{
+			Length:      8,
+			Description: "PIN Data",
+			Enc:         encoding.Binary,
+			Pref:        prefix.Binary.Fixed,
+		}),
+		53: field.NewBinary(&field.Spec{
+			Length:      48,
+			Description: "Security Related Control Information",
+			Enc:         encoding.Binary,
+			Pref:        prefix.BCD.LL,
+		}),
+		54: field.NewString(&field.Spec{
+			Length:      120,
+			Description: "Additional Amounts",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.BCD.LLL,
+		}),
+		55: field.NewBinary(&field.Spec{
+			Length:      255,
+			Description: "Integrated Circuit Card (ICC) Related Data",
+			Enc:         encoding.Binary,
+			Pref:        prefix.BCD.LLL,
+		}),
+		56: field.NewString(&field.Spec{
+			Length:      35,
+			Description: "Original Data Elements",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.LL,
+		}),
+		57: field.NewString(&field.Spec{
+			Length:      3,
+			Description: "Authorization Life Cycle Code",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+		}),
+		58: field.NewString(&field.Spec{
+			Length:      11,
+			Description: "Authorizing Agent Institution Identification Code",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.LL,
+		}),
+		59: field.NewString(&field.Spec{
+			Length:      999,
+			Description: "Transport Data",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.BCD.LLL,
+		}),
+		60: field.NewString(&field.Spec{
+			Length:      999,
+			Description: "Reserved (National)",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.BCD.LLL,
+		}),
+		61: field.NewString(&field.Spec{
+			Length:      999,
+			Description: "Reserved (National)",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.BCD.LLL,
+		}),
+		62: field.NewString(&field.Spec{
+			Length:      999,
+			Description: "Reserved (National)",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.BCD.LLL,
+		}),
+		63: field.NewString(&field.Spec{
+			Length:      999999,
+			Description: "Reserved (Private)",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.BCD.LLLLLL,
+		}),
+		64: field.NewBinary(&field.Spec{
+			Length:      8,
+			Description: "Message Authentication Code (MAC)",
+			Enc:         encoding.Binary,
+			Pref:        prefix.Binary.Fixed,
+		}),
+		66: field.NewString(&field.Spec{
+			Length:      204,
+			Description: "Original Fees Amounts",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.BCD.LLL,
+		}),
+		67: field.NewString(&field.Spec{
+			Length:      2,
+			Description: "Extended Payment Data",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+		}),
+		68: field.NewString(&field.Spec{
+			Length:      3,
+			Description: "Receiving Institution Country Code",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+		}),
+		69: field.NewString(&field.Spec{
+			Length:      3,
+			Description: "Settlement Institution Country Code",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+		}),
+		70: field.NewString(&field.Spec{
+			Length:      3,
+			Description: "Authorizing Agent Institution Country Code",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+		}),
+		71: field.NewString(&field.Spec{
+			Length:      8,
+			Description: "Message Number",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+		}),
+		72: field.NewString(&field.Spec{
+			Length:      999,
+			Description: "Data Record",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.BCD.LLL,
+		}),
+		73: field.NewString(&field.Spec{
+			Length:      6,
+			Description: "Action Date",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+		}),
+		74: field.NewString(&field.Spec{
+			Length:      10,
+			Description: "Credits, Number",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+		}),
+		75: field.NewString(&field.Spec{
+			Length:      10,
+			Description: "Credits Reversal, Number",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+		}),
+		76: field.NewString(&field.Spec{
+			Length:      10,
+			Description: "Debits, Number",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+		}),
+		77: field.NewString(&field.Spec{
+			Length:      10,
+			Description: "Debits Reversal, Number",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+		}),
+		78: field.NewString(&field.Spec{
+			Length:      10,
+			Description: "Transfer, Number",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+		}),
+		79: field.NewString(&field.Spec{
+			Length:      10,
+			Description: "Transfer Reversal, Number",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+		}),
+		80: field.NewString(&field.Spec{
+			Length:      10,
+			Description: "Inquiries, Number",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+		}),
+		81: field.NewString(&field.Spec{
+			Length:      10,
+			Description: "Authorizations, Number",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+		}),
+		82: field.NewString(&field.Spec{
+			Length:      10,
+			Description: "Inquiries Reversal, Number",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+		}),
+		83: field.NewString(&field.Spec{
+			Length:      10,
+			Description: "Payments, Number",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+		}),
+		84: field.NewString(&field.Spec{
+			Length:      10,
+			Description: "Payments Reversal, Number",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+		}),
+		85: field.NewString(&field.Spec{
+			Length:      10,
+			Description: "Fee Collections, Number",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+		}),
+		86: field.NewString(&field.Spec{
+			Length:      16,
+			Description: "Credits, Amount",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+			Pad:         padding.Left('0'),
+		}),
+		87: field.NewString(&field.Spec{
+			Length:      16,
+			Description: "Credits Reversal, Amount",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+			Pad:         padding.Left('0'),
+		}),
+		88: field.NewString(&field.Spec{
+			Length:      16,
+			Description: "Debits, Amount",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+			Pad:         padding.Left('0'),
+		}),
+		89: field.NewString(&field.Spec{
+			Length:      16,
+			Description: "Debits Reversal, Amount",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+			Pad:         padding.Left('0'),
+		}),
+		90: field.NewString(&field.Spec{
+			Length:      10,
+			Description: "Authorizations Reversal, Number",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+		}),
+		91: field.NewString(&field.Spec{
+			Length:      3,
+			Description: "Transaction Destination Institution Country Code",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+		}),
+		92: field.NewString(&field.Spec{
+			Length:      3,
+			Description: "Transaction Originator Institution Country Code",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+		}),
+		93: field.NewString(&field.Spec{
+			Length:      11,
+			Description: "Transaction Destination Institution Identification Code",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.LL,
+		}),
+		94: field.NewString(&field.Spec{
+			Length:      11,
+			Description: "Transaction Originator Institution Identification Code",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.LL,
+		}),
+		95: field.NewString(&field.Spec{
+			Length:      99,
+			Description: "Card Issuer Reference Data",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.BCD.LL,
+		}),
+		96: field.NewBinary(&field.Spec{
+			Length:      999,
+			Description: "Key Management Data",
+			Enc:         encoding.Binary,
+			Pref:        prefix.BCD.LLL,
+		}),
+		97: field.NewString(&field.Spec{
+			Length:      17,
+			Description: "Net Reconciliation Amount",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.ASCII.Fixed,
+		}),
+		98: field.NewString(&field.Spec{
+			Length:      25,
+			Description: "Payee",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.ASCII.Fixed,
+		}),
+		99: field.NewString(&field.Spec{
+			Length:      11,
+			Description: "Settlement Institution Identification Code",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.BCD.LL,
+		}),
+		100: field.NewString(&field.Spec{
+			Length:      11,
+			Description: "Receiving Institution Identification Code",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.LL,
+		}),
+		101: field.NewString(&field.Spec{
+			Length:      17,
+			Description: "File Name",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.BCD.LL,
+		}),
+		102: field.NewString(&field.Spec{
+			Length:      28,
+			Description: "Account Identification 1",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.BCD.LL,
+		}),
+		103: field.NewString(&field.Spec{
+			Length:      28,
+			Description: "Account Identification 2",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.BCD.LL,
+		}),
+		104: field.NewString(&field.Spec{
+			Length:      100,
+			Description: "Transaction Description",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.BCD.LLL,
+		}),
+		105: field.NewString(&field.Spec{
+			Length:      16,
+			Description: "Credits Chargeback, Amount",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+			Pad:         padding.Left('0'),
+		}),
+		106: field.NewString(&field.Spec{
+			Length:      16,
+			Description: "Debits Chargeback, Amount",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+			Pad:         padding.Left('0'),
+		}),
+		107: field.NewString(&field.Spec{
+			Length:      10,
+			Description: "Credits Chargeback, Number",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+		}),
+		108: field.NewString(&field.Spec{
+			Length:      10,
+			Description: "Debits Chargeback, Number",
+			Enc:         encoding.BCD,
+			Pref:        prefix.BCD.Fixed,
+		}),
+		109: field.NewString(&field.Spec{
+			Length:      84,
+			Description: "Credits Fee Amounts",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.BCD.LL,
+		}),
+		110: field.NewString(&field.Spec{
+			Length:      84,
+			Description: "Debits Fee Amounts",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.BCD.LL,
+		}),
+		111: field.NewString(&field.Spec{
+			Length:      999,
+			Description: "Reserved (ISO)",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.BCD.LLL,
+		}),
+		112: field.NewString(&field.Spec{
+			Length:      999,
+			Description: "Reserved (ISO)",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.BCD.LLL,
+		}),
+		113: field.NewString(&field.Spec{
+			Length:      999,
+			Description: "Reserved (ISO)",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.BCD.LLL,
+		}),
+		114: field.NewString(&field.Spec{
+			Length:      999,
+			Description: "Reserved (ISO)",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.BCD.LLL,
+		}),
+		115: field.NewString(&field.Spec{
+			Length:      999,
+			Description: "Reserved (ISO)",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.BCD.LLL,
+		}),
+		116: field.NewString(&field.Spec{
+			Length:      999,
+			Description: "Reserved (National)",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.BCD.LLL,
+		}),
+		117: field.NewString(&field.Spec{
+			Length:      999,
+			Description: "Reserved (National)",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.BCD.LLL,
+		}),
+		118: field.NewString(&field.Spec{
+			Length:      999,
+			Description: "Reserved (National)",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.BCD.LLL,
+		}),
+		119: field.NewString(&field.Spec{
+			Length:      999,
+			Description: "Reserved (National)",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.BCD.LLL,
+		}),
+		120: field.NewString(&field.Spec{
+			Length:      999,
+			Description: "Reserved (National)",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.BCD.LLL,
+		}),
+		121: field.NewString(&field.Spec{
+			Length:      999,
+			Description: "Reserved (National)",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.BCD.LLL,
+		}),
+		122: field.NewString(&field.Spec{
+			Length:      999,
+			Description: "Reserved (National)",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.BCD.LLL,
+		}),
+		123: field.NewString(&field.Spec{
+			Length:      999999,
+			Description: "Reserved (Private)",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.BCD.LLLLLL,
+		}),
+		124: field.NewString(&field.Spec{
+			Length:      999999,
+			Description: "Reserved (Private)",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.BCD.LLLLLL,
+		}),
+		125: field.NewString(&field.Spec{
+			Length:      999999,
+			Description: "Reserved (Private)",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.BCD.LLLLLL,
+		}),
+		126: field.NewString(&field.Spec{
+			Length:      999999,
+			Description: "Reserved (Private)",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.BCD.LLLLLL,
+		}),
+		127: field.NewString(&field.Spec{
+			Length:      999999,
+			Description: "Reserved (Private)",
+			Enc:         encoding.ASCII,
+			Pref:        prefix.BCD.LLLLLL,
+		}),
+		128: field.NewBinary(&field.Spec{
+			Length:      8,
+			Description: "Message Authentication Code (MAC)",
+			Enc:         encoding.Binary,
+			Pref:        prefix.Binary.Fixed,
+		}),
+	},
+}
@@ -0,0 +1,262 @@
+package iso8583
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+
+	iso8583errors "github.com/moov-io/iso8583/errors"
+	"github.com/moov-io/iso8583/field"
+)
+
+type unpackOptions struct {
+	lazy             bool
+	preserveOriginal bool
+	lenient          bool
+}
+
+// UnpackOption configures how the message is unpacked by UnpackWithOptions.
+type UnpackOption func(*unpackOptions)
+
+// WithLazyUnpacking makes the message record the bytes of each field during
+// unpacking and decode the field (and its subfields) only when it's accessed
+// for the first time, e.g. by GetField, GetString or Unmarshal. Fields that
+// were never accessed are packed using their original bytes.
+//
+// The MTI and the bitmap are always decoded. Fields which length can't be
+// determined without decoding them (see field.PeekLen) are decoded during
+// unpacking as well.
+//
+// As the values of lazy fields are validated only when they are decoded,
+// errors of such fields are returned by the methods that access them.
+// GetField returns nil and GetFields omits fields that fail to decode.
+func WithLazyUnpacking() UnpackOption {
+	return func(o *unpackOptions) {
+		o.lazy = true
+	}
+}
+
+// WithOriginalBytes makes the message remember the original bytes of each
+// unpacked field. When the message is packed, fields that were not modified
+// are packed using their original bytes instead of the normalized output of
+// their encoders and paddings (e.g. lower case hex, different padding or
+// order of TLV tags), so the packed message matches the unpacked one
+// byte-for-byte except for the modified fields. It's useful for proxies that
+// modify a few fields and must keep the rest of the message intact (e.g.
+// for MAC verification).
+//
+// The field is considered modified if it packs into bytes that differ from
+// the ones it packed into right after unpacking. Fields that are set again
+// (e.g. using Field or Marshal) or unset are considered modified as well.
+// Original bytes of composite subfields are preserved too (see
+// field.Composite.SetPreserveOriginalBytes).
+func WithOriginalBytes() UnpackOption {
+	return func(o *unpackOptions) {
+		o.preserveOriginal = true
+	}
+}
+
+// WithLenientUnpacking makes the message continue unpacking when its fields
+// fail to unpack, as long as the length of the failed field can be
+// determined without decoding it (see field.PeekLen). Subfields of composite
+// fields are unpacked leniently as well (see
+// field.Composite.SetLenientUnpacking).
+//
+// UnpackWithOptions then returns errors of all failed fields joined together.
+// Use errors.UnpackErrors to get them as a list of *errors.UnpackError, with
+// FieldIDs returning the path of each failed field. Fields that were unpacked
+// successfully stay populated, so the message can be inspected (e.g. using
+// Describe). Failed fields are not set, unless they are composites with some
+// of their subfields populated.
+func WithLenientUnpacking() UnpackOption {
+	return func(o *unpackOptions) {
+		o.lenient = true
+	}
+}
+
+// UnpackWithOptions unpacks the message from the given byte slice using the
+// provided options. Like Unpack, it returns an error of type *UnpackError.
+func (m *Message) UnpackWithOptions(src []byte, opts ...UnpackOption) error {
+	options := unpackOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if options.lazy || options.preserveOriginal {
+		// lazy fields and original bytes reference the unpacked bytes, so
+		// we make a copy in case the caller reuses src
+		src = bytes.Clone(src)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.wrapErrorUnpack(src, options)
+}
+
+// unpackLazyField records the bytes of the field i located at the beginning
+// of data. It returns false if the field length can't be determined without
+// decoding the field.
+func (m *Message) unpackLazyField(i int, data []byte) (int, bool, error) {
+	specField, ok := m.spec.Fields[i]
+	if !ok {
+		return 0, false, fmt.Errorf("creating field %d: field %d is not defined in the spec", i, i)
+	}
+
+	n, ok, err := field.PeekLen(specField, data)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to unpack field %d (%s): %w", i, specField.Spec().Description, err)
+	}
+
+	if !ok {
+		return 0, false, nil
+	}
+
+	if m.lazyFields == nil {
+		m.lazyFields = make(map[int][]byte)
+	}
+
+	// limit capacity so the field can't modify the bytes of other fields
+	m.lazyFields[i] = data[:n:n]
+
+	return n, true, nil
+}
+
+// fieldByID returns the field with the given ID or nil if the field is not
+// set. The field that was unpacked lazily is decoded first.
+func (m *Message) fieldByID(id int) (field.Field, error) {
+	if f := m.fields[id]; f != nil {
+		return f, nil
+	}
+
+	raw, ok := m.lazyFields[id]
+	if !ok {
+		return nil, nil
+	}
+
+	f := field.NewInstanceOf(m.spec.Fields[id])
+	m.preserveOriginalSubfields(f)
+
+	read, err := f.Unpack(raw)
+	if err == nil && read != len(raw) {
+		err = fmt.Errorf("read %d bytes instead of %d", read, len(raw))
+	}
+
+	if err != nil {
+		return nil, &iso8583errors.UnpackError{
+			Err:        fmt.Errorf("failed to unpack field %d (%s): %w", id, f.Spec().Description, err),
+			FieldID:    strconv.Itoa(id),
+			RawMessage: raw,
+		}
+	}
+
+	delete(m.lazyFields, id)
+	m.fields[id] = f
+	m.rememberOriginal(id, f, raw)
+
+	return f, nil
+}
+
+// decodeLazyFields decodes all lazily unpacked fields. Fields that fail to
+// decode are left as they are.
+func (m *Message) decodeLazyFields() {
+	for id := range m.lazyFields {
+		_, _ = m.fieldByID(id)
+	}
+}
+
+// originalField holds the bytes the field was unpacked from and the bytes it
+// was packed into right after unpacking.
+type originalField struct {
+	raw    []byte
+	packed []byte
+}
+
+// pack returns the original bytes of the field if packed matches the bytes
+// the field was packed into right after unpacking.
+func (o originalField) pack(packed []byte) []byte {
+	if bytes.Equal(packed, o.packed) {
+		return o.raw
+	}
+
+	return packed
+}
+
+// rememberOriginal stores the original bytes of the unpacked field if the
+// message was unpacked with WithOriginalBytes.
+func (m *Message) rememberOriginal(id int, f field.Field, raw []byte) {
+	// the field may be not set if it failed to unpack in lenient mode
+	if _, ok := m.fields[id]; m.originalFields == nil || !ok {
+		return
+	}
+
+	packed, err := f.Pack()
+	if err != nil {
+		// the field will fail to pack anyway
+		return
+	}
+
+	m.originalFields[id] = originalField{
+		raw:    raw,
+		packed: packed,
+	}
+}
+
+// preserveOriginalSubfields makes the composite field remember the original
+// bytes of its subfields if the message was unpacked with WithOriginalBytes.
+func (m *Message) preserveOriginalSubfields(f field.Field) {
+	if m.originalFields == nil {
+		return
+	}
+
+	if composite, ok := f.(*field.Composite); ok {
+		composite.SetPreserveOriginalBytes(true)
+	}
+}
+
+// recoverField is called when the field fails to unpack with err. In lenient
+// mode it records the error and returns the length of the field, so
+// unpacking can continue. Otherwise, or if the length of the field can't be
+// determined, err is returned.
+func (m *Message) recoverField(id int, f field.Field, data []byte, err error, opts unpackOptions) (int, error) {
+	if !opts.lenient {
+		return 0, err
+	}
+
+	n, ok, peekErr := field.PeekLen(f, data)
+	if peekErr != nil || !ok {
+		return 0, err
+	}
+
+	// report errors of the composite subfields separately
+	causes := []error{err}
+	if unpackErrs := iso8583errors.UnpackErrors(err); len(unpackErrs) > 1 {
+		causes = causes[:0]
+		for _, unpackErr := range unpackErrs {
+			causes = append(causes, unpackErr)
+		}
+	}
+
+	for _, cause := range causes {
+		m.unpackErrs = append(m.unpackErrs, &iso8583errors.UnpackError{
+			Err:        fmt.Errorf("failed to unpack field %d (%s): %w", id, f.Spec().Description, cause),
+			FieldID:    strconv.Itoa(id),
+			RawMessage: data[:n],
+		})
+	}
+
+	// keep the composite with the subfields that were unpacked
+	if container, ok := f.(FieldContainer); !ok || len(container.GetSubfields()) == 0 {
+		delete(m.fields, id)
+	}
+
+	return n, nil
+}
+
+// unpackSubfieldsLeniently makes the composite field continue unpacking when
+// its subfields fail to unpack.
+func (m *Message) unpackSubfieldsLeniently(f field.Field) {
+	if composite, ok := f.(*field.Composite); ok {
+		composite.SetLenientUnpacking(true)
+	}
+}
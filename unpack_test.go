@@ -0,0 +1,476 @@
+package iso8583
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/moov-io/iso8583/encoding"
+	iso8583errors "github.com/moov-io/iso8583/errors"
+	"github.com/moov-io/iso8583/field"
+	"github.com/moov-io/iso8583/padding"
+	"github.com/moov-io/iso8583/prefix"
+	"github.com/moov-io/iso8583/sort"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnpackWithLazyUnpacking(t *testing.T) {
+	spec := &MessageSpec{
+		Fields: map[int]field.Field{
+			0: field.NewString(&field.Spec{
+				Length:      4,
+				Description: "Message Type Indicator",
+				Enc:         encoding.ASCII,
+				Pref:        prefix.ASCII.Fixed,
+			}),
+			1: field.NewBitmap(&field.Spec{
+				Length:      8,
+				Description: "Bitmap",
+				Enc:         encoding.BytesToASCIIHex,
+				Pref:        prefix.Hex.Fixed,
+			}),
+			2: field.NewString(&field.Spec{
+				Length:      19,
+				Description: "Primary Account Number",
+				Enc:         encoding.ASCII,
+				Pref:        prefix.ASCII.LL,
+			}),
+			3: field.NewNumeric(&field.Spec{
+				Length:      6,
+				Description: "Processing Code",
+				Enc:         encoding.ASCII,
+				Pref:        prefix.ASCII.Fixed,
+			}),
+			4: field.NewString(&field.Spec{
+				Length:      12,
+				Description: "Transaction Amount",
+				Enc:         encoding.ASCII,
+				Pref:        prefix.ASCII.Fixed,
+				Pad:         padding.Left('0'),
+			}),
+			48: field.NewComposite(&field.Spec{
+				Length:      999,
+				Description: "Additional Data",
+				Pref:        prefix.ASCII.LLL,
+				Tag: &field.TagSpec{
+					Length: 2,
+					Enc:    encoding.ASCII,
+					Sort:   sort.StringsByInt,
+				},
+				Subfields: map[string]field.Field{
+					"01": field.NewString(&field.Spec{
+						Length:      2,
+						Description: "Subfield 1",
+						Enc:         encoding.ASCII,
+						Pref:        prefix.ASCII.Fixed,
+					}),
+					"02": field.NewString(&field.Spec{
+						Length:      10,
+						Description: "Subfield 2",
+						Enc:         encoding.ASCII,
+						Pref:        prefix.ASCII.LL,
+					}),
+				},
+			}),
+		},
+	}
+
+	type additionalData struct {
+		F1 *field.String `index:"01"`
+		F2 *field.String `index:"02"`
+	}
+
+	type authorizationRequest struct {
+		PAN            *field.String   `index:"2"`
+		ProcessingCode *field.Numeric  `index:"3"`
+		AdditionalData *additionalData `index:"48"`
+	}
+
+	packed := func(t *testing.T) []byte {
+		t.Helper()
+
+		message := NewMessage(spec)
+		message.MTI("0100")
+		require.NoError(t, message.Field(2, "4242424242424242"))
+		require.NoError(t, message.Field(3, "123456"))
+		require.NoError(t, message.Field(4, "100"))
+		require.NoError(t, message.MarshalPath("48.01", "AB"))
+		require.NoError(t, message.MarshalPath("48.02", "hello"))
+
+		rawMsg, err := message.Pack()
+		require.NoError(t, err)
+
+		return rawMsg
+	}
+
+	t.Run("decodes fields on first access", func(t *testing.T) {
+		message := NewMessage(spec)
+		require.NoError(t, message.UnpackWithOptions(packed(t), WithLazyUnpacking()))
+
+		// only MTI and bitmap are decoded
+		require.Len(t, message.fields, 2)
+		require.Len(t, message.lazyFields, 4)
+
+		pan, err := message.GetString(2)
+		require.NoError(t, err)
+		require.Equal(t, "4242424242424242", pan)
+		require.Len(t, message.lazyFields, 3)
+
+		var subfield string
+		require.NoError(t, message.UnmarshalPath("48.02", &subfield))
+		require.Equal(t, "hello", subfield)
+		require.Len(t, message.lazyFields, 2)
+
+		data := &authorizationRequest{}
+		require.NoError(t, message.Unmarshal(data))
+		require.Equal(t, "4242424242424242", data.PAN.Value())
+		require.Equal(t, int64(123456), data.ProcessingCode.Value())
+		require.Equal(t, "AB", data.AdditionalData.F1.Value())
+		require.Len(t, message.lazyFields, 1)
+
+		require.Len(t, message.GetFields(), 6)
+		require.Empty(t, message.lazyFields)
+	})
+
+	t.Run("packs untouched fields using original bytes", func(t *testing.T) {
+		rawMsg := packed(t)
+
+		message := NewMessage(spec)
+		require.NoError(t, message.UnpackWithOptions(rawMsg, WithLazyUnpacking()))
+
+		repacked, err := message.Pack()
+		require.NoError(t, err)
+		require.Equal(t, rawMsg, repacked)
+
+		packedLen, err := message.PackedLen()
+		require.NoError(t, err)
+		require.Equal(t, len(rawMsg), packedLen)
+
+		// modified and unset fields are packed as usual
+		require.NoError(t, message.Field(4, "200"))
+		message.UnsetField(2)
+
+		repacked, err = message.Pack()
+		require.NoError(t, err)
+
+		expected := NewMessage(spec)
+		require.NoError(t, expected.Unpack(rawMsg))
+		require.NoError(t, expected.Field(4, "200"))
+		expected.UnsetField(2)
+
+		expectedMsg, err := expected.Pack()
+		require.NoError(t, err)
+		require.Equal(t, expectedMsg, repacked)
+	})
+
+	t.Run("returns error when message is truncated", func(t *testing.T) {
+		rawMsg := packed(t)
+
+		message := NewMessage(spec)
+		err := message.UnpackWithOptions(rawMsg[:len(rawMsg)-1], WithLazyUnpacking())
+		require.Error(t, err)
+
+		var unpackError *iso8583errors.UnpackError
+		require.ErrorAs(t, err, &unpackError)
+		require.Equal(t, "48", unpackError.FieldID)
+	})
+
+	t.Run("returns error when field fails to decode on access", func(t *testing.T) {
+		rawMsg := packed(t)
+
+		// corrupt the processing code
+		idx := bytes.Index(rawMsg, []byte("123456"))
+		require.Positive(t, idx)
+		rawMsg[idx+2] = 'X'
+
+		message := NewMessage(spec)
+		require.NoError(t, message.UnpackWithOptions(rawMsg, WithLazyUnpacking()))
+
+		_, err := message.GetString(3)
+		require.Error(t, err)
+
+		var unpackError *iso8583errors.UnpackError
+		require.ErrorAs(t, err, &unpackError)
+		require.Equal(t, "3", unpackError.FieldID)
+		require.Contains(t, err.Error(), "failed to unpack field 3 (Processing Code)")
+
+		require.Nil(t, message.GetField(3))
+
+		// other fields are still available
+		pan, err := message.GetString(2)
+		require.NoError(t, err)
+		require.Equal(t, "4242424242424242", pan)
+	})
+}
+
+func TestUnpackWithOriginalBytes(t *testing.T) {
+	spec := &MessageSpec{
+		Fields: map[int]field.Field{
+			0: field.NewString(&field.Spec{
+				Length:      4,
+				Description: "Message Type Indicator",
+				Enc:         encoding.ASCII,
+				Pref:        prefix.ASCII.Fixed,
+			}),
+			1: field.NewBitmap(&field.Spec{
+				Length:      8,
+				Description: "Bitmap",
+				Enc:         encoding.BytesToASCIIHex,
+				Pref:        prefix.Hex.Fixed,
+			}),
+			2: field.NewNumeric(&field.Spec{
+				Length:      19,
+				Description: "Primary Account Number",
+				Enc:         encoding.ASCII,
+				Pref:        prefix.ASCII.LL,
+			}),
+			48: field.NewComposite(&field.Spec{
+				Length:      999,
+				Description: "Additional Data",
+				Pref:        prefix.ASCII.LLL,
+				Tag: &field.TagSpec{
+					Length: 2,
+					Enc:    encoding.ASCII,
+					Sort:   sort.StringsByInt,
+				},
+				Subfields: map[string]field.Field{
+					"01": field.NewString(&field.Spec{
+						Length:      10,
+						Description: "Subfield 1",
+						Enc:         encoding.ASCII,
+						Pref:        prefix.ASCII.LL,
+					}),
+					"02": field.NewString(&field.Spec{
+						Length:      10,
+						Description: "Subfield 2",
+						Enc:         encoding.ASCII,
+						Pref:        prefix.ASCII.LL,
+					}),
+				},
+			}),
+			52: field.NewHex(&field.Spec{
+				Length:      8,
+				Description: "PIN Data",
+				Enc:         encoding.BytesToASCIIHex,
+				Pref:        prefix.ASCII.Fixed,
+			}),
+		},
+	}
+
+	// field 2 has leading zero, subfields of field 48 are not sorted and
+	// field 52 is in lower case, so the message is not packed into the
+	// same bytes by default
+	rawMsg := []byte("0100" + "4000000000011000" +
+		"040100" +
+		"015" + "0205hello" + "0102AB" +
+		"0a0b0c0d0e0f1011")
+
+	t.Run("normalizes fields by default", func(t *testing.T) {
+		message := NewMessage(spec)
+		require.NoError(t, message.Unpack(rawMsg))
+
+		packed, err := message.Pack()
+		require.NoError(t, err)
+		require.NotEqual(t, rawMsg, packed)
+	})
+
+	t.Run("packs unmodified fields using original bytes", func(t *testing.T) {
+		message := NewMessage(spec)
+		require.NoError(t, message.UnpackWithOptions(rawMsg, WithOriginalBytes()))
+
+		packed, err := message.Pack()
+		require.NoError(t, err)
+		require.Equal(t, string(rawMsg), string(packed))
+
+		packedLen, err := message.PackedLen()
+		require.NoError(t, err)
+		require.Equal(t, len(rawMsg), packedLen)
+
+		// reading fields doesn't modify them
+		pinData, err := message.GetString(52)
+		require.NoError(t, err)
+		require.Equal(t, "0A0B0C0D0E0F1011", pinData)
+
+		packed, err = message.Pack()
+		require.NoError(t, err)
+		require.Equal(t, string(rawMsg), string(packed))
+	})
+
+	t.Run("packs modified fields and subfields", func(t *testing.T) {
+		message := NewMessage(spec)
+		require.NoError(t, message.UnpackWithOptions(rawMsg, WithOriginalBytes()))
+
+		require.NoError(t, message.Field(2, "200"))
+		require.NoError(t, message.MarshalPath("48.01", "CD"))
+
+		packed, err := message.Pack()
+		require.NoError(t, err)
+
+		// order of subfields and the bytes of subfield 02 are preserved
+		expected := "0100" + "4000000000011000" +
+			"03200" +
+			"015" + "0205hello" + "0102CD" +
+			"0a0b0c0d0e0f1011"
+		require.Equal(t, expected, string(packed))
+
+		message.UnsetField(52)
+		require.NoError(t, message.MarshalPath("52", "0a0b0c0d0e0f1011"))
+
+		packed, err = message.Pack()
+		require.NoError(t, err)
+		require.Equal(t, "0A0B0C0D0E0F1011", string(packed[len(packed)-16:]))
+	})
+
+	t.Run("works with lazy unpacking", func(t *testing.T) {
+		message := NewMessage(spec)
+		require.NoError(t, message.UnpackWithOptions(rawMsg, WithLazyUnpacking(), WithOriginalBytes()))
+
+		var subfield string
+		require.NoError(t, message.UnmarshalPath("48.02", &subfield))
+		require.Equal(t, "hello", subfield)
+
+		packed, err := message.Pack()
+		require.NoError(t, err)
+		require.Equal(t, string(rawMsg), string(packed))
+
+		require.NoError(t, message.MarshalPath("48.02", "world"))
+
+		packed, err = message.Pack()
+		require.NoError(t, err)
+		require.Contains(t, string(packed), "015"+"0205world"+"0102AB")
+	})
+}
+
+func TestUnpackWithLenientUnpacking(t *testing.T) {
+	spec := &MessageSpec{
+		Fields: map[int]field.Field{
+			0: field.NewString(&field.Spec{
+				Length:      4,
+				Description: "Message Type Indicator",
+				Enc:         encoding.ASCII,
+				Pref:        prefix.ASCII.Fixed,
+			}),
+			1: field.NewBitmap(&field.Spec{
+				Length:      8,
+				Description: "Bitmap",
+				Enc:         encoding.BytesToASCIIHex,
+				Pref:        prefix.Hex.Fixed,
+			}),
+			2: field.NewString(&field.Spec{
+				Length:      19,
+				Description: "Primary Account Number",
+				Enc:         encoding.ASCII,
+				Pref:        prefix.ASCII.LL,
+			}),
+			3: field.NewNumeric(&field.Spec{
+				Length:      6,
+				Description: "Processing Code",
+				Enc:         encoding.ASCII,
+				Pref:        prefix.ASCII.Fixed,
+			}),
+			4: field.NewString(&field.Spec{
+				Length:      12,
+				Description: "Transaction Amount",
+				Enc:         encoding.ASCII,
+				Pref:        prefix.ASCII.Fixed,
+				Pad:         padding.Left('0'),
+			}),
+			48: field.NewComposite(&field.Spec{
+				Length:      999,
+				Description: "Additional Data",
+				Pref:        prefix.ASCII.LLL,
+				Tag: &field.TagSpec{
+					Length: 2,
+					Enc:    encoding.ASCII,
+					Sort:   sort.StringsByInt,
+				},
+				Subfields: map[string]field.Field{
+					"01": field.NewNumeric(&field.Spec{
+						Length:      2,
+						Description: "Subfield 1",
+						Enc:         encoding.ASCII,
+						Pref:        prefix.ASCII.Fixed,
+					}),
+					"02": field.NewString(&field.Spec{
+						Length:      10,
+						Description: "Subfield 2",
+						Enc:         encoding.ASCII,
+						Pref:        prefix.ASCII.LL,
+					}),
+					"03": field.NewNumeric(&field.Spec{
+						Length:      2,
+						Description: "Subfield 3",
+						Enc:         encoding.ASCII,
+						Pref:        prefix.ASCII.Fixed,
+					}),
+				},
+			}),
+		},
+	}
+
+	// field 3 and subfields 48.01 and 48.03 are not numeric
+	rawMsg := []byte("0100" + "7000000000010000" +
+		"164242424242424242" +
+		"12X456" +
+		"000000000100" +
+		"017" + "01XY" + "0205hello" + "03ZZ")
+
+	t.Run("returns the first error by default", func(t *testing.T) {
+		message := NewMessage(spec)
+		err := message.Unpack(rawMsg)
+
+		unpackErrs := iso8583errors.UnpackErrors(err)
+		require.Len(t, unpackErrs, 1)
+		require.Equal(t, []string{"3"}, unpackErrs[0].FieldIDs())
+	})
+
+	t.Run("returns errors of all fields and keeps unpacked fields", func(t *testing.T) {
+		message := NewMessage(spec)
+		err := message.UnpackWithOptions(rawMsg, WithLenientUnpacking())
+		require.Error(t, err)
+
+		var unpackError *iso8583errors.UnpackError
+		require.ErrorAs(t, err, &unpackError)
+
+		unpackErrs := iso8583errors.UnpackErrors(err)
+		require.Len(t, unpackErrs, 3)
+		require.Equal(t, []string{"3"}, unpackErrs[0].FieldIDs())
+		require.Equal(t, []string{"48", "01"}, unpackErrs[1].FieldIDs())
+		require.Equal(t, []string{"48", "03"}, unpackErrs[2].FieldIDs())
+		require.Equal(t, "017"+"01XY"+"0205hello"+"03ZZ", string(unpackErrs[1].RawMessage))
+
+		pan, err := message.GetString(2)
+		require.NoError(t, err)
+		require.Equal(t, "4242424242424242", pan)
+
+		amount, err := message.GetString(4)
+		require.NoError(t, err)
+		require.Equal(t, "100", amount)
+
+		var subfield string
+		require.NoError(t, message.UnmarshalPath("48.02", &subfield))
+		require.Equal(t, "hello", subfield)
+
+		require.Nil(t, message.GetField(3))
+		require.NotContains(t, message.GetField(48).(*field.Composite).GetSubfields(), "01")
+
+		var buf bytes.Buffer
+		require.NoError(t, Describe(message, &buf))
+		require.Contains(t, buf.String(), "4242****4242")
+		require.Contains(t, buf.String(), "hello")
+	})
+
+	t.Run("stops when length of the field can't be determined", func(t *testing.T) {
+		message := NewMessage(spec)
+		err := message.UnpackWithOptions(rawMsg[:len(rawMsg)-1], WithLenientUnpacking())
+
+		unpackErrs := iso8583errors.UnpackErrors(err)
+		require.Len(t, unpackErrs, 2)
+		require.Equal(t, []string{"3"}, unpackErrs[0].FieldIDs())
+		require.Equal(t, []string{"48"}, unpackErrs[1].FieldIDs())
+		require.Contains(t, unpackErrs[1].Error(), "not enough data")
+
+		pan, err := message.GetString(2)
+		require.NoError(t, err)
+		require.Equal(t, "4242424242424242", pan)
+	})
+}